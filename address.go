@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/bech32"
@@ -52,18 +53,71 @@ func AddressSelector(addressType uint32) (serializer.Serializable, error) {
 	return newAddress(byte(addressType))
 }
 
+// AddressCodec defines how addresses of a given AddressType are created and encoded into their Bech32 form.
+type AddressCodec struct {
+	// New returns a new empty address of the type.
+	New func() Address
+	// The codec used to encode and decode the Bech32 form of the address.
+	Bech32 bech32.Codec
+}
+
+var (
+	addressCodecsMu sync.RWMutex
+	addressCodecs   = map[AddressType]AddressCodec{
+		AddressEd25519: {New: func() Address { return &Ed25519Address{} }},
+	}
+)
+
+// RegisterAddressCodec registers the AddressCodec for the given AddressType, replacing a previously registered one.
+// Registered address types are supported by AddressSelector and ParseBech32.
+func RegisterAddressCodec(addrType AddressType, codec AddressCodec) {
+	if codec.New == nil {
+		panic("address codec must define a constructor")
+	}
+	addressCodecsMu.Lock()
+	defer addressCodecsMu.Unlock()
+	addressCodecs[addrType] = codec
+}
+
+// AddressCodecByType returns the AddressCodec registered for the given AddressType.
+func AddressCodecByType(addrType AddressType) (AddressCodec, error) {
+	addressCodecsMu.RLock()
+	defer addressCodecsMu.RUnlock()
+	codec, has := addressCodecs[addrType]
+	if !has {
+		return AddressCodec{}, fmt.Errorf("%w: type %d", ErrUnknownAddrType, addrType)
+	}
+	return codec, nil
+}
+
+// returns the maximum Bech32 length of all registered address types.
+func maxAddressBech32Length() int {
+	addressCodecsMu.RLock()
+	defer addressCodecsMu.RUnlock()
+	maxLength := 0
+	for _, codec := range addressCodecs {
+		if codec.Bech32.Limit() > maxLength {
+			maxLength = codec.Bech32.Limit()
+		}
+	}
+	return maxLength
+}
+
 func newAddress(addressType byte) (address Address, err error) {
-	switch addressType {
-	case AddressEd25519:
-		return &Ed25519Address{}, nil
-	default:
-		return nil, fmt.Errorf("%w: type %d", ErrUnknownAddrType, addressType)
+	codec, err := AddressCodecByType(addressType)
+	if err != nil {
+		return nil, err
 	}
+	return codec.New(), nil
 }
 
 func bech32String(hrp NetworkPrefix, addr Address) string {
+	codec, err := AddressCodecByType(addr.Type())
+	if err != nil {
+		panic(err)
+	}
 	bytes, _ := addr.Serialize(serializer.DeSeriModeNoValidation)
-	s, err := bech32.Encode(string(hrp), bytes)
+	s, err := codec.Bech32.Encode(string(hrp), bytes)
 	if err != nil {
 		panic(err)
 	}
@@ -71,8 +125,9 @@ func bech32String(hrp NetworkPrefix, addr Address) string {
 }
 
 // ParseBech32 decodes a bech32 encoded string.
+// The string must use the checksum variant and length limit of the AddressCodec registered for the address type.
 func ParseBech32(s string) (NetworkPrefix, Address, error) {
-	hrp, addrData, err := bech32.Decode(s)
+	hrp, addrData, variant, err := bech32.Codec{MaxLength: maxAddressBech32Length()}.Decode(s)
 	if err != nil {
 		return "", nil, fmt.Errorf("invalid bech32 encoding: %w", err)
 	}
@@ -81,11 +136,18 @@ func ParseBech32(s string) (NetworkPrefix, Address, error) {
 		return "", nil, serializer.ErrDeserializationNotEnoughData
 	}
 
-	addr, err := newAddress(addrData[0])
+	codec, err := AddressCodecByType(addrData[0])
 	if err != nil {
 		return "", nil, err
 	}
+	if variant != codec.Bech32.Variant {
+		return "", nil, fmt.Errorf("invalid bech32 encoding: %w: address type %d requires a %s checksum but got %s", bech32.ErrInvalidChecksum, addrData[0], codec.Bech32.Variant, variant)
+	}
+	if len(s) > codec.Bech32.Limit() {
+		return "", nil, fmt.Errorf("invalid bech32 encoding: %w: maximum length of address type %d exceeded", bech32.ErrInvalidLength, addrData[0])
+	}
 
+	addr := codec.New()
 	n, err := addr.Deserialize(addrData, serializer.DeSeriModePerformValidation)
 	if err != nil {
 		return "", nil, err
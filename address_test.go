@@ -2,11 +2,13 @@ package iotago_test
 
 import (
 	"errors"
+	"fmt"
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/tpkg"
 	"testing"
 
 	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/bech32"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -107,3 +109,71 @@ func TestParseBech32(t *testing.T) {
 		})
 	}
 }
+
+// testAddress is an address type only used to test the AddressCodec registry.
+type testAddress [20]byte
+
+const testAddressType iotago.AddressType = 0xf0
+
+func (a *testAddress) Type() iotago.AddressType { return testAddressType }
+
+func (a *testAddress) Bech32(hrp iotago.NetworkPrefix) string {
+	codec, _ := iotago.AddressCodecByType(testAddressType)
+	data, _ := a.Serialize(serializer.DeSeriModeNoValidation)
+	s, _ := codec.Bech32.Encode(string(hrp), data)
+	return s
+}
+
+func (a *testAddress) String() string { return fmt.Sprintf("%x", a[:]) }
+
+func (a *testAddress) Deserialize(data []byte, _ serializer.DeSerializationMode) (int, error) {
+	if err := serializer.CheckMinByteLength(1+len(a), len(data)); err != nil {
+		return 0, err
+	}
+	copy(a[:], data[1:])
+	return 1 + len(a), nil
+}
+
+func (a *testAddress) Serialize(_ serializer.DeSerializationMode) ([]byte, error) {
+	return append([]byte{testAddressType}, a[:]...), nil
+}
+
+func (a *testAddress) MarshalJSON() ([]byte, error) { return nil, nil }
+
+func (a *testAddress) UnmarshalJSON([]byte) error { return nil }
+
+func TestRegisterAddressCodec(t *testing.T) {
+	_, err := iotago.AddressCodecByType(testAddressType)
+	assert.True(t, errors.Is(err, iotago.ErrUnknownAddrType))
+
+	iotago.RegisterAddressCodec(testAddressType, iotago.AddressCodec{
+		New:    func() iotago.Address { return &testAddress{} },
+		Bech32: bech32.Codec{Variant: bech32.Bech32m},
+	})
+
+	addr := &testAddress{1, 2, 3}
+	s := addr.Bech32(iotago.PrefixTestnet)
+	network, parsedAddr, err := iotago.ParseBech32(s)
+	assert.NoError(t, err)
+	assert.Equal(t, iotago.PrefixTestnet, network)
+	assert.Equal(t, addr, parsedAddr)
+
+	seri, err := iotago.AddressSelector(uint32(testAddressType))
+	assert.NoError(t, err)
+	assert.IsType(t, &testAddress{}, seri)
+
+	// the registered address type requires the Bech32m checksum
+	data, _ := addr.Serialize(serializer.DeSeriModeNoValidation)
+	bech32Str, err := bech32.Encode(string(iotago.PrefixTestnet), data)
+	assert.NoError(t, err)
+	_, _, err = iotago.ParseBech32(bech32Str)
+	assert.True(t, errors.Is(err, bech32.ErrInvalidChecksum))
+
+	// and Ed25519 addresses the original one
+	edAddr, _ := tpkg.RandEd25519Address()
+	edData, _ := edAddr.Serialize(serializer.DeSeriModeNoValidation)
+	bech32mStr, err := bech32.Codec{Variant: bech32.Bech32m}.Encode(string(iotago.PrefixMainnet), edData)
+	assert.NoError(t, err)
+	_, _, err = iotago.ParseBech32(bech32mStr)
+	assert.True(t, errors.Is(err, bech32.ErrInvalidChecksum))
+}
@@ -0,0 +1,450 @@
+// Package archive provides a local archive of messages and their metadata, indexed by message ID, indexation index,
+// address outputs and milestone.
+//
+// An Archive stores its data in a KVStore, which can be held in memory or persisted to a file, and answers queries
+// with the same response types as the iotago.NodeHTTPAPIClient:
+//
+//	store, err := archive.OpenFileKVStore("messages.db")
+//	a := archive.New(store)
+//	err = a.Store(msg, metadata)
+//	res, err := a.MessageIDsByIndex(ctx, []byte("my index"))
+//
+// The archived tangle can be walked with a traversal.Walker over the Archive's Storage.
+package archive
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/iotaledger/hive.go/serializer"
+
+	"github.com/iotaledger/iota.go/v2"
+)
+
+var (
+	// ErrNotFound gets returned when the Archive does not hold the queried data.
+	// It wraps iotago.ErrHTTPNotFound so that callers handle archive and node misses alike.
+	ErrNotFound = fmt.Errorf("%w: not in archive", iotago.ErrHTTPNotFound)
+)
+
+// the prefixes of the keys in the KVStore.
+const (
+	// message ID -> serialized message
+	keyPrefixMessage byte = iota
+	// message ID -> JSON metadata
+	keyPrefixMetadata
+	// parent message ID + child message ID -> empty
+	keyPrefixChildren
+	// index length + index + message ID -> empty
+	keyPrefixIndexation
+	// output ID -> JSON node output response
+	keyPrefixOutput
+	// serialized address + output ID -> empty
+	keyPrefixAddressOutputs
+	// big endian milestone index -> JSON milestone response
+	keyPrefixMilestone
+	// empty -> big endian index of the latest milestone
+	keyPrefixLatestMilestone
+)
+
+func key(prefix byte, parts ...[]byte) []byte {
+	k := []byte{prefix}
+	for _, part := range parts {
+		k = append(k, part...)
+	}
+	return k
+}
+
+func milestoneIndexBytes(index uint32) []byte {
+	b := make([]byte, serializer.UInt32ByteSize)
+	binary.BigEndian.PutUint32(b, index)
+	return b
+}
+
+// the default options applied to the Archive.
+var defaultArchiveOptions = []ArchiveOption{
+	WithMaxResults(1000),
+}
+
+// ArchiveOptions define options for the Archive.
+type ArchiveOptions struct {
+	// The max. amount of results returned by list queries.
+	maxResults uint32
+}
+
+// applies the given ArchiveOption.
+func (ao *ArchiveOptions) apply(opts ...ArchiveOption) {
+	for _, opt := range opts {
+		opt(ao)
+	}
+}
+
+// WithMaxResults sets the max. amount of results returned by list queries, such as MessageIDsByIndex.
+func WithMaxResults(maxResults uint32) ArchiveOption {
+	return func(opts *ArchiveOptions) {
+		opts.maxResults = maxResults
+	}
+}
+
+// ArchiveOption is a function setting an Archive option.
+type ArchiveOption func(opts *ArchiveOptions)
+
+// New creates a new Archive storing its data in the given KVStore.
+func New(store KVStore, opts ...ArchiveOption) *Archive {
+	options := &ArchiveOptions{}
+	options.apply(defaultArchiveOptions...)
+	options.apply(opts...)
+	return &Archive{store: store, opts: options}
+}
+
+// Archive is a local store of messages and their metadata.
+type Archive struct {
+	store KVStore
+	opts  *ArchiveOptions
+	// latestMilestoneMu serializes the updates of the latest milestone index.
+	latestMilestoneMu sync.Mutex
+}
+
+// Close closes the underlying KVStore.
+func (a *Archive) Close() error {
+	return a.store.Close()
+}
+
+// Store archives the given message and its metadata, which might be nil, and returns the ID of the message.
+// Storing a message again updates its metadata. The outputs of a transaction are indexed once its metadata
+// denotes it as included, which also marks the archived outputs it consumes as spent.
+func (a *Archive) Store(msg *iotago.Message, metadata *iotago.MessageMetadataResponse) (iotago.MessageID, error) {
+	msgID, err := msg.ID()
+	if err != nil {
+		return iotago.MessageID{}, fmt.Errorf("unable to compute message ID: %w", err)
+	}
+	msgData, err := msg.Serialize(serializer.DeSeriModePerformValidation)
+	if err != nil {
+		return iotago.MessageID{}, fmt.Errorf("unable to serialize message: %w", err)
+	}
+
+	if err := a.store.Set(key(keyPrefixMessage, msgID[:]), msgData); err != nil {
+		return *msgID, err
+	}
+	for _, parent := range msg.Parents {
+		if err := a.store.Set(key(keyPrefixChildren, parent[:], msgID[:]), nil); err != nil {
+			return *msgID, err
+		}
+	}
+
+	if metadata != nil {
+		metadataJSON, err := json.Marshal(metadata)
+		if err != nil {
+			return *msgID, fmt.Errorf("unable to marshal metadata: %w", err)
+		}
+		if err := a.store.Set(key(keyPrefixMetadata, msgID[:]), metadataJSON); err != nil {
+			return *msgID, err
+		}
+	}
+
+	switch payload := msg.Payload.(type) {
+	case *iotago.Indexation:
+		err = a.storeIndexation(*msgID, payload)
+	case *iotago.Transaction:
+		essence, ok := payload.Essence.(*iotago.TransactionEssence)
+		if !ok {
+			return *msgID, fmt.Errorf("%w: unknown transaction essence type %T", iotago.ErrUnknownTransactionEssenceType, payload.Essence)
+		}
+		if indexation, ok := essence.Payload.(*iotago.Indexation); ok {
+			if err := a.storeIndexation(*msgID, indexation); err != nil {
+				return *msgID, err
+			}
+		}
+		if metadata != nil && metadata.LedgerInclusionState != nil &&
+			iotago.LedgerInclusionState(*metadata.LedgerInclusionState) == iotago.LedgerInclusionStateIncluded {
+			err = a.storeTransaction(*msgID, payload, essence, metadata)
+		}
+	case *iotago.Milestone:
+		err = a.storeMilestone(*msgID, payload)
+	}
+	return *msgID, err
+}
+
+func (a *Archive) storeIndexation(msgID iotago.MessageID, indexation *iotago.Indexation) error {
+	return a.store.Set(key(keyPrefixIndexation, []byte{byte(len(indexation.Index))}, indexation.Index, msgID[:]), nil)
+}
+
+func (a *Archive) storeTransaction(msgID iotago.MessageID, tx *iotago.Transaction, essence *iotago.TransactionEssence, metadata *iotago.MessageMetadataResponse) error {
+	txID, err := tx.ID()
+	if err != nil {
+		return fmt.Errorf("unable to compute transaction ID: %w", err)
+	}
+
+	var ledgerIndex uint64
+	if metadata.ReferencedByMilestoneIndex != nil {
+		ledgerIndex = uint64(*metadata.ReferencedByMilestoneIndex)
+	}
+
+	for i, output := range essence.Outputs {
+		utxoID := (&iotago.UTXOInput{TransactionID: *txID, TransactionOutputIndex: uint16(i)}).ID()
+		// outputs stored before are kept as they might have been spent since
+		if _, err := a.store.Get(key(keyPrefixOutput, utxoID[:])); err == nil {
+			continue
+		}
+
+		outputJSON, err := json.Marshal(output)
+		if err != nil {
+			return fmt.Errorf("unable to marshal output %d: %w", i, err)
+		}
+		rawOutput := json.RawMessage(outputJSON)
+		if err := a.setOutput(utxoID, &iotago.NodeOutputResponse{
+			MessageID:     iotago.MessageIDToHexString(msgID),
+			TransactionID: hex.EncodeToString(txID[:]),
+			OutputIndex:   uint16(i),
+			LedgerIndex:   ledgerIndex,
+			RawOutput:     &rawOutput,
+		}); err != nil {
+			return err
+		}
+
+		out, ok := output.(iotago.Output)
+		if !ok {
+			continue
+		}
+		target, err := out.Target()
+		if err != nil || target == nil {
+			continue
+		}
+		addrData, err := target.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			return fmt.Errorf("unable to serialize address of output %d: %w", i, err)
+		}
+		if err := a.store.Set(key(keyPrefixAddressOutputs, addrData, utxoID[:]), nil); err != nil {
+			return err
+		}
+	}
+
+	for _, input := range essence.Inputs {
+		utxoInput, ok := input.(*iotago.UTXOInput)
+		if !ok {
+			continue
+		}
+		res, err := a.outputByID(utxoInput.ID())
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		res.Spent = true
+		if err := a.setOutput(utxoInput.ID(), res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *Archive) setOutput(utxoID iotago.UTXOInputID, res *iotago.NodeOutputResponse) error {
+	resJSON, err := json.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("unable to marshal output %s: %w", utxoID.ToHex(), err)
+	}
+	return a.store.Set(key(keyPrefixOutput, utxoID[:]), resJSON)
+}
+
+func (a *Archive) storeMilestone(msgID iotago.MessageID, ms *iotago.Milestone) error {
+	resJSON, err := json.Marshal(&iotago.MilestoneResponse{
+		Index:     ms.Index,
+		MessageID: iotago.MessageIDToHexString(msgID),
+		Time:      int64(ms.Timestamp),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to marshal milestone %d: %w", ms.Index, err)
+	}
+	if err := a.store.Set(key(keyPrefixMilestone, milestoneIndexBytes(ms.Index)), resJSON); err != nil {
+		return err
+	}
+
+	a.latestMilestoneMu.Lock()
+	defer a.latestMilestoneMu.Unlock()
+	latest, err := a.latestMilestoneIndex()
+	if err != nil || latest >= ms.Index {
+		return err
+	}
+	return a.store.Set(key(keyPrefixLatestMilestone), milestoneIndexBytes(ms.Index))
+}
+
+// gets and unmarshals the JSON value of the given key.
+func (a *Archive) getJSON(k []byte, target interface{}) error {
+	value, err := a.store.Get(k)
+	if errors.Is(err, ErrKeyNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(value, target)
+}
+
+// MessageByMessageID gets the message with the given ID from the archive.
+func (a *Archive) MessageByMessageID(_ context.Context, msgID iotago.MessageID) (*iotago.Message, error) {
+	msgData, err := a.store.Get(key(keyPrefixMessage, msgID[:]))
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, fmt.Errorf("%w: message %s", ErrNotFound, iotago.MessageIDToHexString(msgID))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &iotago.Message{}
+	if _, err := msg.Deserialize(msgData, serializer.DeSeriModePerformValidation); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// MessageMetadataByMessageID gets the archived metadata of the message with the given ID.
+func (a *Archive) MessageMetadataByMessageID(_ context.Context, msgID iotago.MessageID) (*iotago.MessageMetadataResponse, error) {
+	res := &iotago.MessageMetadataResponse{}
+	if err := a.getJSON(key(keyPrefixMetadata, msgID[:]), res); err != nil {
+		return nil, fmt.Errorf("unable to get metadata of message %s: %w", iotago.MessageIDToHexString(msgID), err)
+	}
+	return res, nil
+}
+
+// ChildrenByMessageID gets the IDs of the archived children of the message with the given ID.
+func (a *Archive) ChildrenByMessageID(_ context.Context, msgID iotago.MessageID) (*iotago.ChildrenResponse, error) {
+	res := &iotago.ChildrenResponse{MessageID: iotago.MessageIDToHexString(msgID), MaxResults: a.opts.maxResults, Children: []string{}}
+	prefix := key(keyPrefixChildren, msgID[:])
+	err := a.store.Iterate(prefix, func(k []byte, _ []byte) bool {
+		if res.Count >= res.MaxResults {
+			return false
+		}
+		res.Children = append(res.Children, hex.EncodeToString(k[len(prefix):]))
+		res.Count++
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MessageIDsByIndex gets the IDs of the archived messages with the given indexation index.
+func (a *Archive) MessageIDsByIndex(_ context.Context, index []byte) (*iotago.MessageIDsByIndexResponse, error) {
+	res := &iotago.MessageIDsByIndexResponse{Index: hex.EncodeToString(index), MaxResults: a.opts.maxResults, MessageIDs: []string{}}
+	prefix := key(keyPrefixIndexation, []byte{byte(len(index))}, index)
+	err := a.store.Iterate(prefix, func(k []byte, _ []byte) bool {
+		if res.Count >= res.MaxResults {
+			return false
+		}
+		res.MessageIDs = append(res.MessageIDs, hex.EncodeToString(k[len(prefix):]))
+		res.Count++
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *Archive) outputByID(utxoID iotago.UTXOInputID) (*iotago.NodeOutputResponse, error) {
+	res := &iotago.NodeOutputResponse{}
+	if err := a.getJSON(key(keyPrefixOutput, utxoID[:]), res); err != nil {
+		return nil, fmt.Errorf("unable to get output %s: %w", utxoID.ToHex(), err)
+	}
+	return res, nil
+}
+
+// OutputByID gets the archived output with the given ID.
+func (a *Archive) OutputByID(_ context.Context, utxoID iotago.UTXOInputID) (*iotago.NodeOutputResponse, error) {
+	return a.outputByID(utxoID)
+}
+
+// OutputIDsByEd25519Address gets the IDs of the archived outputs residing on the given Ed25519Address.
+// Per default only unspent output IDs are returned. Set includeSpentOutputs to true to also return spent output IDs.
+func (a *Archive) OutputIDsByEd25519Address(_ context.Context, addr *iotago.Ed25519Address, includeSpentOutputs bool) (*iotago.AddressOutputsResponse, error) {
+	return a.outputIDsByAddress(addr, includeSpentOutputs)
+}
+
+// OutputIDsByBech32Address gets the IDs of the archived outputs residing on the given Bech32 address.
+// Per default only unspent output IDs are returned. Set includeSpentOutputs to true to also return spent output IDs.
+func (a *Archive) OutputIDsByBech32Address(_ context.Context, bech32Addr string, includeSpentOutputs bool) (*iotago.AddressOutputsResponse, error) {
+	_, addr, err := iotago.ParseBech32(bech32Addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bech32 address: %w", err)
+	}
+	return a.outputIDsByAddress(addr, includeSpentOutputs)
+}
+
+func (a *Archive) outputIDsByAddress(addr iotago.Address, includeSpentOutputs bool) (*iotago.AddressOutputsResponse, error) {
+	addrData, err := addr.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize address: %w", err)
+	}
+	ledgerIndex, err := a.latestMilestoneIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &iotago.AddressOutputsResponse{
+		AddressType: addr.Type(),
+		Address:     hex.EncodeToString(addrData[serializer.SmallTypeDenotationByteSize:]),
+		MaxResults:  a.opts.maxResults,
+		OutputIDs:   []iotago.OutputIDHex{},
+		LedgerIndex: uint64(ledgerIndex),
+	}
+	prefix := key(keyPrefixAddressOutputs, addrData)
+	var iterErr error
+	err = a.store.Iterate(prefix, func(k []byte, _ []byte) bool {
+		if res.Count >= res.MaxResults {
+			return false
+		}
+		var utxoID iotago.UTXOInputID
+		copy(utxoID[:], k[len(prefix):])
+		if !includeSpentOutputs {
+			output, err := a.outputByID(utxoID)
+			if err != nil {
+				iterErr = err
+				return false
+			}
+			if output.Spent {
+				return true
+			}
+		}
+		res.OutputIDs = append(res.OutputIDs, iotago.OutputIDHex(utxoID.ToHex()))
+		res.Count++
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if iterErr != nil {
+		return nil, iterErr
+	}
+	return res, nil
+}
+
+// MilestoneByIndex gets the archived milestone with the given index.
+func (a *Archive) MilestoneByIndex(_ context.Context, index uint32) (*iotago.MilestoneResponse, error) {
+	res := &iotago.MilestoneResponse{}
+	if err := a.getJSON(key(keyPrefixMilestone, milestoneIndexBytes(index)), res); err != nil {
+		return nil, fmt.Errorf("unable to get milestone %d: %w", index, err)
+	}
+	return res, nil
+}
+
+// returns the index of the latest archived milestone or 0 if no milestone is archived.
+func (a *Archive) latestMilestoneIndex() (uint32, error) {
+	indexBytes, err := a.store.Get(key(keyPrefixLatestMilestone))
+	if errors.Is(err, ErrKeyNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(indexBytes) != serializer.UInt32ByteSize {
+		return 0, fmt.Errorf("%w: invalid latest milestone index", ErrCorruptedKVStore)
+	}
+	return binary.BigEndian.Uint32(indexBytes), nil
+}
@@ -0,0 +1,121 @@
+package archive_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/archive"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+func includedMetadata(index uint32) *iotago.MessageMetadataResponse {
+	included := string(iotago.LedgerInclusionStateIncluded)
+	return &iotago.MessageMetadataResponse{ReferencedByMilestoneIndex: &index, LedgerInclusionState: &included}
+}
+
+func TestArchive(t *testing.T) {
+	ctx := context.Background()
+	a := archive.New(archive.NewMemoryKVStore(), archive.WithMaxResults(2))
+	defer a.Close()
+
+	_, err := a.MessageByMessageID(ctx, tpkg.Rand32ByteArray())
+	assert.True(t, errors.Is(err, archive.ErrNotFound))
+	assert.True(t, errors.Is(err, iotago.ErrHTTPNotFound))
+
+	// indexation messages
+	parents := tpkg.SortedRand32BytArray(1)
+	indexed := func(index string, data byte) iotago.MessageID {
+		msgID, err := a.Store(&iotago.Message{Parents: parents, Payload: &iotago.Indexation{Index: []byte(index), Data: []byte{data}}}, nil)
+		require.NoError(t, err)
+		return msgID
+	}
+	a1, a2, a3, b := indexed("a", 1), indexed("a", 2), indexed("a", 3), indexed("ab", 1)
+
+	res, err := a.MessageIDsByIndex(ctx, []byte("a"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, res.Count)
+	assert.Subset(t, []string{iotago.MessageIDToHexString(a1), iotago.MessageIDToHexString(a2), iotago.MessageIDToHexString(a3)}, res.MessageIDs)
+	res, err = a.MessageIDsByIndex(ctx, []byte("ab"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{iotago.MessageIDToHexString(b)}, res.MessageIDs)
+
+	children, err := a.ChildrenByMessageID(ctx, parents[0])
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, children.Count)
+
+	msg, err := a.MessageByMessageID(ctx, b)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ab"), msg.Payload.(*iotago.Indexation).Index)
+	_, err = a.MessageMetadataByMessageID(ctx, b)
+	assert.True(t, errors.Is(err, archive.ErrNotFound))
+
+	// milestones
+	ms, _ := tpkg.RandMilestone(parents)
+	msID, err := a.Store(&iotago.Message{Parents: parents, Payload: ms}, nil)
+	require.NoError(t, err)
+	msRes, err := a.MilestoneByIndex(ctx, ms.Index)
+	require.NoError(t, err)
+	assert.Equal(t, &iotago.MilestoneResponse{Index: ms.Index, MessageID: iotago.MessageIDToHexString(msID), Time: int64(ms.Timestamp)}, msRes)
+	_, err = a.MilestoneByIndex(ctx, ms.Index+1)
+	assert.True(t, errors.Is(err, archive.ErrNotFound))
+
+	// an older milestone does not change the ledger index
+	older, _ := tpkg.RandMilestone(parents)
+	older.Index = ms.Index / 2
+	_, err = a.Store(&iotago.Message{Parents: parents, Payload: older}, nil)
+	require.NoError(t, err)
+
+	// transactions, tx2 spends the output of tx1
+	tx1 := tpkg.OneInputOutputTransaction()
+	addr := tx1.Essence.(*iotago.TransactionEssence).Outputs[0].(*iotago.SigLockedSingleOutput).Address.(*iotago.Ed25519Address)
+	tx1ID, err := tx1.ID()
+	require.NoError(t, err)
+	tx1MsgID, err := a.Store(&iotago.Message{Parents: parents, Payload: tx1}, nil)
+	require.NoError(t, err)
+
+	outputs, err := a.OutputIDsByEd25519Address(ctx, addr, true)
+	require.NoError(t, err)
+	assert.Empty(t, outputs.OutputIDs)
+
+	_, err = a.Store(&iotago.Message{Parents: parents, Payload: tx1}, includedMetadata(ms.Index))
+	require.NoError(t, err)
+	metadata, err := a.MessageMetadataByMessageID(ctx, tx1MsgID)
+	require.NoError(t, err)
+	assert.EqualValues(t, ms.Index, *metadata.ReferencedByMilestoneIndex)
+
+	outputID := (&iotago.UTXOInput{TransactionID: *tx1ID}).ID()
+	outputs, err = a.OutputIDsByBech32Address(ctx, addr.Bech32(iotago.PrefixTestnet), false)
+	require.NoError(t, err)
+	assert.Equal(t, []iotago.OutputIDHex{iotago.OutputIDHex(outputID.ToHex())}, outputs.OutputIDs)
+	assert.Equal(t, addr.String(), outputs.Address)
+	assert.EqualValues(t, ms.Index, outputs.LedgerIndex)
+
+	output, err := a.OutputByID(ctx, outputID)
+	require.NoError(t, err)
+	assert.False(t, output.Spent)
+	assert.Equal(t, iotago.MessageIDToHexString(tx1MsgID), output.MessageID)
+	out, err := output.Output()
+	require.NoError(t, err)
+	assert.Equal(t, tx1.Essence.(*iotago.TransactionEssence).Outputs[0], out)
+
+	tx2 := tpkg.OneInputOutputTransaction()
+	tx2.Essence.(*iotago.TransactionEssence).Inputs = serializer.Serializables{&iotago.UTXOInput{TransactionID: *tx1ID}}
+	_, err = a.Store(&iotago.Message{Parents: parents, Payload: tx2}, includedMetadata(ms.Index))
+	require.NoError(t, err)
+
+	output, err = a.OutputByID(ctx, outputID)
+	require.NoError(t, err)
+	assert.True(t, output.Spent)
+	outputs, err = a.OutputIDsByEd25519Address(ctx, addr, false)
+	require.NoError(t, err)
+	assert.Empty(t, outputs.OutputIDs)
+	outputs, err = a.OutputIDsByEd25519Address(ctx, addr, true)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, outputs.Count)
+}
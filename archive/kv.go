@@ -0,0 +1,270 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/iotaledger/hive.go/serializer"
+)
+
+var (
+	// ErrKeyNotFound gets returned by a KVStore when it does not hold a key.
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrCorruptedKVStore gets returned when the file of a file-based KVStore can not be read.
+	ErrCorruptedKVStore = errors.New("corrupted kv store")
+)
+
+// KVStore is the key-value backend of an Archive.
+type KVStore interface {
+	// Get returns the value of the given key or an error wrapping ErrKeyNotFound.
+	Get(key []byte) ([]byte, error)
+	// Set sets the value of the given key.
+	Set(key []byte, value []byte) error
+	// Iterate calls the given function with the keys having the given prefix and their values in lexical order of the keys
+	// until the function returns false.
+	Iterate(prefix []byte, f func(key []byte, value []byte) bool) error
+	// Close closes the KVStore.
+	Close() error
+}
+
+// NewMemoryKVStore creates a new empty in-memory KVStore.
+func NewMemoryKVStore() *MemoryKVStore {
+	return &MemoryKVStore{values: make(map[string][]byte), keys: newSkipList()}
+}
+
+// the amount of keys read per lock acquisition while iterating.
+const iterateBatchSize = 128
+
+// MemoryKVStore is a KVStore holding its values in memory.
+// Its keys are kept ordered, so that iterating a prefix only visits the keys having the prefix.
+type MemoryKVStore struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+	keys   *skipList
+}
+
+func (s *MemoryKVStore) Get(key []byte) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, has := s.values[string(key)]
+	if !has {
+		return nil, fmt.Errorf("%w: %x", ErrKeyNotFound, key)
+	}
+	return append([]byte{}, value...), nil
+}
+
+func (s *MemoryKVStore) Set(key []byte, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set(string(key), append([]byte{}, value...))
+	return nil
+}
+
+// sets the given value without locking or copying it.
+func (s *MemoryKVStore) set(key string, value []byte) {
+	if _, has := s.values[key]; !has {
+		s.keys.insert(key)
+	}
+	s.values[key] = value
+}
+
+func (s *MemoryKVStore) Iterate(prefix []byte, f func(key []byte, value []byte) bool) error {
+	from := string(prefix)
+	for {
+		keys := make([]string, 0, iterateBatchSize)
+		values := make([][]byte, 0, iterateBatchSize)
+		s.mu.RLock()
+		for node := s.keys.seek(from); node != nil && len(keys) < iterateBatchSize && strings.HasPrefix(node.key, string(prefix)); node = node.next[0] {
+			keys = append(keys, node.key)
+			values = append(values, s.values[node.key])
+		}
+		s.mu.RUnlock()
+
+		// f is called without holding the lock so that it can access the store
+		for i, key := range keys {
+			if !f([]byte(key), append([]byte{}, values[i]...)) {
+				return nil
+			}
+		}
+		if len(keys) < iterateBatchSize {
+			return nil
+		}
+		// continues with the smallest key following the last one
+		from = keys[len(keys)-1] + "\x00"
+	}
+}
+
+func (s *MemoryKVStore) Close() error {
+	return nil
+}
+
+// OpenFileKVStore opens the file-based KVStore at the given path, creating the file if it does not exist.
+//
+// A FileKVStore is a persisted in-memory store: the file is an append-only log of the set keys and values,
+// which is read into memory on opening, so the store can not hold more data than fits into memory.
+// Every Set appends a record, also when it overwrites a key, until the log is compacted via Compact.
+// Every Set is synced to disk before it returns; a record torn by a crash during a Set is discarded on opening.
+func OpenFileKVStore(path string) (*FileKVStore, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open kv store file: %w", err)
+	}
+
+	store := &FileKVStore{MemoryKVStore: NewMemoryKVStore(), file: file}
+	if err := store.load(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// FileKVStore is a MemoryKVStore persisting its values to a file.
+type FileKVStore struct {
+	*MemoryKVStore
+	fileMu sync.Mutex
+	file   *os.File
+}
+
+// reads the records of the log into memory and truncates a torn trailing record left by an interrupted Set.
+func (s *FileKVStore) load() error {
+	reader := bufio.NewReader(s.file)
+	var offset int64
+	for {
+		key, err := readRecordField(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return s.truncate(offset)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: unable to read key: %s", ErrCorruptedKVStore, err)
+		}
+		value, err := readRecordField(reader)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return s.truncate(offset)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: unable to read value of key %x: %s", ErrCorruptedKVStore, key, err)
+		}
+		s.set(string(key), value)
+		offset += int64(2*serializer.UInt32ByteSize + len(key) + len(value))
+	}
+}
+
+// truncates the log to the given size.
+func (s *FileKVStore) truncate(size int64) error {
+	if err := s.file.Truncate(size); err != nil {
+		return fmt.Errorf("unable to truncate torn record of kv store file: %w", err)
+	}
+	return nil
+}
+
+// reads a length prefixed field of a record, returns io.EOF only if no byte was read.
+func readRecordField(reader io.Reader) ([]byte, error) {
+	var length uint32
+	if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+		return nil, err
+	}
+	field := make([]byte, length)
+	if _, err := io.ReadFull(reader, field); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return field, nil
+}
+
+// writes the length prefixed key and value of a record to the given writer.
+func writeRecord(w io.Writer, key []byte, value []byte) error {
+	for _, field := range [][]byte{key, value} {
+		if err := binary.Write(w, binary.LittleEndian, uint32(len(field))); err != nil {
+			return err
+		}
+		if _, err := w.Write(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FileKVStore) Set(key []byte, value []byte) error {
+	var record bytes.Buffer
+	if err := writeRecord(&record, key, value); err != nil {
+		return err
+	}
+
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+	if _, err := s.file.Write(record.Bytes()); err != nil {
+		return fmt.Errorf("unable to append to kv store file: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("unable to sync kv store file: %w", err)
+	}
+	return s.MemoryKVStore.Set(key, value)
+}
+
+// Compact rewrites the log with a single record per key, dropping the records of overwritten values.
+// The compacted log replaces the old one atomically once it is synced to disk.
+func (s *FileKVStore) Compact() error {
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+
+	path := s.file.Name()
+	compactPath := path + ".compact"
+	compactFile, err := os.OpenFile(compactPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("unable to create compacted kv store file: %w", err)
+	}
+	if err := s.writeValues(compactFile); err != nil {
+		_ = compactFile.Close()
+		_ = os.Remove(compactPath)
+		return fmt.Errorf("unable to write compacted kv store file: %w", err)
+	}
+	if err := compactFile.Close(); err != nil {
+		_ = os.Remove(compactPath)
+		return fmt.Errorf("unable to close compacted kv store file: %w", err)
+	}
+	if err := os.Rename(compactPath, path); err != nil {
+		_ = os.Remove(compactPath)
+		return fmt.Errorf("unable to replace kv store file: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("unable to reopen kv store file: %w", err)
+	}
+	_ = s.file.Close()
+	s.file = file
+	return nil
+}
+
+// writes and syncs a record for every key to the given file.
+func (s *FileKVStore) writeValues(file *os.File) error {
+	writer := bufio.NewWriter(file)
+	s.mu.RLock()
+	for node := s.keys.seek(""); node != nil; node = node.next[0] {
+		if err := writeRecord(writer, []byte(node.key), s.values[node.key]); err != nil {
+			s.mu.RUnlock()
+			return err
+		}
+	}
+	s.mu.RUnlock()
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// Close closes the file of the FileKVStore.
+func (s *FileKVStore) Close() error {
+	s.fileMu.Lock()
+	defer s.fileMu.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,135 @@
+package archive_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2/archive"
+)
+
+// sets some keys and checks the values held by the given store.
+func testKVStore(t *testing.T, store archive.KVStore) {
+	_, err := store.Get([]byte("missing"))
+	assert.True(t, errors.Is(err, archive.ErrKeyNotFound))
+
+	require.NoError(t, store.Set([]byte("b2"), []byte("2")))
+	require.NoError(t, store.Set([]byte("b1"), []byte("1")))
+	require.NoError(t, store.Set([]byte("a"), []byte("a")))
+	require.NoError(t, store.Set([]byte("b1"), []byte("one")))
+
+	value, err := store.Get([]byte("b1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("one"), value)
+
+	var keys, values []string
+	require.NoError(t, store.Iterate([]byte("b"), func(key []byte, value []byte) bool {
+		keys = append(keys, string(key))
+		values = append(values, string(value))
+		return true
+	}))
+	assert.Equal(t, []string{"b1", "b2"}, keys)
+	assert.Equal(t, []string{"one", "2"}, values)
+
+	var count int
+	require.NoError(t, store.Iterate(nil, func([]byte, []byte) bool {
+		count++
+		return false
+	}))
+	assert.Equal(t, 1, count)
+}
+
+// checks that the given store holds exactly the given values.
+func testKVStoreValues(t *testing.T, store archive.KVStore, expected map[string]string) {
+	values := map[string]string{}
+	require.NoError(t, store.Iterate(nil, func(key []byte, value []byte) bool {
+		values[string(key)] = string(value)
+		return true
+	}))
+	assert.Equal(t, expected, values)
+}
+
+func TestMemoryKVStore(t *testing.T) {
+	testKVStore(t, archive.NewMemoryKVStore())
+}
+
+func TestMemoryKVStore_IteratePrefix(t *testing.T) {
+	store := archive.NewMemoryKVStore()
+	var expected []string
+	for i := 999; i >= 0; i-- {
+		for _, prefix := range []string{"a", "b", "c"} {
+			key := fmt.Sprintf("%s%03d", prefix, i)
+			require.NoError(t, store.Set([]byte(key), []byte(key)))
+			if prefix == "b" {
+				expected = append([]string{key}, expected...)
+			}
+		}
+	}
+
+	// the keys are iterated in order across batches and can be modified while iterating
+	var keys []string
+	require.NoError(t, store.Iterate([]byte("b"), func(key []byte, value []byte) bool {
+		assert.Equal(t, key, value)
+		keys = append(keys, string(key))
+		require.NoError(t, store.Set(key, []byte("modified")))
+		return true
+	}))
+	assert.Equal(t, expected, keys)
+}
+
+func TestFileKVStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive.db")
+	store, err := archive.OpenFileKVStore(path)
+	require.NoError(t, err)
+	testKVStore(t, store)
+	require.NoError(t, store.Close())
+
+	// the values are loaded on reopening
+	store, err = archive.OpenFileKVStore(path)
+	require.NoError(t, err)
+	value, err := store.Get([]byte("b1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("one"), value)
+	require.NoError(t, store.Close())
+
+	// compaction keeps the current values only
+	store, err = archive.OpenFileKVStore(path)
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Compact())
+	compacted, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Less(t, compacted.Size(), info.Size())
+	require.NoError(t, store.Set([]byte("b1"), []byte("1")))
+	require.NoError(t, store.Close())
+
+	store, err = archive.OpenFileKVStore(path)
+	require.NoError(t, err)
+	testKVStoreValues(t, store, map[string]string{"a": "a", "b1": "1", "b2": "2"})
+	require.NoError(t, store.Close())
+
+	// a torn trailing record is discarded and the log stays appendable
+	info, err = os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-1))
+	store, err = archive.OpenFileKVStore(path)
+	require.NoError(t, err)
+	value, err = store.Get([]byte("b1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("one"), value)
+	require.NoError(t, store.Set([]byte("c"), []byte("3")))
+	require.NoError(t, store.Close())
+
+	store, err = archive.OpenFileKVStore(path)
+	require.NoError(t, err)
+	value, err = store.Get([]byte("c"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("3"), value)
+	require.NoError(t, store.Close())
+}
@@ -0,0 +1,73 @@
+package archive
+
+import (
+	"math/rand"
+)
+
+const (
+	// the max. amount of levels of a skipList, enough for 4^32 keys
+	skipListMaxLevel = 32
+	// the inverse probability of a node reaching the next level
+	skipListBranching = 4
+)
+
+// skipList is an ordered set of keys supporting insertion and seeking in logarithmic time.
+// It is not safe for concurrent use.
+type skipList struct {
+	head  skipListNode
+	level int
+	rand  *rand.Rand
+}
+
+type skipListNode struct {
+	key  string
+	next []*skipListNode
+}
+
+func newSkipList() *skipList {
+	return &skipList{
+		head:  skipListNode{next: make([]*skipListNode, skipListMaxLevel)},
+		level: 1,
+		rand:  rand.New(rand.NewSource(1)),
+	}
+}
+
+// inserts the given key, which must not be in the skipList yet.
+func (l *skipList) insert(key string) {
+	var update [skipListMaxLevel]*skipListNode
+	node := &l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && node.next[i].key < key {
+			node = node.next[i]
+		}
+		update[i] = node
+	}
+
+	level := 1
+	for level < skipListMaxLevel && l.rand.Intn(skipListBranching) == 0 {
+		level++
+	}
+	for i := l.level; i < level; i++ {
+		update[i] = &l.head
+	}
+	if level > l.level {
+		l.level = level
+	}
+
+	inserted := &skipListNode{key: key, next: make([]*skipListNode, level)}
+	for i := 0; i < level; i++ {
+		inserted.next[i] = update[i].next[i]
+		update[i].next[i] = inserted
+	}
+}
+
+// returns the node of the first key greater than or equal to the given key, nil if there is none.
+func (l *skipList) seek(key string) *skipListNode {
+	node := &l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for node.next[i] != nil && node.next[i].key < key {
+			node = node.next[i]
+		}
+	}
+	return node.next[0]
+}
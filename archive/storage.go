@@ -0,0 +1,59 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/traversal"
+)
+
+// Storage returns a traversal.Storage over the archived messages, their metadata and children,
+// so that the tangle can be traversed locally. Unlike ChildrenByMessageID, it returns all archived children.
+func (a *Archive) Storage() traversal.Storage {
+	return &storage{archive: a}
+}
+
+type storage struct {
+	archive *Archive
+}
+
+// wraps ErrNotFound errors of the archive with traversal.ErrMessageNotFound.
+func notFound(err error) error {
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("%w: %s", traversal.ErrMessageNotFound, err)
+	}
+	return err
+}
+
+func (s *storage) Message(ctx context.Context, msgID iotago.MessageID) (*iotago.Message, error) {
+	msg, err := s.archive.MessageByMessageID(ctx, msgID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return msg, nil
+}
+
+func (s *storage) Metadata(ctx context.Context, msgID iotago.MessageID) (*iotago.MessageMetadataResponse, error) {
+	metadata, err := s.archive.MessageMetadataByMessageID(ctx, msgID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return metadata, nil
+}
+
+func (s *storage) Children(_ context.Context, msgID iotago.MessageID) (iotago.MessageIDs, error) {
+	var children iotago.MessageIDs
+	prefix := key(keyPrefixChildren, msgID[:])
+	err := s.archive.store.Iterate(prefix, func(k []byte, _ []byte) bool {
+		var child iotago.MessageID
+		copy(child[:], k[len(prefix):])
+		children = append(children, child)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return children, nil
+}
@@ -0,0 +1,67 @@
+package archive_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/archive"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/iotaledger/iota.go/v2/traversal"
+)
+
+func TestArchive_Storage(t *testing.T) {
+	ctx := context.Background()
+	a := archive.New(archive.NewMemoryKVStore(), archive.WithMaxResults(1))
+	defer a.Close()
+
+	// g <- a <- c
+	//  ^        |
+	//  +-- b <--+
+	names := map[iotago.MessageID]string{}
+	attach := func(name string, parents ...iotago.MessageID) iotago.MessageID {
+		msg := &iotago.Message{
+			Parents: serializer.RemoveDupsAndSortByLexicalOrderArrayOf32Bytes(parents),
+			Payload: &iotago.Indexation{Index: []byte(name)},
+		}
+		msgID, err := a.Store(msg, includedMetadata(1))
+		require.NoError(t, err)
+		names[msgID] = name
+		return msgID
+	}
+	missing := tpkg.Rand32ByteArray()
+	g := attach("g", missing)
+	msgA, msgB := attach("a", g), attach("b", g)
+	c := attach("c", msgA, msgB)
+
+	storage := a.Storage()
+	_, err := storage.Message(ctx, missing)
+	assert.True(t, errors.Is(err, traversal.ErrMessageNotFound))
+	_, err = storage.Metadata(ctx, missing)
+	assert.True(t, errors.Is(err, traversal.ErrMessageNotFound))
+	metadata, err := storage.Metadata(ctx, c)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, *metadata.ReferencedByMilestoneIndex)
+
+	// all children are returned regardless of the max results of the archive
+	children, err := storage.Children(ctx, g)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, iotago.MessageIDs{msgA, msgB}, children)
+
+	collect := func(walk func(ctx context.Context, start iotago.MessageIDs, consumer traversal.Consumer) error, start iotago.MessageID) []string {
+		var visited []string
+		require.NoError(t, walk(ctx, iotago.MessageIDs{start}, func(msgID iotago.MessageID, _ *iotago.Message) error {
+			visited = append(visited, names[msgID])
+			return nil
+		}))
+		return visited
+	}
+	walker := traversal.NewWalker(storage, traversal.WithSkipMissing(true))
+	assert.ElementsMatch(t, []string{"c", "a", "b", "g"}, collect(walker.PastCone, c))
+	assert.ElementsMatch(t, []string{"g", "a", "b", "c"}, collect(walker.FutureCone, g))
+}
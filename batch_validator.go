@@ -0,0 +1,373 @@
+package iotago
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// LedgerInclusionState defines whether a message's payload mutated the ledger, as reported by the node.
+type LedgerInclusionState string
+
+const (
+	// LedgerInclusionStateNoTransaction denotes that a message does not contain a transaction.
+	LedgerInclusionStateNoTransaction LedgerInclusionState = "noTransaction"
+	// LedgerInclusionStateIncluded denotes that a transaction mutated the ledger.
+	LedgerInclusionStateIncluded LedgerInclusionState = "included"
+	// LedgerInclusionStateConflicting denotes that a transaction was conflicting and did not mutate the ledger.
+	LedgerInclusionStateConflicting LedgerInclusionState = "conflicting"
+)
+
+// ConflictReason defines the reason why a transaction is conflicting, as reported by the node.
+type ConflictReason uint8
+
+const (
+	// ConflictNone denotes that a transaction is not conflicting.
+	ConflictNone ConflictReason = 0
+	// ConflictInputUTXOAlreadySpent denotes that an input was already spent before the transaction.
+	ConflictInputUTXOAlreadySpent ConflictReason = 1
+	// ConflictInputUTXOAlreadySpentInThisMilestone denotes that an input was spent by a previous transaction of the same milestone.
+	ConflictInputUTXOAlreadySpentInThisMilestone ConflictReason = 2
+	// ConflictInputUTXONotFound denotes that an input references an unknown UTXO.
+	ConflictInputUTXONotFound ConflictReason = 3
+	// ConflictInputOutputSumMismatch denotes that the inputs and outputs do not spend/deposit the same amount.
+	ConflictInputOutputSumMismatch ConflictReason = 4
+	// ConflictInvalidSignature denotes that a signature is invalid or does not match the address of its input.
+	ConflictInvalidSignature ConflictReason = 5
+	// ConflictInvalidDustAllowance denotes that the transaction violates the dust allowance rules.
+	ConflictInvalidDustAllowance ConflictReason = 6
+	// ConflictSemanticValidationFailed denotes that any other semantic validation rule is violated.
+	ConflictSemanticValidationFailed ConflictReason = 255
+)
+
+// String returns the name of the ConflictReason.
+func (c ConflictReason) String() string {
+	switch c {
+	case ConflictNone:
+		return "none"
+	case ConflictInputUTXOAlreadySpent:
+		return "input already spent"
+	case ConflictInputUTXOAlreadySpentInThisMilestone:
+		return "input already spent in this milestone"
+	case ConflictInputUTXONotFound:
+		return "input not found"
+	case ConflictInputOutputSumMismatch:
+		return "input/output sum mismatch"
+	case ConflictInvalidSignature:
+		return "invalid signature"
+	case ConflictInvalidDustAllowance:
+		return "invalid dust allowance"
+	default:
+		return "semantic validation failed"
+	}
+}
+
+var (
+	// ErrInputUTXOAlreadySpent gets returned if an input references an already spent UTXO.
+	ErrInputUTXOAlreadySpent = errors.New("input UTXO already spent")
+	// ErrInputUTXOAlreadySpentInBatch gets returned if an input references an UTXO spent by a previous transaction of the same batch.
+	ErrInputUTXOAlreadySpentInBatch = errors.New("input UTXO already spent by a previous transaction of the batch")
+)
+
+// ConflictReasonFromError returns the ConflictReason corresponding to an error returned by the semantic validation
+// of a transaction, ConflictNone for a nil error.
+func ConflictReasonFromError(err error) ConflictReason {
+	switch {
+	case err == nil:
+		return ConflictNone
+	case errors.Is(err, ErrInputUTXOAlreadySpentInBatch):
+		return ConflictInputUTXOAlreadySpentInThisMilestone
+	case errors.Is(err, ErrInputUTXOAlreadySpent):
+		return ConflictInputUTXOAlreadySpent
+	case errors.Is(err, ErrMissingUTXO):
+		return ConflictInputUTXONotFound
+	case errors.Is(err, ErrInputOutputSumMismatch):
+		return ConflictInputOutputSumMismatch
+	case errors.Is(err, ErrEd25519SignatureInvalid), errors.Is(err, ErrEd25519PubKeyAndAddrMismatch),
+		errors.Is(err, ErrSignatureAndAddrIncompatible), errors.Is(err, ErrInputSignatureUnlockBlockInvalid):
+		return ConflictInvalidSignature
+	case errors.Is(err, ErrInvalidDustAllowance):
+		return ConflictInvalidDustAllowance
+	default:
+		return ConflictSemanticValidationFailed
+	}
+}
+
+// UTXOLookup looks up the outputs referenced by the inputs of transactions.
+type UTXOLookup interface {
+	// LookupUTXO returns the output with the given ID and whether it is already spent.
+	// It returns an error wrapping ErrMissingUTXO if the output is unknown.
+	LookupUTXO(id UTXOInputID) (output Output, spent bool, err error)
+}
+
+// UTXOLookupFunc is a function implementing UTXOLookup.
+type UTXOLookupFunc func(id UTXOInputID) (output Output, spent bool, err error)
+
+// LookupUTXO calls the function itself.
+func (f UTXOLookupFunc) LookupUTXO(id UTXOInputID) (Output, bool, error) {
+	return f(id)
+}
+
+// the default options applied to the BatchValidator.
+var defaultBatchValidatorOptions = []BatchValidatorOption{
+	WithBatchValidatorWorkers(runtime.NumCPU()),
+}
+
+// BatchValidatorOptions define options for the BatchValidator.
+type BatchValidatorOptions struct {
+	// The amount of workers verifying signatures.
+	workers int
+	// The additional semantic validation functions applied to every transaction.
+	semValFuncs []SemanticValidationFunc
+}
+
+// applies the given BatchValidatorOption.
+func (bo *BatchValidatorOptions) apply(opts ...BatchValidatorOption) {
+	for _, opt := range opts {
+		opt(bo)
+	}
+}
+
+// WithBatchValidatorWorkers sets the amount of workers verifying signatures concurrently.
+func WithBatchValidatorWorkers(workers int) BatchValidatorOption {
+	return func(opts *BatchValidatorOptions) {
+		if workers < 1 {
+			workers = 1
+		}
+		opts.workers = workers
+	}
+}
+
+// WithBatchValidatorSemanticValidations sets additional SemanticValidationFunc(s), e.g. NewDustSemanticValidation,
+// which are applied to every transaction. They get passed the outputs the transaction's inputs reference.
+func WithBatchValidatorSemanticValidations(semValFuncs ...SemanticValidationFunc) BatchValidatorOption {
+	return func(opts *BatchValidatorOptions) {
+		opts.semValFuncs = semValFuncs
+	}
+}
+
+// BatchValidatorOption is a function setting a BatchValidator option.
+type BatchValidatorOption func(opts *BatchValidatorOptions)
+
+// NewBatchValidator creates a new BatchValidator looking up the UTXOs of the ledger with the given UTXOLookup.
+func NewBatchValidator(utxos UTXOLookup, opts ...BatchValidatorOption) *BatchValidator {
+	options := &BatchValidatorOptions{}
+	options.apply(defaultBatchValidatorOptions...)
+	options.apply(opts...)
+	return &BatchValidator{utxos: utxos, opts: options}
+}
+
+// BatchValidator semantically validates an ordered batch of transactions, e.g. the ones referenced by a milestone,
+// against a shared view of the ledger.
+type BatchValidator struct {
+	utxos UTXOLookup
+	opts  *BatchValidatorOptions
+}
+
+// TransactionValidationResult is the result of the semantic validation of a transaction within a batch.
+type TransactionValidationResult struct {
+	// The ID of the transaction.
+	TransactionID TransactionID
+	// Whether the transaction mutates the ledger.
+	InclusionState LedgerInclusionState
+	// The reason why the transaction is conflicting.
+	ConflictReason ConflictReason
+	// The error describing the conflict.
+	Err error
+}
+
+// the state of a transaction within a batch validation.
+type batchTx struct {
+	tx             *Transaction
+	id             TransactionID
+	essence        *TransactionEssence
+	essenceBytes   []byte
+	sigValidations []*ed25519SigValidation
+	// the result of the signature verification once it ran
+	sigVerified bool
+	sigErr      error
+}
+
+// Validate semantically validates the given transactions in the given order as the ledger would apply them:
+// a transaction is conflicting if it spends an UTXO which is unknown, already spent by the ledger or by a previous
+// transaction of the batch, or if it violates any other semantic rule. The outputs of the included transactions
+// can be spent by the subsequent transactions of the batch. The signatures are verified concurrently.
+// The transactions must be syntactically valid. The returned error is only non-nil if the UTXOLookup fails
+// or the context is done.
+func (v *BatchValidator) Validate(ctx context.Context, txs []*Transaction) ([]*TransactionValidationResult, error) {
+	batch := make([]*batchTx, len(txs))
+	for i, tx := range txs {
+		txID, err := tx.ID()
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute ID of transaction at index %d: %w", i, err)
+		}
+		batch[i] = &batchTx{tx: tx, id: *txID}
+	}
+
+	// the ledger mutations depend on which transactions have valid signatures, therefore the batch is applied
+	// assuming that the not yet verified signatures are valid, then those are verified concurrently
+	// and the batch is applied again until all signatures of the included transactions are verified
+	for {
+		results, pending, err := v.apply(batch)
+		if err != nil {
+			return nil, err
+		}
+		if len(pending) == 0 {
+			return results, nil
+		}
+		if err := v.verifySignatures(ctx, pending); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// applies the batch in order and returns the results and the transactions whose signatures still need to be verified.
+func (v *BatchValidator) apply(batch []*batchTx) ([]*TransactionValidationResult, []*batchTx, error) {
+	results := make([]*TransactionValidationResult, len(batch))
+	spent := make(map[UTXOInputID]struct{})
+	created := make(map[UTXOInputID]Output)
+	var pending []*batchTx
+
+	for i, btx := range batch {
+		inputs, err := v.resolveInputs(btx, spent, created)
+		var lookupErr *utxoLookupError
+		if errors.As(err, &lookupErr) {
+			return nil, nil, lookupErr.err
+		}
+		if err == nil {
+			err = v.validate(btx, inputs)
+		}
+		if err == nil && btx.sigVerified {
+			err = btx.sigErr
+		}
+
+		results[i] = &TransactionValidationResult{TransactionID: btx.id, ConflictReason: ConflictReasonFromError(err), Err: err}
+		if err != nil {
+			results[i].InclusionState = LedgerInclusionStateConflicting
+			continue
+		}
+		results[i].InclusionState = LedgerInclusionStateIncluded
+
+		if !btx.sigVerified {
+			pending = append(pending, btx)
+		}
+		for id := range inputs {
+			spent[id] = struct{}{}
+		}
+		for outputIndex, output := range btx.essence.Outputs {
+			utxoInput := &UTXOInput{TransactionID: btx.id, TransactionOutputIndex: uint16(outputIndex)}
+			created[utxoInput.ID()] = output.(Output)
+		}
+	}
+
+	return results, pending, nil
+}
+
+// wraps an error of the UTXOLookup which aborts the validation of the batch.
+type utxoLookupError struct {
+	err error
+}
+
+func (e *utxoLookupError) Error() string {
+	return e.err.Error()
+}
+
+// resolves the outputs the inputs of the given transaction reference.
+// Errors other than utxoLookupError make the transaction conflicting.
+func (v *BatchValidator) resolveInputs(btx *batchTx, spent map[UTXOInputID]struct{}, created map[UTXOInputID]Output) (InputToOutputMapping, error) {
+	txEssence, ok := btx.tx.Essence.(*TransactionEssence)
+	if !ok {
+		return nil, fmt.Errorf("%w: transaction is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+	btx.essence = txEssence
+
+	inputs := make(InputToOutputMapping, len(txEssence.Inputs))
+	for i, input := range txEssence.Inputs {
+		utxoInput, ok := input.(*UTXOInput)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i)
+		}
+		utxoID := utxoInput.ID()
+
+		if _, has := spent[utxoID]; has {
+			return nil, fmt.Errorf("%w: UTXO %s (input at index %d)", ErrInputUTXOAlreadySpentInBatch, utxoID.ToHex(), i)
+		}
+		if output, has := created[utxoID]; has {
+			inputs[utxoID] = output
+			continue
+		}
+
+		output, isSpent, err := v.utxos.LookupUTXO(utxoID)
+		switch {
+		case errors.Is(err, ErrMissingUTXO):
+			return nil, fmt.Errorf("%w: UTXO %s (input at index %d)", err, utxoID.ToHex(), i)
+		case err != nil:
+			return nil, &utxoLookupError{fmt.Errorf("unable to look up UTXO %s: %w", utxoID.ToHex(), err)}
+		case isSpent:
+			return nil, fmt.Errorf("%w: UTXO %s (input at index %d)", ErrInputUTXOAlreadySpent, utxoID.ToHex(), i)
+		}
+		inputs[utxoID] = output
+	}
+	return inputs, nil
+}
+
+// performs the semantic validation of the given transaction except for the signature verification.
+func (v *BatchValidator) validate(btx *batchTx, inputs InputToOutputMapping) error {
+	if btx.essenceBytes == nil {
+		essenceBytes, err := btx.essence.SigningMessage()
+		if err != nil {
+			return err
+		}
+		btx.essenceBytes = essenceBytes
+	}
+
+	inputSum, sigValidations, _, err := btx.tx.semanticallyValidateInputs(inputs, btx.essence, nil)
+	if err != nil {
+		return err
+	}
+	btx.sigValidations = sigValidations
+
+	outputSum, err := btx.tx.SemanticallyValidateOutputs(btx.essence)
+	if err != nil {
+		return err
+	}
+	if inputSum != outputSum {
+		return fmt.Errorf("%w: inputs sum %d, outputs sum %d", ErrInputOutputSumMismatch, inputSum, outputSum)
+	}
+
+	for _, semValFunc := range v.opts.semValFuncs {
+		if err := semValFunc(btx.tx, inputs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifies the signatures of the given transactions using the worker pool.
+func (v *BatchValidator) verifySignatures(ctx context.Context, pending []*batchTx) error {
+	work := make(chan *batchTx)
+	var wg sync.WaitGroup
+	for i := 0; i < v.opts.workers && i < len(pending); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for btx := range work {
+				btx.sigErr = verifyEd25519SigValidations(btx.sigValidations, btx.essenceBytes)
+				btx.sigVerified = true
+			}
+		}()
+	}
+
+	var err error
+	for _, btx := range pending {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		work <- btx
+	}
+	close(work)
+	wg.Wait()
+	return err
+}
@@ -0,0 +1,168 @@
+package iotago_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+// a ledger of UTXOs used as the UTXOLookup.
+type testLedger struct {
+	outputs map[iotago.UTXOInputID]iotago.Output
+	spent   map[iotago.UTXOInputID]bool
+}
+
+func (l *testLedger) LookupUTXO(id iotago.UTXOInputID) (iotago.Output, bool, error) {
+	output, has := l.outputs[id]
+	if !has {
+		return nil, false, fmt.Errorf("%w: %s", iotago.ErrMissingUTXO, id.ToHex())
+	}
+	return output, l.spent[id], nil
+}
+
+// adds an output of the given amount to the given address to the ledger.
+func (l *testLedger) add(addr iotago.Address, amount uint64, spent bool) *iotago.UTXOInput {
+	input := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}
+	l.outputs[input.ID()] = &iotago.SigLockedSingleOutput{Address: addr, Amount: amount}
+	l.spent[input.ID()] = spent
+	return input
+}
+
+// builds a transaction signed by the given keys moving the amount of the inputs back to their address.
+func batchTestTransaction(t *testing.T, addrKeys iotago.AddressKeys, amount uint64, inputs ...*iotago.UTXOInput) *iotago.Transaction {
+	builder := iotago.NewTransactionBuilder()
+	for _, input := range inputs {
+		builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: addrKeys.Address, Input: input})
+	}
+	builder.AddOutput(&iotago.SigLockedSingleOutput{Address: addrKeys.Address, Amount: amount})
+	tx, err := builder.Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	require.NoError(t, err)
+	return tx
+}
+
+// returns the input referencing the first output of the given transaction.
+func firstOutput(t *testing.T, tx *iotago.Transaction) *iotago.UTXOInput {
+	txID, err := tx.ID()
+	require.NoError(t, err)
+	return &iotago.UTXOInput{TransactionID: *txID}
+}
+
+func TestBatchValidator_Validate(t *testing.T) {
+	prvKey := tpkg.RandEd25519PrivateKey()
+	addr := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &addr, Keys: prvKey}
+
+	ledger := &testLedger{outputs: map[iotago.UTXOInputID]iotago.Output{}, spent: map[iotago.UTXOInputID]bool{}}
+	utxoA := ledger.add(&addr, 1_000_000, false)
+	utxoB := ledger.add(&addr, 1_000_000, true)
+	utxoC := ledger.add(&addr, 1_000_000, false)
+	utxoD := ledger.add(&addr, 1_000_000, false)
+	unknown := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}
+
+	spendsA := batchTestTransaction(t, addrKeys, 1_000_000, utxoA)
+	invalidSig := batchTestTransaction(t, addrKeys, 1_000_000, utxoC)
+	invalidSig.UnlockBlocks[0].(*iotago.SignatureUnlockBlock).Signature.(*iotago.Ed25519Signature).Signature[0] ^= 0xff
+
+	txs := []*iotago.Transaction{
+		spendsA,
+		batchTestTransaction(t, addrKeys, 1_000_000, utxoA),
+		batchTestTransaction(t, addrKeys, 1_000_000, utxoB),
+		batchTestTransaction(t, addrKeys, 1_000_000, unknown),
+		batchTestTransaction(t, addrKeys, 2_000_000, utxoD),
+		// spends the output created by the first transaction
+		batchTestTransaction(t, addrKeys, 1_000_000, firstOutput(t, spendsA)),
+		invalidSig,
+		// the output of the conflicting transaction does not exist
+		batchTestTransaction(t, addrKeys, 1_000_000, firstOutput(t, invalidSig)),
+		// spends the UTXO the conflicting transaction failed to spend
+		batchTestTransaction(t, addrKeys, 1_000_000, utxoC),
+	}
+	expected := []iotago.ConflictReason{
+		iotago.ConflictNone,
+		iotago.ConflictInputUTXOAlreadySpentInThisMilestone,
+		iotago.ConflictInputUTXOAlreadySpent,
+		iotago.ConflictInputUTXONotFound,
+		iotago.ConflictInputOutputSumMismatch,
+		iotago.ConflictNone,
+		iotago.ConflictInvalidSignature,
+		iotago.ConflictInputUTXONotFound,
+		iotago.ConflictNone,
+	}
+
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			results, err := iotago.NewBatchValidator(ledger, iotago.WithBatchValidatorWorkers(workers)).Validate(context.Background(), txs)
+			require.NoError(t, err)
+			require.Len(t, results, len(txs))
+
+			for i, result := range results {
+				txID, err := txs[i].ID()
+				require.NoError(t, err)
+				assert.Equal(t, *txID, result.TransactionID)
+				assert.Equalf(t, expected[i], result.ConflictReason, "transaction %d: %v", i, result.Err)
+				if expected[i] == iotago.ConflictNone {
+					assert.Equal(t, iotago.LedgerInclusionStateIncluded, result.InclusionState)
+					assert.NoError(t, result.Err)
+					continue
+				}
+				assert.Equal(t, iotago.LedgerInclusionStateConflicting, result.InclusionState)
+				assert.Equal(t, expected[i], iotago.ConflictReasonFromError(result.Err))
+			}
+		})
+	}
+}
+
+func TestBatchValidator_SemanticValidations(t *testing.T) {
+	prvKey := tpkg.RandEd25519PrivateKey()
+	addr := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &addr, Keys: prvKey}
+
+	ledger := &testLedger{outputs: map[iotago.UTXOInputID]iotago.Output{}, spent: map[iotago.UTXOInputID]bool{}}
+	tx := batchTestTransaction(t, addrKeys, 1_000_000, ledger.add(&addr, 1_000_000, false))
+
+	failingSemVal := func(t *iotago.Transaction, utxos iotago.InputToOutputMapping) error {
+		return iotago.ErrInvalidDustAllowance
+	}
+	results, err := iotago.NewBatchValidator(ledger, iotago.WithBatchValidatorSemanticValidations(failingSemVal)).
+		Validate(context.Background(), []*iotago.Transaction{tx})
+	require.NoError(t, err)
+	assert.Equal(t, iotago.ConflictInvalidDustAllowance, results[0].ConflictReason)
+	assert.Equal(t, iotago.LedgerInclusionStateConflicting, results[0].InclusionState)
+}
+
+func TestBatchValidator_Errors(t *testing.T) {
+	prvKey := tpkg.RandEd25519PrivateKey()
+	addr := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &addr, Keys: prvKey}
+	tx := batchTestTransaction(t, addrKeys, 1_000_000, &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()})
+
+	errLookup := errors.New("storage failure")
+	failingLookup := iotago.UTXOLookupFunc(func(id iotago.UTXOInputID) (iotago.Output, bool, error) {
+		return nil, false, errLookup
+	})
+	_, err := iotago.NewBatchValidator(failingLookup).Validate(context.Background(), []*iotago.Transaction{tx})
+	assert.True(t, errors.Is(err, errLookup))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	lookup := iotago.UTXOLookupFunc(func(id iotago.UTXOInputID) (iotago.Output, bool, error) {
+		return &iotago.SigLockedSingleOutput{Address: &addr, Amount: 1_000_000}, false, nil
+	})
+	_, err = iotago.NewBatchValidator(lookup).Validate(ctx, []*iotago.Transaction{tx})
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestConflictReasonFromError(t *testing.T) {
+	assert.Equal(t, iotago.ConflictNone, iotago.ConflictReasonFromError(nil))
+	assert.Equal(t, iotago.ConflictInvalidSignature, iotago.ConflictReasonFromError(fmt.Errorf("%w: wrapped", iotago.ErrEd25519PubKeyAndAddrMismatch)))
+	assert.Equal(t, iotago.ConflictSemanticValidationFailed, iotago.ConflictReasonFromError(errors.New("other")))
+	assert.Equal(t, "invalid dust allowance", iotago.ConflictInvalidDustAllowance.String())
+}
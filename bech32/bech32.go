@@ -10,26 +10,90 @@ import (
 )
 
 const (
-	maxStringLength = 90
-	checksumLength  = 6
-	separator       = '1'
+	// MaxStringLength is the maximum length of a Bech32 string as defined in BIP 173.
+	MaxStringLength = 90
+
+	checksumLength = 6
+	separator      = '1'
 )
 
 var charset = newEncoding("qpzry9x8gf2tvdw0s3jn54khce6mua7l")
 
-// Encode encodes the String string and the src data as a Bech32 string.
+// Variant defines the checksum variant of a Bech32 string.
+type Variant byte
+
+const (
+	// Bech32 denotes the original checksum as defined in BIP 173.
+	Bech32 Variant = iota
+	// Bech32m denotes the modified checksum as defined in BIP 350.
+	Bech32m
+)
+
+// the constant the polymod of a valid checksum of the variant results in.
+func (v Variant) constant() int {
+	if v == Bech32m {
+		return 0x2bc830a3
+	}
+	return 1
+}
+
+func (v Variant) String() string {
+	if v == Bech32m {
+		return "Bech32m"
+	}
+	return "Bech32"
+}
+
+// Codec encodes and decodes Bech32 strings using a configurable checksum variant and maximum length.
+// The zero value is a valid Codec encoding with the original Bech32 checksum and a maximum length of MaxStringLength.
+type Codec struct {
+	// The checksum variant used for encoding.
+	Variant Variant
+	// The maximum length of a Bech32 string, MaxStringLength is used if 0.
+	MaxLength int
+}
+
+// Limit returns the maximum length of a Bech32 string handled by the Codec.
+func (c Codec) Limit() int {
+	if c.MaxLength == 0 {
+		return MaxStringLength
+	}
+	return c.MaxLength
+}
+
+// Encode encodes the String string and the src data as a Bech32 string using the original checksum.
 // It returns an error when the input is invalid.
 func Encode(hrp string, src []byte) (string, error) {
+	return Codec{}.Encode(hrp, src)
+}
+
+// Decode decodes the Bech32 string s into its human-readable and data part.
+// It returns an error when s does not represent a valid Bech32 encoding using the original checksum.
+// An SyntaxError is returned when the error can be matched to a certain position in s.
+func Decode(s string) (string, []byte, error) {
+	hrp, data, variant, err := Codec{}.Decode(s)
+	if err != nil {
+		return "", nil, err
+	}
+	if variant != Bech32 {
+		return "", nil, &SyntaxError{fmt.Errorf("%w: %s checksum", ErrInvalidChecksum, variant), len(s) - checksumLength}
+	}
+	return hrp, data, nil
+}
+
+// Encode encodes the String string and the src data as a Bech32 string using the Codec's checksum variant.
+// It returns an error when the input is invalid.
+func (c Codec) Encode(hrp string, src []byte) (string, error) {
 	dataLen := base32.EncodedLen(len(src))
-	if len(hrp)+dataLen+checksumLength+1 > maxStringLength {
+	if len(hrp)+dataLen+checksumLength+1 > c.Limit() {
 		return "", fmt.Errorf("%w: String length=%d, data length=%d", ErrInvalidLength, len(hrp), dataLen)
 	}
 	// validate the human-readable part
 	if len(hrp) < 1 {
 		return "", fmt.Errorf("%w: String must not be empty", ErrInvalidLength)
 	}
-	for _, c := range hrp {
-		if !isValidHRPChar(c) {
+	for _, r := range hrp {
+		if !isValidHRPChar(r) {
 			return "", fmt.Errorf("%w: not US-ASCII character in human-readable part", ErrInvalidCharacter)
 		}
 	}
@@ -43,7 +107,7 @@ func Encode(hrp string, src []byte) (string, error) {
 	// convert to base32 and add the checksum
 	data := make([]uint8, base32.EncodedLen(len(src))+checksumLength)
 	base32.Encode(data, src)
-	copy(data[dataLen:], bech32CreateChecksum(hrpLower, data[:dataLen]))
+	copy(data[dataLen:], bech32CreateChecksum(hrpLower, data[:dataLen], c.Variant))
 
 	// enc the data part using the charset
 	chars := charset.encode(data)
@@ -62,45 +126,29 @@ func Encode(hrp string, src []byte) (string, error) {
 }
 
 // Decode decodes the Bech32 string s into its human-readable and data part.
+// The checksum variant is detected automatically and returned.
 // It returns an error when s does not represent a valid Bech32 encoding.
 // An SyntaxError is returned when the error can be matched to a certain position in s.
-func Decode(s string) (string, []byte, error) {
-	if len(s) > maxStringLength {
-		return "", nil, &SyntaxError{fmt.Errorf("%w: maximum length exceeded", ErrInvalidLength), maxStringLength}
-	}
-	// validate the separator
-	hrpLen := strings.LastIndex(s, string(separator))
-	if hrpLen == -1 {
-		return "", nil, ErrMissingSeparator
-	}
-	if hrpLen < 1 || hrpLen+checksumLength > len(s) {
-		return "", nil, &SyntaxError{fmt.Errorf("%w: invalid position", ErrInvalidSeparator), hrpLen}
-	}
-	// validate characters in human-readable part
-	for i, c := range s[:hrpLen] {
-		if !isValidHRPChar(c) {
-			return "", nil, &SyntaxError{fmt.Errorf("%w: not US-ASCII character in human-readable part", ErrInvalidCharacter), i}
-		}
-	}
-	// validate that the case of the entire string is consistent
-	if err := validateCase(s); err != nil {
-		return "", nil, err
+func (c Codec) Decode(s string) (string, []byte, Variant, error) {
+	hrp, chars, err := c.split(s)
+	if err != nil {
+		return "", nil, 0, err
 	}
-
-	// convert everything to lower
-	s = strings.ToLower(s)
-	hrp := s[:hrpLen]
-	chars := s[hrpLen+1:]
+	hrpLen := len(hrp)
 
 	// decode the data part
 	data, err := charset.decode(chars)
 	if err != nil {
-		return "", nil, &SyntaxError{fmt.Errorf("%w: non-charset character in data part", ErrInvalidCharacter), hrpLen + 1 + len(data)}
+		return "", nil, 0, &SyntaxError{fmt.Errorf("%w: non-charset character in data part", ErrInvalidCharacter), hrpLen + 1 + len(data)}
 	}
 
 	// validate the checksum
-	if len(data) < checksumLength || !bech32VerifyChecksum(hrp, data) {
-		return "", nil, &SyntaxError{ErrInvalidChecksum, len(s) - checksumLength}
+	if len(data) < checksumLength {
+		return "", nil, 0, &SyntaxError{ErrInvalidChecksum, len(s) - checksumLength}
+	}
+	variant, ok := bech32VerifyChecksum(hrp, data)
+	if !ok {
+		return "", nil, 0, &SyntaxError{ErrInvalidChecksum, len(s) - checksumLength}
 	}
 	data = data[:len(data)-checksumLength]
 
@@ -109,11 +157,40 @@ func Decode(s string) (string, []byte, error) {
 	if _, err := base32.Decode(dst, data); err != nil {
 		var e *base32.CorruptInputError
 		if errors.As(err, &e) {
-			return "", nil, &SyntaxError{e.Unwrap(), hrpLen + 1 + e.Offset}
+			return "", nil, 0, &SyntaxError{e.Unwrap(), hrpLen + 1 + e.Offset}
 		}
-		return "", nil, err
+		return "", nil, 0, err
 	}
-	return hrp, dst, nil
+	return hrp, dst, variant, nil
+}
+
+// validates the structure of s and splits it into its lower case human-readable and data part.
+func (c Codec) split(s string) (string, string, error) {
+	if len(s) > c.Limit() {
+		return "", "", &SyntaxError{fmt.Errorf("%w: maximum length exceeded", ErrInvalidLength), c.Limit()}
+	}
+	// validate the separator
+	hrpLen := strings.LastIndex(s, string(separator))
+	if hrpLen == -1 {
+		return "", "", &SyntaxError{ErrMissingSeparator, len(s)}
+	}
+	if hrpLen < 1 || hrpLen+checksumLength > len(s) {
+		return "", "", &SyntaxError{fmt.Errorf("%w: invalid position", ErrInvalidSeparator), hrpLen}
+	}
+	// validate characters in human-readable part
+	for i, r := range s[:hrpLen] {
+		if !isValidHRPChar(r) {
+			return "", "", &SyntaxError{fmt.Errorf("%w: not US-ASCII character in human-readable part", ErrInvalidCharacter), i}
+		}
+	}
+	// validate that the case of the entire string is consistent
+	if err := validateCase(s); err != nil {
+		return "", "", err
+	}
+
+	// convert everything to lower
+	s = strings.ToLower(s)
+	return s[:hrpLen], s[hrpLen+1:], nil
 }
 
 func isValidHRPChar(r rune) bool {
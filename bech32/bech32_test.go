@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/iotaledger/iota.go/v2/bech32/internal/base32"
@@ -202,3 +203,102 @@ func decodeHex(s string) []byte {
 	}
 	return dst
 }
+
+func TestCodec(t *testing.T) {
+	var tests = []*struct {
+		s       string
+		codec   Codec
+		expHRP  string
+		expData []byte
+		expVar  Variant
+		expErr  error
+	}{
+		{s: "A1LQFN3A", expHRP: "a", expData: []byte{}, expVar: Bech32m},
+		{s: "a1lqfn3a", expHRP: "a", expData: []byte{}, expVar: Bech32m},
+		{
+			s:       "an83characterlonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber11sg7hg6",
+			expHRP:  "an83characterlonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber1",
+			expData: []byte{},
+			expVar:  Bech32m,
+		},
+		{s: "abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", expHRP: "abcdef", expData: decodeHex("ffbbcdeb38bdab49ca307b9ac5a928398a418820"), expVar: Bech32m},
+		{s: "split1checkupstagehandshakeupstreamerranterredcaperredlc445v", expHRP: "split", expData: decodeHex("c5f38b70305f519bf66d85fb6cf03058f3dde463ecd7918f2dc743918f2d"), expVar: Bech32m},
+		{s: "?1v759aa", expHRP: "?", expData: []byte{}, expVar: Bech32m},
+		{s: "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", expHRP: "abcdef", expData: decodeHex("00443214c74254b635cf84653a56d7c675be77df"), expVar: Bech32},
+		{s: "a1lqfn3c", expErr: ErrInvalidChecksum},
+		{s: "M1VUXWEZ", expErr: ErrInvalidChecksum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			hrp, data, variant, err := tt.codec.Decode(tt.s)
+			if !assert.Truef(t, errors.Is(err, tt.expErr), "unexpected error: %v", err) || err != nil {
+				return
+			}
+			assert.Equal(t, tt.expHRP, hrp)
+			assert.Equal(t, tt.expData, data)
+			assert.Equal(t, tt.expVar, variant)
+
+			// encoding results in the same string
+			codec := tt.codec
+			codec.Variant = variant
+			s, err := codec.Encode(hrp, data)
+			assert.NoError(t, err)
+			assert.Equal(t, strings.ToLower(tt.s), s)
+		})
+	}
+}
+
+func TestCodec_MaxLength(t *testing.T) {
+	src := make([]byte, 60)
+
+	_, err := Encode("long", src)
+	assert.True(t, errors.Is(err, ErrInvalidLength))
+
+	codec := Codec{Variant: Bech32m, MaxLength: 120}
+	s, err := codec.Encode("long", src)
+	assert.NoError(t, err)
+	assert.Greater(t, len(s), MaxStringLength)
+
+	_, _, _, err = Codec{}.Decode(s)
+	assert.True(t, errors.Is(err, ErrInvalidLength))
+
+	hrp, data, variant, err := codec.Decode(s)
+	assert.NoError(t, err)
+	assert.Equal(t, "long", hrp)
+	assert.Equal(t, src, data)
+	assert.Equal(t, Bech32m, variant)
+}
+
+func TestDecode_Bech32m(t *testing.T) {
+	// Decode only accepts the original checksum
+	_, _, err := Decode("a1lqfn3a")
+	assert.True(t, errors.Is(err, ErrInvalidChecksum))
+	var syntaxErr *SyntaxError
+	if assert.True(t, errors.As(err, &syntaxErr)) {
+		assert.Equal(t, 2, syntaxErr.Offset)
+	}
+}
+
+func TestSyntaxError_Offset(t *testing.T) {
+	var tests = []*struct {
+		s         string
+		expOffset int
+	}{
+		{s: "pzry9x0s0muk", expOffset: 12},
+		{s: "1pzry9x0s0muk", expOffset: 0},
+		{s: "x1b4n0q5v", expOffset: 2},
+		{s: "li1dgmt3", expOffset: 2},
+		{s: "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sL5k7", expOffset: 58},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			_, _, err := Decode(tt.s)
+			var syntaxErr *SyntaxError
+			if assert.Truef(t, errors.As(err, &syntaxErr), "unexpected error: %v", err) {
+				assert.Equal(t, tt.expOffset, syntaxErr.Offset)
+			}
+		})
+	}
+}
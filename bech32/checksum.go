@@ -2,10 +2,10 @@ package bech32
 
 var gen = []int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
 
-// For more details on the checksum calculation, please refer to BIP 173.
-func bech32CreateChecksum(hrp string, blocks []byte) []byte {
+// For more details on the checksum calculation, please refer to BIP 173 and BIP 350.
+func bech32CreateChecksum(hrp string, blocks []byte, variant Variant) []byte {
 	values := append(bech32HrpExpand(hrp), blocks...)
-	polymod := bech32Polymod(append(values, []byte{0, 0, 0, 0, 0, 0}...)) ^ 1
+	polymod := bech32Polymod(append(values, []byte{0, 0, 0, 0, 0, 0}...)) ^ variant.constant()
 	res := make([]byte, 6)
 	for i := range res {
 		res[i] = byte((polymod >> (5 * (5 - i))) & 31)
@@ -17,12 +17,19 @@ func bech32CreateChecksum(hrp string, blocks []byte) []byte {
 func bech32Polymod(values []byte) int {
 	chk := 1
 	for _, v := range values {
-		b := chk >> 25
-		chk = (chk&0x1ffffff)<<5 ^ int(v)
-		for i := range gen {
-			if (b>>i)&1 != 0 {
-				chk ^= gen[i]
-			}
+		chk = polymodStep(chk, v)
+	}
+	return chk
+}
+
+// feeds the value v into the polymod state chk.
+// The step is linear, so the effect of a changed value on the final polymod does not depend on the other values.
+func polymodStep(chk int, v byte) int {
+	b := chk >> 25
+	chk = (chk&0x1ffffff)<<5 ^ int(v)
+	for i := range gen {
+		if (b>>i)&1 != 0 {
+			chk ^= gen[i]
 		}
 	}
 	return chk
@@ -41,7 +48,15 @@ func bech32HrpExpand(s string) []byte {
 	return res
 }
 
-// For more details on the checksum verification, please refer to BIP 173.
-func bech32VerifyChecksum(hrp string, data []byte) bool {
-	return bech32Polymod(append(bech32HrpExpand(hrp), data...)) == 1
+// For more details on the checksum verification, please refer to BIP 173 and BIP 350.
+// It returns the variant of the valid checksum and false if the checksum is invalid for all variants.
+func bech32VerifyChecksum(hrp string, data []byte) (Variant, bool) {
+	switch bech32Polymod(append(bech32HrpExpand(hrp), data...)) {
+	case Bech32.constant():
+		return Bech32, true
+	case Bech32m.constant():
+		return Bech32m, true
+	default:
+		return 0, false
+	}
 }
@@ -10,6 +10,7 @@ var (
 	ErrMixedCase        = errors.New("mixed case")
 	ErrInvalidCharacter = errors.New("invalid character")
 	ErrInvalidChecksum  = errors.New("invalid checksum")
+	ErrTooManyErrors    = errors.New("too many errors to locate")
 )
 
 // A SyntaxError is a description of a Bech32 syntax error.
@@ -0,0 +1,162 @@
+package bech32
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxLocatableErrors is the maximum number of substitution errors LocateErrors can locate.
+const maxLocatableErrors = 2
+
+// Correction is a candidate correction of a mistyped Bech32 string.
+type Correction struct {
+	// The positions of the substituted characters within the mistyped string.
+	Positions []int
+	// The corrected string.
+	Corrected string
+}
+
+// LocateErrors locates up to two substitution errors within the data part of the Bech32 string s
+// using the original checksum. See Codec.LocateErrors for details.
+func LocateErrors(s string) ([]Correction, error) {
+	return Codec{}.LocateErrors(s)
+}
+
+// LocateErrors locates up to two substitution errors within the data part of the Bech32 string s,
+// checked against the Codec's checksum variant, and returns the candidate corrections.
+// Corrections substituting a single character come first, followed by the ones substituting two.
+// Characters not part of the charset are treated as known errors, which every candidate corrects.
+// It returns no corrections if s is valid and ErrTooManyErrors if no correction exists.
+//
+// As advised by BIP 173, the candidates should only be used to point the user at the positions of the errors
+// and never to correct the string without the user's confirmation.
+func (c Codec) LocateErrors(s string) ([]Correction, error) {
+	hrp, chars, err := c.split(s)
+	if err != nil {
+		return nil, err
+	}
+	dataOffset := len(hrp) + 1
+	if len(chars) < checksumLength {
+		return nil, &SyntaxError{ErrInvalidChecksum, len(s) - checksumLength}
+	}
+
+	// substitute characters not part of the charset and remember them as known errors
+	data := make([]byte, len(chars))
+	var erasures []int
+	for i := range chars {
+		d := charset.decMap[chars[i]]
+		if d == 0xFF {
+			erasures = append(erasures, i)
+			d = 0
+		}
+		data[i] = d
+	}
+	if len(erasures) > maxLocatableErrors {
+		return nil, &SyntaxError{fmt.Errorf("%w: %d characters not part of the charset", ErrTooManyErrors, len(erasures)), dataOffset + erasures[maxLocatableErrors]}
+	}
+
+	// as the checksum is linear, the syndrome of the string is the sum of the syndromes of its errors
+	syndrome := bech32Polymod(append(bech32HrpExpand(hrp), data...)) ^ c.Variant.constant()
+	if syndrome == 0 && len(erasures) == 0 {
+		return nil, nil
+	}
+	deltas := errorSyndromes(len(data))
+
+	// the errors per position which would cause the given syndrome, a known error might also be no error
+	isErasure := make(map[int]bool, len(erasures))
+	for _, pos := range erasures {
+		isErasure[pos] = true
+	}
+	type posErr struct {
+		pos int
+		err byte
+	}
+	bySyndrome := make(map[int][]posErr)
+	for pos := range data {
+		minErr := byte(1)
+		if isErasure[pos] {
+			minErr = 0
+		}
+		for e := minErr; e < 32; e++ {
+			bySyndrome[deltas[pos][e]] = append(bySyndrome[deltas[pos][e]], posErr{pos, e})
+		}
+	}
+
+	var corrections []Correction
+	addCorrection := func(errs ...posErr) {
+		for _, pos := range erasures {
+			covered := false
+			for _, e := range errs {
+				covered = covered || e.pos == pos
+			}
+			if !covered {
+				return
+			}
+		}
+		corrected := []byte(strings.ToLower(s))
+		positions := make([]int, len(errs))
+		for i, e := range errs {
+			corrected[dataOffset+e.pos] = charset.enc[data[e.pos]^e.err]
+			positions[i] = dataOffset + e.pos
+		}
+		correctedStr := string(corrected)
+		if s == strings.ToUpper(s) {
+			correctedStr = strings.ToUpper(correctedStr)
+		}
+		// the corrected string must also have a valid data part
+		if _, _, variant, err := c.Decode(correctedStr); err != nil || variant != c.Variant {
+			return
+		}
+		corrections = append(corrections, Correction{Positions: positions, Corrected: correctedStr})
+	}
+
+	for _, single := range bySyndrome[syndrome] {
+		addCorrection(single)
+	}
+	for pos := range data {
+		minErr := byte(1)
+		if isErasure[pos] {
+			minErr = 0
+		}
+		for e := minErr; e < 32; e++ {
+			for _, other := range bySyndrome[syndrome^deltas[pos][e]] {
+				if other.pos <= pos || (e == 0 && other.err == 0) {
+					continue
+				}
+				addCorrection(posErr{pos, e}, other)
+			}
+		}
+	}
+
+	if len(corrections) == 0 {
+		return nil, &SyntaxError{fmt.Errorf("%w: more than %d substitutions", ErrTooManyErrors, maxLocatableErrors), len(s) - checksumLength}
+	}
+	sort.SliceStable(corrections, func(i, j int) bool {
+		a, b := corrections[i].Positions, corrections[j].Positions
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return false
+	})
+	return corrections, nil
+}
+
+// computes for each of the n data positions and each error value, the change of the polymod
+// caused by adding the error value at the given position.
+func errorSyndromes(n int) [][32]int {
+	deltas := make([][32]int, n)
+	for e := 1; e < 32; e++ {
+		chk := polymodStep(0, byte(e))
+		for pos := n - 1; pos >= 0; pos-- {
+			deltas[pos][e] = chk
+			chk = polymodStep(chk, 0)
+		}
+	}
+	return deltas
+}
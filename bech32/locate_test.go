@@ -0,0 +1,88 @@
+package bech32
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// substitutes the characters at the given positions with other charset characters.
+func substitute(s string, positions ...int) string {
+	b := []byte(s)
+	for _, pos := range positions {
+		b[pos] = charset.enc[(charset.decMap[b[pos]]+7)%32]
+	}
+	return string(b)
+}
+
+func TestLocateErrors(t *testing.T) {
+	const valid = "iota1qpf0mlq8yxpx2nck8a0slxnzr4ef2ek8f5gqxlzd0wasgp73utryj430ldu"
+
+	var tests = []*struct {
+		name         string
+		s            string
+		expPositions [][]int
+		expErr       error
+	}{
+		{name: "valid", s: valid},
+		{name: "single error", s: substitute(valid, 10), expPositions: [][]int{{10}}},
+		{name: "single error in checksum", s: substitute(valid, len(valid)-1), expPositions: [][]int{{len(valid) - 1}}},
+		{name: "two errors", s: substitute(valid, 7, 40), expPositions: [][]int{{7, 40}}},
+		{name: "non-charset character", s: valid[:12] + "b" + valid[13:], expPositions: [][]int{{12}}},
+		{name: "non-charset character and error", s: substitute(valid[:12]+"o"+valid[13:], 30), expPositions: [][]int{{12, 30}}},
+		{name: "upper case", s: strings.ToUpper(substitute(valid, 20)), expPositions: [][]int{{20}}},
+		{name: "too many non-charset characters", s: valid[:10] + "bio" + valid[13:], expErr: ErrTooManyErrors},
+		{name: "missing separator", s: "qpzry9x8gf2tvdw0s3jn54khce6mua7l", expErr: ErrMissingSeparator},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			corrections, err := LocateErrors(tt.s)
+			if tt.expErr != nil {
+				assert.True(t, errors.Is(err, tt.expErr), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expPositions == nil {
+				assert.Empty(t, corrections)
+				return
+			}
+			require.NotEmpty(t, corrections)
+			// the most likely candidate corrects the actual errors
+			assert.Equal(t, tt.expPositions[0], corrections[0].Positions)
+			assert.Equal(t, strings.ToLower(valid), strings.ToLower(corrections[0].Corrected))
+			for _, correction := range corrections {
+				_, _, err := Decode(correction.Corrected)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLocateErrors_TooManyErrors(t *testing.T) {
+	const valid = "iota1qpf0mlq8yxpx2nck8a0slxnzr4ef2ek8f5gqxlzd0wasgp73utryj430ldu"
+	// more than two errors can not be located, but might lead to unrelated candidates
+	corrections, err := LocateErrors(substitute(valid, 6, 20, 35, 50))
+	if err == nil {
+		for _, correction := range corrections {
+			assert.NotEqual(t, valid, correction.Corrected)
+		}
+		return
+	}
+	assert.True(t, errors.Is(err, ErrTooManyErrors))
+}
+
+func TestCodec_LocateErrors_Bech32m(t *testing.T) {
+	codec := Codec{Variant: Bech32m}
+	valid, err := codec.Encode("atoi", []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	require.NoError(t, err)
+
+	corrections, err := codec.LocateErrors(substitute(valid, 8))
+	require.NoError(t, err)
+	require.NotEmpty(t, corrections)
+	assert.Equal(t, []int{8}, corrections[0].Positions)
+	assert.Equal(t, valid, corrections[0].Corrected)
+}
@@ -108,3 +108,60 @@ func BenchmarkSerializeAndHashMessageWithTransactionPayload(b *testing.B) {
 		_, _ = m.ID()
 	}
 }
+
+func BenchmarkDeserializeWithoutValidationMessageWithTransactionPayload(b *testing.B) {
+	m := &iotago.Message{
+		Parents: tpkg.SortedRand32BytArray(2),
+		Payload: tpkg.OneInputOutputTransaction(),
+	}
+	data, err := m.Serialize(serializer.DeSeriModeNoValidation)
+	tpkg.Must(err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		target := &iotago.Message{}
+		_, _ = target.Deserialize(data, serializer.DeSeriModeNoValidation)
+	}
+}
+
+func BenchmarkMessageViewWithTransactionPayload(b *testing.B) {
+	m := &iotago.Message{
+		Parents: tpkg.SortedRand32BytArray(2),
+		Payload: tpkg.OneInputOutputTransaction(),
+	}
+	data, err := m.Serialize(serializer.DeSeriModeNoValidation)
+	tpkg.Must(err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		view, err := iotago.NewMessageView(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		txView, _ := view.Transaction()
+		for j := 0; j < txView.OutputsCount(); j++ {
+			_ = txView.Output(j).Ed25519Address()
+		}
+	}
+}
+
+func BenchmarkMessageViewIDWithTransactionPayload(b *testing.B) {
+	m := &iotago.Message{
+		Parents: tpkg.SortedRand32BytArray(2),
+		Payload: tpkg.OneInputOutputTransaction(),
+	}
+	data, err := m.Serialize(serializer.DeSeriModeNoValidation)
+	tpkg.Must(err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		view, err := iotago.NewMessageView(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = view.ID()
+	}
+}
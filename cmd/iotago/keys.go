@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+var keygenCmd = &command{
+	usage:       "[-seed <hex>] [-hrp <prefix>]",
+	description: "generates an Ed25519 key pair and its address",
+}
+
+var addressCmd = &command{
+	usage:       "[-hrp <prefix>] (-pubkey <hex> | <bech32 or hex address>)",
+	description: "converts a public key or address into the hex and Bech32 address forms",
+}
+
+func init() {
+	keygenCmd.run = runKeygen
+	addressCmd.run = runAddress
+}
+
+func runKeygen(e *env, args []string) error {
+	fs := newFlagSet(e, "keygen")
+	seedHex := fs.String("seed", "", "the hex encoded seed to derive the key pair from, random if omitted")
+	hrp := fs.String("hrp", string(iotago.PrefixMainnet), "the network prefix of the Bech32 address")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var prvKey ed25519.PrivateKey
+	if *seedHex == "" {
+		var err error
+		if _, prvKey, err = ed25519.GenerateKey(nil); err != nil {
+			return fmt.Errorf("unable to generate key: %w", err)
+		}
+	} else {
+		var err error
+		if prvKey, err = privateKeyFromSeed(*seedHex); err != nil {
+			return err
+		}
+	}
+
+	pubKey := prvKey.Public().(ed25519.PublicKey)
+	addr := iotago.AddressFromEd25519PubKey(pubKey)
+	fmt.Fprintf(e.stdout, "seed: %s\n", hex.EncodeToString(prvKey.Seed()))
+	fmt.Fprintf(e.stdout, "private key: %s\n", hex.EncodeToString(prvKey))
+	fmt.Fprintf(e.stdout, "public key: %s\n", hex.EncodeToString(pubKey))
+	fmt.Fprintf(e.stdout, "address: %s\n", hex.EncodeToString(addr[:]))
+	fmt.Fprintf(e.stdout, "bech32: %s\n", addr.Bech32(iotago.NetworkPrefix(*hrp)))
+	return nil
+}
+
+func runAddress(e *env, args []string) error {
+	fs := newFlagSet(e, "address")
+	pubKeyHex := fs.String("pubkey", "", "the hex encoded Ed25519 public key to derive the address from")
+	hrp := fs.String("hrp", string(iotago.PrefixMainnet), "the network prefix of the Bech32 address")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var addr *iotago.Ed25519Address
+	switch {
+	case *pubKeyHex != "":
+		pubKey, err := hex.DecodeString(*pubKeyHex)
+		if err != nil || len(pubKey) != ed25519.PublicKeySize {
+			return fmt.Errorf("%w: public key must be %d hex encoded bytes", ErrInvalidArguments, ed25519.PublicKeySize)
+		}
+		edAddr := iotago.AddressFromEd25519PubKey(pubKey)
+		addr = &edAddr
+	case fs.NArg() == 1:
+		var err error
+		if addr, err = parseAddress(fs.Arg(0)); err != nil {
+			return err
+		}
+	default:
+		fs.Usage()
+		return fmt.Errorf("%w: either a public key or an address is required", ErrInvalidArguments)
+	}
+
+	fmt.Fprintf(e.stdout, "address: %s\n", hex.EncodeToString(addr[:]))
+	fmt.Fprintf(e.stdout, "bech32: %s\n", addr.Bech32(iotago.NetworkPrefix(*hrp)))
+	return nil
+}
+
+// parses the given Bech32 or hex encoded Ed25519 address.
+func parseAddress(s string) (*iotago.Ed25519Address, error) {
+	if _, bech32Addr, err := iotago.ParseBech32(s); err == nil {
+		addr, ok := bech32Addr.(*iotago.Ed25519Address)
+		if !ok {
+			return nil, fmt.Errorf("%w: only Ed25519 addresses are supported", ErrInvalidArguments)
+		}
+		return addr, nil
+	}
+	addrBytes, err := hex.DecodeString(s)
+	if err != nil || len(addrBytes) != iotago.Ed25519AddressBytesLength {
+		return nil, fmt.Errorf("%w: %s is neither a Bech32 nor a hex encoded Ed25519 address", ErrInvalidArguments, s)
+	}
+	addr := &iotago.Ed25519Address{}
+	copy(addr[:], addrBytes)
+	return addr, nil
+}
+
+// derives the Ed25519 private key from the given hex encoded seed.
+func privateKeyFromSeed(seedHex string) (ed25519.PrivateKey, error) {
+	seed, err := hex.DecodeString(seedHex)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%w: seed must be %d hex encoded bytes", ErrInvalidArguments, ed25519.SeedSize)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
@@ -0,0 +1,119 @@
+// Command iotago is a command-line tool to encode, decode, sign and submit messages.
+//
+// All commands except submit work offline. Messages are read from the positional argument or
+// from stdin if it is omitted or "-", either as hex encoded bytes or in their JSON form.
+//
+// Usage:
+//
+//	iotago <command> [flags] [args]
+//
+// Run "iotago help" to list the available commands.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+var (
+	// ErrUnknownCommand gets returned when the given command does not exist.
+	ErrUnknownCommand = errors.New("unknown command")
+	// ErrInvalidArguments gets returned when the arguments given to a command are invalid.
+	ErrInvalidArguments = errors.New("invalid arguments")
+)
+
+// env holds the streams commands read from and write to.
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// command is a sub command of the tool.
+type command struct {
+	// The synopsis of the arguments of the command.
+	usage string
+	// A short description of what the command does.
+	description string
+	// Executes the command with the given arguments.
+	run func(e *env, args []string) error
+}
+
+var commands = map[string]*command{
+	"decode":           decodeCmd,
+	"id":               idCmd,
+	"keygen":           keygenCmd,
+	"address":          addressCmd,
+	"transfer":         transferCmd,
+	"pow":              powCmd,
+	"submit":           submitCmd,
+	"verify-milestone": verifyMilestoneCmd,
+}
+
+func main() {
+	e := &env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	if err := run(e, os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(e.stderr, "error: %s\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// runs the command denoted by the first argument.
+func run(e *env, args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(e.stdout)
+		return nil
+	}
+	cmd, has := commands[args[0]]
+	if !has {
+		printUsage(e.stderr)
+		return fmt.Errorf("%w: %s", ErrUnknownCommand, args[0])
+	}
+	return cmd.run(e, args[1:])
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: iotago <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-18s %s\n", name, commands[name].description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "iotago <command> -h" for the flags of a command.`)
+}
+
+// creates the flag set of the given command.
+func newFlagSet(e *env, name string) *flag.FlagSet {
+	cmd := commands[name]
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "Usage: iotago %s %s\n\n%s\n\nFlags:\n", name, cmd.usage, cmd.description)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// stringsFlag is a flag which can be given multiple times.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return fmt.Sprint([]string(*s))
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/pow"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+const testSeed = "0000000000000000000000000000000000000000000000000000000000000001"
+
+// runs the tool with the given arguments and stdin and returns what it wrote to stdout.
+func runTool(t *testing.T, stdin string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	err := run(&env{stdin: strings.NewReader(stdin), stdout: &stdout, stderr: &stderr}, args)
+	return stdout.String(), err
+}
+
+// parses "key: value" lines.
+func parseLines(out string) map[string]string {
+	values := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if sep := strings.Index(line, ": "); sep != -1 {
+			values[line[:sep]] = line[sep+2:]
+		}
+	}
+	return values
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	_, err := runTool(t, "", "foo")
+	assert.True(t, errors.Is(err, ErrUnknownCommand))
+
+	out, err := runTool(t, "")
+	require.NoError(t, err)
+	assert.Contains(t, out, "verify-milestone")
+}
+
+func TestKeygenAndAddress(t *testing.T) {
+	out, err := runTool(t, "", "keygen", "-seed", testSeed, "-hrp", "atoi")
+	require.NoError(t, err)
+	keys := parseLines(out)
+
+	seed, _ := hex.DecodeString(testSeed)
+	pubKey := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+	addr := iotago.AddressFromEd25519PubKey(pubKey)
+	assert.Equal(t, testSeed, keys["seed"])
+	assert.Equal(t, hex.EncodeToString(pubKey), keys["public key"])
+	assert.Equal(t, hex.EncodeToString(addr[:]), keys["address"])
+	assert.Equal(t, addr.Bech32(iotago.PrefixTestnet), keys["bech32"])
+
+	out, err = runTool(t, "", "address", "-pubkey", keys["public key"])
+	require.NoError(t, err)
+	assert.Equal(t, addr.Bech32(iotago.PrefixMainnet), parseLines(out)["bech32"])
+
+	out, err = runTool(t, "", "address", keys["bech32"])
+	require.NoError(t, err)
+	assert.Equal(t, keys["address"], parseLines(out)["address"])
+
+	out, err = runTool(t, "", "keygen")
+	require.NoError(t, err)
+	assert.NotEqual(t, keys["seed"], parseLines(out)["seed"])
+
+	_, err = runTool(t, "", "keygen", "-seed", "abcd")
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+}
+
+func TestTransfer(t *testing.T) {
+	targetAddr, _ := tpkg.RandEd25519Address()
+	txID := tpkg.Rand32ByteArray()
+	parents := tpkg.SortedRand32BytArray(2)
+
+	out, err := runTool(t, "", "transfer",
+		"-seed", testSeed,
+		"-input", fmt.Sprintf("%s:1", hex.EncodeToString(txID[:])),
+		"-output", fmt.Sprintf("%s:1000000", targetAddr.Bech32(iotago.PrefixMainnet)),
+		"-index", "cli",
+		"-data", "hello",
+		"-network-id", "testnet",
+		"-parent", hex.EncodeToString(parents[0][:]),
+		"-parent", hex.EncodeToString(parents[1][:]),
+		"-pow", "1",
+	)
+	require.NoError(t, err)
+
+	msgBytes, err := hex.DecodeString(strings.TrimSpace(out))
+	require.NoError(t, err)
+	msg := &iotago.Message{}
+	_, err = msg.Deserialize(msgBytes, serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+	assert.Equal(t, iotago.NetworkIDFromString("testnet"), msg.NetworkID)
+	assert.GreaterOrEqual(t, pow.Score(msgBytes), 1.0)
+
+	tx := msg.Payload.(*iotago.Transaction)
+	essence := tx.Essence.(*iotago.TransactionEssence)
+	assert.Equal(t, &iotago.UTXOInput{TransactionID: txID, TransactionOutputIndex: 1}, essence.Inputs[0])
+	assert.Equal(t, &iotago.SigLockedSingleOutput{Address: targetAddr, Amount: 1_000_000}, essence.Outputs[0])
+	assert.Equal(t, &iotago.Indexation{Index: []byte("cli"), Data: []byte("hello")}, essence.Payload)
+
+	// the transfer is signed by the seed's address
+	seed, _ := hex.DecodeString(testSeed)
+	inputAddr := iotago.AddressFromEd25519PubKey(ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey))
+	require.NoError(t, tx.SemanticallyValidate(iotago.InputToOutputMapping{
+		essence.Inputs[0].(*iotago.UTXOInput).ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 1_000_000},
+	}))
+
+	// decode and compute the IDs of the created message read from stdin
+	out, err = runTool(t, out, "decode")
+	require.NoError(t, err)
+	assert.Contains(t, out, targetAddr.Bech32(iotago.PrefixMainnet))
+	assert.Contains(t, out, "validity: valid")
+
+	out, err = runTool(t, hex.EncodeToString(msgBytes), "id")
+	require.NoError(t, err)
+	ids := parseLines(out)
+	msgID := msg.MustID()
+	actualTxID, err := tx.ID()
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(msgID[:]), ids["message"])
+	assert.Equal(t, hex.EncodeToString(actualTxID[:]), ids["transaction"])
+
+	_, err = runTool(t, "", "transfer", "-seed", testSeed)
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+}
+
+func TestPoW(t *testing.T) {
+	msg := &iotago.Message{Parents: tpkg.SortedRand32BytArray(1), Payload: &iotago.Indexation{Index: []byte("pow")}}
+	msgBytes, err := msg.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	out, err := runTool(t, "", "pow", "-score", "10", hex.EncodeToString(msgBytes))
+	require.NoError(t, err)
+	powMsgBytes, err := hex.DecodeString(strings.TrimSpace(out))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, pow.Score(powMsgBytes), 10.0)
+	// only the nonce changes
+	assert.Equal(t, msgBytes[:len(msgBytes)-serializer.UInt64ByteSize], powMsgBytes[:len(powMsgBytes)-serializer.UInt64ByteSize])
+}
+
+func TestVerifyMilestone(t *testing.T) {
+	prvKeys := []ed25519.PrivateKey{tpkg.RandEd25519PrivateKey(), tpkg.RandEd25519PrivateKey()}
+	keyMapping := iotago.MilestonePublicKeyMapping{}
+	pubKeys := make([]iotago.MilestonePublicKey, len(prvKeys))
+	pubKeysHex := make([]string, len(prvKeys))
+	for i, prvKey := range prvKeys {
+		copy(pubKeys[i][:], prvKey.Public().(ed25519.PublicKey))
+		keyMapping[pubKeys[i]] = prvKey
+		pubKeysHex[i] = hex.EncodeToString(pubKeys[i][:])
+	}
+
+	parents := tpkg.SortedRand32BytArray(1)
+	ms, err := iotago.NewMilestone(1000, 1609459200, parents, [iotago.MilestoneInclusionMerkleProofLength]byte{}, pubKeys)
+	require.NoError(t, err)
+	require.NoError(t, ms.Sign(iotago.InMemoryEd25519MilestoneSigner(keyMapping)))
+
+	msg := &iotago.Message{Parents: parents, Payload: ms}
+	msgJSON, err := msg.MarshalJSON()
+	require.NoError(t, err)
+
+	out, err := runTool(t, string(msgJSON), "verify-milestone", "-keys", strings.Join(pubKeysHex, ","))
+	require.NoError(t, err)
+	assert.Equal(t, "milestone 1000: 2 valid signatures\n", out)
+
+	_, err = runTool(t, string(msgJSON), "verify-milestone", "-keys", pubKeysHex[0])
+	assert.True(t, errors.Is(err, iotago.ErrMilestoneSignatureThresholdGreaterThanApplicablePublicKeySet))
+
+	ms.Signatures[1][0] ^= 0xff
+	msgJSON, err = msg.MarshalJSON()
+	require.NoError(t, err)
+	_, err = runTool(t, string(msgJSON), "verify-milestone", "-keys", strings.Join(pubKeysHex, ","))
+	assert.True(t, errors.Is(err, iotago.ErrMilestoneInvalidSignature))
+}
+
+func TestSubmit(t *testing.T) {
+	defer gock.Off()
+
+	const nodeAPIUrl = "http://127.0.0.1:14265"
+
+	msgID := tpkg.Rand32ByteArray()
+	msgIDHex := hex.EncodeToString(msgID[:])
+	completeMsg := &iotago.Message{Parents: tpkg.SortedRand32BytArray(1), Payload: &iotago.Indexation{Index: []byte("submit")}}
+	completeMsgBytes, err := completeMsg.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	gock.New(nodeAPIUrl).
+		Post(iotago.NodeAPIRouteMessages).
+		Reply(201).
+		AddHeader("Location", msgIDHex)
+
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMessageBytes, msgIDHex)).
+		Reply(200).
+		Body(bytes.NewReader(completeMsgBytes))
+
+	// a message without parents which the node completes
+	incompleteMsg := &iotago.Message{Payload: &iotago.Indexation{Index: []byte("submit")}}
+	incompleteMsgBytes, err := incompleteMsg.Serialize(serializer.DeSeriModeNoValidation)
+	require.NoError(t, err)
+
+	out, err := runTool(t, "", "submit", "-node", nodeAPIUrl, hex.EncodeToString(incompleteMsgBytes))
+	require.NoError(t, err)
+	completeMsgID := completeMsg.MustID()
+	assert.Equal(t, fmt.Sprintf("message: %s\n", hex.EncodeToString(completeMsgID[:])), out)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/iotaledger/hive.go/serializer"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/pretty"
+)
+
+var decodeCmd = &command{
+	usage:       "[-hrp <prefix>] [message]",
+	description: "decodes a hex or JSON encoded message into its human-readable form",
+}
+
+var idCmd = &command{
+	usage:       "[message]",
+	description: "computes the message ID and the ID of the transaction or milestone payload",
+}
+
+func init() {
+	decodeCmd.run = runDecode
+	idCmd.run = runID
+}
+
+func runDecode(e *env, args []string) error {
+	fs := newFlagSet(e, "decode")
+	hrp := fs.String("hrp", string(iotago.PrefixMainnet), "the network prefix used to render addresses")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	// also decode syntactically invalid messages, e.g. ones without parents
+	msg, err := readMessageWithMode(e, fs.Arg(0), serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return err
+	}
+	return pretty.NewPrinter(pretty.WithNetworkPrefix(iotago.NetworkPrefix(*hrp))).Fprint(e.stdout, msg)
+}
+
+func runID(e *env, args []string) error {
+	fs := newFlagSet(e, "id")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	msg, err := readMessageWithMode(e, fs.Arg(0), serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return err
+	}
+
+	msgID, err := msg.ID()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "message: %s\n", hex.EncodeToString(msgID[:]))
+
+	switch payload := msg.Payload.(type) {
+	case *iotago.Transaction:
+		txID, err := payload.ID()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stdout, "transaction: %s\n", hex.EncodeToString(txID[:]))
+	case *iotago.Milestone:
+		msID, err := payload.ID()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stdout, "milestone: %s\n", hex.EncodeToString(msID[:]))
+	}
+	return nil
+}
+
+// reads a message from the given argument or from stdin if the argument is empty or "-".
+// The message is either given as hex encoded bytes or in its JSON form.
+func readMessage(e *env, arg string) (*iotago.Message, error) {
+	return readMessageWithMode(e, arg, serializer.DeSeriModePerformValidation)
+}
+
+// like readMessage but deserializes binary messages with the given mode.
+func readMessageWithMode(e *env, arg string, deSeriMode serializer.DeSerializationMode) (*iotago.Message, error) {
+	var data []byte
+	if arg == "" || arg == "-" {
+		var err error
+		if data, err = ioutil.ReadAll(e.stdin); err != nil {
+			return nil, fmt.Errorf("unable to read message from stdin: %w", err)
+		}
+	} else {
+		data = []byte(arg)
+	}
+	data = bytes.TrimSpace(data)
+
+	msg := &iotago.Message{}
+	if bytes.HasPrefix(data, []byte("{")) {
+		if err := json.Unmarshal(data, msg); err != nil {
+			return nil, fmt.Errorf("unable to decode JSON message: %w", err)
+		}
+		return msg, nil
+	}
+
+	msgBytes, err := hex.DecodeString(strings.TrimPrefix(string(data), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode hex message: %w", err)
+	}
+	if _, err := msg.Deserialize(msgBytes, deSeriMode); err != nil {
+		return nil, fmt.Errorf("unable to deserialize message: %w", err)
+	}
+	return msg, nil
+}
+
+// writes the given message hex encoded or in its JSON form.
+func writeMessage(w io.Writer, msg *iotago.Message, asJSON bool) error {
+	if asJSON {
+		jsonMsg, err := json.MarshalIndent(msg, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(jsonMsg))
+		return err
+	}
+	// parents might be missing when the node is supposed to fill them in
+	msgBytes, err := msg.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, hex.EncodeToString(msgBytes))
+	return err
+}
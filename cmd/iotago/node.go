@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/iotaledger/hive.go/serializer"
+
+	"github.com/iotaledger/iota.go/v2"
+)
+
+var submitCmd = &command{
+	usage:       "[-node <url>] [-user <user:password>] [-timeout <duration>] [message]",
+	description: "submits a message to a node",
+}
+
+var verifyMilestoneCmd = &command{
+	usage:       "-keys <hex,...> [-threshold <n>] [message]",
+	description: "verifies the signatures of a milestone message against a set of public keys",
+}
+
+func init() {
+	submitCmd.run = runSubmit
+	verifyMilestoneCmd.run = runVerifyMilestone
+}
+
+func runSubmit(e *env, args []string) error {
+	fs := newFlagSet(e, "submit")
+	nodeURL := fs.String("node", "http://127.0.0.1:14265", "the URL of the node's HTTP API")
+	user := fs.String("user", "", "the basic auth credentials as <user>:<password>")
+	timeout := fs.Duration("timeout", 30*time.Second, "the timeout of the submission")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	// the node fills in missing parents and validates the message itself
+	msg, err := readMessageWithMode(e, fs.Arg(0), serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return err
+	}
+
+	var opts []iotago.NodeHTTPAPIClientOption
+	if *user != "" {
+		userInfo := url.User(*user)
+		if sep := strings.Index(*user, ":"); sep != -1 {
+			userInfo = url.UserPassword((*user)[:sep], (*user)[sep+1:])
+		}
+		opts = append(opts, iotago.WithNodeHTTPAPIClientUserInfo(userInfo))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	submitted, err := iotago.NewNodeHTTPAPIClient(*nodeURL, opts...).SubmitMessage(ctx, msg)
+	if err != nil {
+		return fmt.Errorf("unable to submit message: %w", err)
+	}
+	msgID, err := submitted.ID()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "message: %s\n", hex.EncodeToString(msgID[:]))
+	return nil
+}
+
+func runVerifyMilestone(e *env, args []string) error {
+	fs := newFlagSet(e, "verify-milestone")
+	keys := fs.String("keys", "", "comma separated hex encoded public keys which are applicable for the milestone")
+	threshold := fs.Int("threshold", 0, "the minimum amount of signatures, all of the milestone's signatures if 0")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *keys == "" {
+		fs.Usage()
+		return fmt.Errorf("%w: the applicable public keys are required", ErrInvalidArguments)
+	}
+
+	keySet := iotago.MilestonePublicKeySet{}
+	for _, key := range strings.Split(*keys, ",") {
+		keyBytes, err := hex.DecodeString(strings.TrimSpace(key))
+		if err != nil || len(keyBytes) != iotago.MilestonePublicKeyLength {
+			return fmt.Errorf("%w: invalid public key %s", ErrInvalidArguments, key)
+		}
+		var pubKey iotago.MilestonePublicKey
+		copy(pubKey[:], keyBytes)
+		keySet[pubKey] = struct{}{}
+	}
+
+	msg, err := readMessage(e, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	ms, ok := msg.Payload.(*iotago.Milestone)
+	if !ok {
+		return fmt.Errorf("%w: message does not contain a milestone", ErrInvalidArguments)
+	}
+
+	minSigThreshold := *threshold
+	if minSigThreshold == 0 {
+		minSigThreshold = len(ms.Signatures)
+	}
+	if err := ms.VerifySignatures(minSigThreshold, keySet); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "milestone %d: %d valid signatures\n", ms.Index, len(ms.Signatures))
+	return nil
+}
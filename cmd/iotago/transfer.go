@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+var transferCmd = &command{
+	usage:       "-seed <hex> -input <txID:index>... -output <address:amount>... [flags]",
+	description: "builds and signs a transfer and wraps it into a message",
+}
+
+var powCmd = &command{
+	usage:       "[-score <score>] [-workers <n>] [-json] [message]",
+	description: "does the proof-of-work for a message",
+}
+
+func init() {
+	transferCmd.run = runTransfer
+	powCmd.run = runPoW
+}
+
+func runTransfer(e *env, args []string) error {
+	var inputs, outputs, parents stringsFlag
+	fs := newFlagSet(e, "transfer")
+	seedHex := fs.String("seed", "", "the hex encoded seed of the key owning the inputs")
+	fs.Var(&inputs, "input", "an output to consume as <hex transaction ID>:<output index>, can be given multiple times")
+	fs.Var(&outputs, "output", "an output to create as <Bech32 or hex address>:<amount>, can be given multiple times")
+	dustAllowance := fs.Bool("dust-allowance", false, "whether the outputs are dust allowance outputs")
+	index := fs.String("index", "", "the index of an optional indexation payload")
+	data := fs.String("data", "", "the data of the optional indexation payload")
+	networkID := fs.String("network-id", "", "the network ID string, e.g. \"chrysalis-mainnet\"")
+	fs.Var(&parents, "parent", "a hex encoded parent message ID, can be given multiple times, the node fills them in if omitted")
+	targetScore := fs.Float64("pow", 0, "the target score of the proof-of-work to do, none if 0")
+	asJSON := fs.Bool("json", false, "whether to output the message in its JSON form")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *seedHex == "" || len(inputs) == 0 || len(outputs) == 0 {
+		fs.Usage()
+		return fmt.Errorf("%w: a seed, inputs and outputs are required", ErrInvalidArguments)
+	}
+	prvKey, err := privateKeyFromSeed(*seedHex)
+	if err != nil {
+		return err
+	}
+	addr := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+
+	txBuilder := iotago.NewTransactionBuilder()
+	for _, input := range inputs {
+		utxoInput, err := parseUTXOInput(input)
+		if err != nil {
+			return err
+		}
+		txBuilder.AddInput(&iotago.ToBeSignedUTXOInput{Address: &addr, Input: utxoInput})
+	}
+	for _, output := range outputs {
+		target, amount, err := parseOutput(output)
+		if err != nil {
+			return err
+		}
+		if *dustAllowance {
+			txBuilder.AddOutput(&iotago.SigLockedDustAllowanceOutput{Address: target, Amount: amount})
+			continue
+		}
+		txBuilder.AddOutput(&iotago.SigLockedSingleOutput{Address: target, Amount: amount})
+	}
+	if *index != "" {
+		txBuilder.AddIndexationPayload(&iotago.Indexation{Index: []byte(*index), Data: []byte(*data)})
+	}
+
+	tx, err := txBuilder.Build(iotago.NewInMemoryAddressSigner(iotago.NewAddressKeysForEd25519Address(&addr, prvKey)))
+	if err != nil {
+		return fmt.Errorf("unable to build transaction: %w", err)
+	}
+
+	msgBuilder := iotago.NewMessageBuilder().NetworkIDFromString(*networkID).Payload(tx)
+	if len(parents) > 0 {
+		parentIDs, err := parseMessageIDs(parents)
+		if err != nil {
+			return err
+		}
+		msgBuilder.ParentsMessageIDs(parentIDs)
+	}
+	if *targetScore > 0 {
+		if len(parents) == 0 {
+			return fmt.Errorf("%w: proof-of-work requires parents", ErrInvalidArguments)
+		}
+		msgBuilder.ProofOfWork(context.Background(), *targetScore)
+	}
+	msg, err := msgBuilder.Build()
+	if err != nil {
+		return fmt.Errorf("unable to build message: %w", err)
+	}
+	return writeMessage(e.stdout, msg, *asJSON)
+}
+
+func runPoW(e *env, args []string) error {
+	fs := newFlagSet(e, "pow")
+	targetScore := fs.Float64("score", 4000, "the target score of the proof-of-work")
+	workers := fs.Int("workers", 0, "the number of workers to use, the number of CPUs if 0")
+	asJSON := fs.Bool("json", false, "whether to output the message in its JSON form")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	msg, err := readMessage(e, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var numWorkers []int
+	if *workers > 0 {
+		numWorkers = append(numWorkers, *workers)
+	}
+	msg, err = iotago.NewMessageBuilder().
+		NetworkID(msg.NetworkID).
+		ParentsMessageIDs(msg.Parents).
+		Payload(msg.Payload).
+		ProofOfWork(context.Background(), *targetScore, numWorkers...).
+		Build()
+	if err != nil {
+		return err
+	}
+	return writeMessage(e.stdout, msg, *asJSON)
+}
+
+// parses an UTXO input given as <hex transaction ID>:<output index>.
+func parseUTXOInput(s string) (*iotago.UTXOInput, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("%w: input %s must be given as <transaction ID>:<output index>", ErrInvalidArguments, s)
+	}
+	txID, err := hex.DecodeString(parts[0])
+	if err != nil || len(txID) != iotago.TransactionIDLength {
+		return nil, fmt.Errorf("%w: invalid transaction ID in input %s", ErrInvalidArguments, s)
+	}
+	outputIndex, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid output index in input %s: %s", ErrInvalidArguments, s, err)
+	}
+	utxoInput := &iotago.UTXOInput{TransactionOutputIndex: uint16(outputIndex)}
+	copy(utxoInput.TransactionID[:], txID)
+	return utxoInput, nil
+}
+
+// parses an output given as <address>:<amount>.
+func parseOutput(s string) (*iotago.Ed25519Address, uint64, error) {
+	sep := strings.LastIndex(s, ":")
+	if sep == -1 {
+		return nil, 0, fmt.Errorf("%w: output %s must be given as <address>:<amount>", ErrInvalidArguments, s)
+	}
+	addr, err := parseAddress(s[:sep])
+	if err != nil {
+		return nil, 0, err
+	}
+	amount, err := strconv.ParseUint(s[sep+1:], 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: invalid amount in output %s: %s", ErrInvalidArguments, s, err)
+	}
+	return addr, amount, nil
+}
+
+// parses the given hex encoded message IDs.
+func parseMessageIDs(ids []string) (iotago.MessageIDs, error) {
+	msgIDs := make(iotago.MessageIDs, len(ids))
+	for i, id := range ids {
+		msgID, err := hex.DecodeString(id)
+		if err != nil || len(msgID) != iotago.MessageIDLength {
+			return nil, fmt.Errorf("%w: invalid message ID %s", ErrInvalidArguments, id)
+		}
+		copy(msgIDs[i][:], msgID)
+	}
+	return msgIDs, nil
+}
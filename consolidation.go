@@ -0,0 +1,572 @@
+package iotago
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrConsolidationBelowMinDeposit gets returned when a consolidation would create a dust output on a target
+	// address other than the source address.
+	ErrConsolidationBelowMinDeposit = errors.New("consolidation creates a dust output on the target address")
+	// ErrConsolidationConflicting gets returned when a consolidation transaction was conflicting.
+	ErrConsolidationConflicting = errors.New("consolidation transaction is conflicting")
+	// ErrConsolidationStateMismatch gets returned when a stored consolidation state belongs to other addresses.
+	ErrConsolidationStateMismatch = errors.New("stored consolidation state belongs to other addresses")
+)
+
+// ConsolidationBatchStatus defines the status of a ConsolidationBatch.
+type ConsolidationBatchStatus string
+
+const (
+	// ConsolidationBatchPending denotes that the batch has not been submitted yet.
+	ConsolidationBatchPending ConsolidationBatchStatus = "pending"
+	// ConsolidationBatchSubmitted denotes that the batch has been submitted and awaits its confirmation.
+	ConsolidationBatchSubmitted ConsolidationBatchStatus = "submitted"
+	// ConsolidationBatchConfirmed denotes that the transaction of the batch mutated the ledger.
+	ConsolidationBatchConfirmed ConsolidationBatchStatus = "confirmed"
+	// ConsolidationBatchConflicting denotes that the transaction of the batch was conflicting.
+	ConsolidationBatchConflicting ConsolidationBatchStatus = "conflicting"
+)
+
+// ConsolidationBatch is a set of outputs swept by one transaction.
+type ConsolidationBatch struct {
+	// The outputs consumed by the batch.
+	Inputs []OutputIDHex `json:"inputs"`
+	// The deposit sum of the consumed outputs.
+	Amount uint64 `json:"amount"`
+	// The status of the batch.
+	Status ConsolidationBatchStatus `json:"status"`
+	// The hex encoded ID of the transaction, once it has been built.
+	TransactionID string `json:"transactionId,omitempty"`
+	// The hex encoded ID of the message containing the transaction, once it has been submitted.
+	MessageID string `json:"messageId,omitempty"`
+	// The milestone index which referenced the message.
+	ReferencedByMilestoneIndex uint32 `json:"referencedByMilestoneIndex,omitempty"`
+	// The reason why the transaction was conflicting.
+	ConflictReason ConflictReason `json:"conflictReason,omitempty"`
+}
+
+// ConsolidationState is the resumable state of a consolidation.
+type ConsolidationState struct {
+	// The hex encoded source address.
+	Source string `json:"source"`
+	// The hex encoded target address.
+	Target string `json:"target"`
+	// The batches in the order they are swept.
+	Batches []*ConsolidationBatch `json:"batches"`
+}
+
+// Done tells whether all batches of the consolidation are confirmed.
+func (s *ConsolidationState) Done() bool {
+	for _, batch := range s.Batches {
+		if batch.Status != ConsolidationBatchConfirmed {
+			return false
+		}
+	}
+	return true
+}
+
+// ConsolidationStore persists the ConsolidationState, so that a consolidation can be resumed.
+type ConsolidationStore interface {
+	// LoadConsolidation loads the stored state or returns nil if there is none.
+	LoadConsolidation() (*ConsolidationState, error)
+	// StoreConsolidation stores the given state.
+	StoreConsolidation(state *ConsolidationState) error
+}
+
+// NewInMemoryConsolidationStore creates a new ConsolidationStore which keeps the state in memory.
+func NewInMemoryConsolidationStore() ConsolidationStore {
+	return &inMemoryConsolidationStore{}
+}
+
+type inMemoryConsolidationStore struct {
+	mu    sync.Mutex
+	state []byte
+}
+
+func (s *inMemoryConsolidationStore) LoadConsolidation() (*ConsolidationState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state == nil {
+		return nil, nil
+	}
+	state := &ConsolidationState{}
+	if err := json.Unmarshal(s.state, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (s *inMemoryConsolidationStore) StoreConsolidation(state *ConsolidationState) error {
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = stateJSON
+	return nil
+}
+
+// NewFileConsolidationStore creates a new ConsolidationStore which keeps the state as JSON in the given file.
+// The file is replaced atomically on every store.
+func NewFileConsolidationStore(path string) ConsolidationStore {
+	return &fileConsolidationStore{path: path}
+}
+
+type fileConsolidationStore struct {
+	path string
+}
+
+func (s *fileConsolidationStore) LoadConsolidation() (*ConsolidationState, error) {
+	stateJSON, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read consolidation state: %w", err)
+	}
+	state := &ConsolidationState{}
+	if err := json.Unmarshal(stateJSON, state); err != nil {
+		return nil, fmt.Errorf("unable to parse consolidation state: %w", err)
+	}
+	return state, nil
+}
+
+func (s *fileConsolidationStore) StoreConsolidation(state *ConsolidationState) error {
+	stateJSON, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to store consolidation state: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(stateJSON); err != nil {
+		_ = tmpFile.Close()
+		return fmt.Errorf("unable to store consolidation state: %w", err)
+	}
+	if err := tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
+		return fmt.Errorf("unable to store consolidation state: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("unable to store consolidation state: %w", err)
+	}
+	if err := os.Rename(tmpFile.Name(), s.path); err != nil {
+		return fmt.Errorf("unable to store consolidation state: %w", err)
+	}
+	return nil
+}
+
+var defaultConsolidatorOptions = []ConsolidatorOption{
+	WithConsolidatorMaxInputs(MaxInputsCount),
+	WithConsolidatorPollInterval(5 * time.Second),
+}
+
+// ConsolidatorOptions define options for the Consolidator.
+type ConsolidatorOptions struct {
+	// The maximum amount of inputs per transaction.
+	maxInputs int
+	// The interval in which the confirmation of a submitted batch is checked.
+	pollInterval time.Duration
+	// The store persisting the state, an in-memory store if nil.
+	store ConsolidationStore
+	// The amount of workers doing the proof-of-work.
+	powWorkers []int
+}
+
+// applies the given ConsolidatorOption.
+func (co *ConsolidatorOptions) apply(opts ...ConsolidatorOption) {
+	for _, opt := range opts {
+		opt(co)
+	}
+}
+
+// WithConsolidatorMaxInputs sets the maximum amount of inputs per transaction.
+func WithConsolidatorMaxInputs(maxInputs int) ConsolidatorOption {
+	return func(opts *ConsolidatorOptions) {
+		if maxInputs < MinInputsCount+1 {
+			maxInputs = MinInputsCount + 1
+		}
+		if maxInputs > MaxInputsCount {
+			maxInputs = MaxInputsCount
+		}
+		opts.maxInputs = maxInputs
+	}
+}
+
+// WithConsolidatorPollInterval sets the interval in which the confirmation of a submitted batch is checked.
+func WithConsolidatorPollInterval(interval time.Duration) ConsolidatorOption {
+	return func(opts *ConsolidatorOptions) {
+		opts.pollInterval = interval
+	}
+}
+
+// WithConsolidatorStore sets the ConsolidationStore used to persist the state of the consolidation.
+func WithConsolidatorStore(store ConsolidationStore) ConsolidatorOption {
+	return func(opts *ConsolidatorOptions) {
+		opts.store = store
+	}
+}
+
+// WithConsolidatorPoWWorkers sets the amount of workers doing the proof-of-work.
+func WithConsolidatorPoWWorkers(workers int) ConsolidatorOption {
+	return func(opts *ConsolidatorOptions) {
+		opts.powWorkers = []int{workers}
+	}
+}
+
+// ConsolidatorOption is a function setting a Consolidator option.
+type ConsolidatorOption func(opts *ConsolidatorOptions)
+
+// NewConsolidator creates a new Consolidator sweeping the outputs of the source address to the target address.
+// The signer must be able to sign for the source address.
+func NewConsolidator(nodeHTTPAPIClient *NodeHTTPAPIClient, source *Ed25519Address, target Address, signer AddressSigner, opts ...ConsolidatorOption) *Consolidator {
+	options := &ConsolidatorOptions{}
+	options.apply(defaultConsolidatorOptions...)
+	options.apply(opts...)
+	if options.store == nil {
+		options.store = NewInMemoryConsolidationStore()
+	}
+	return &Consolidator{nodeAPI: nodeHTTPAPIClient, source: source, target: target, signer: signer, opts: options}
+}
+
+// Consolidator sweeps the outputs of an address into as few transactions as the max inputs count allows.
+//
+// Dust outputs are swept first, together with a larger output if needed so that the target does not receive dust,
+// while SigLockedDustAllowanceOutputs are only swept once no dust output is left on the source address.
+// Every batch is only submitted once the previous one is confirmed. The state is persisted in the ConsolidationStore
+// after every step, so that a consolidation can be resumed by calling Consolidate again.
+type Consolidator struct {
+	nodeAPI *NodeHTTPAPIClient
+	source  *Ed25519Address
+	target  Address
+	signer  AddressSigner
+	opts    *ConsolidatorOptions
+	info    *NodeInfoResponse
+}
+
+// Consolidate resumes the stored consolidation or, if there is none or it is done, scans the outputs
+// of the source address and starts a new one. It blocks until every batch is confirmed and returns the final state.
+func (c *Consolidator) Consolidate(ctx context.Context) (*ConsolidationState, error) {
+	state, err := c.opts.store.LoadConsolidation()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load consolidation state: %w", err)
+	}
+
+	if state != nil && (state.Source != c.source.String() || state.Target != c.target.String()) {
+		return nil, fmt.Errorf("%w: source %s, target %s", ErrConsolidationStateMismatch, state.Source, state.Target)
+	}
+
+	if state == nil || state.Done() {
+		if state, err = c.scan(ctx); err != nil {
+			return nil, err
+		}
+		if err := c.opts.store.StoreConsolidation(state); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, batch := range state.Batches {
+		for batch.Status != ConsolidationBatchConfirmed {
+			switch batch.Status {
+			case ConsolidationBatchPending:
+				err = c.submit(ctx, state, batch)
+			case ConsolidationBatchSubmitted:
+				err = c.await(ctx, batch)
+			case ConsolidationBatchConflicting:
+				return state, fmt.Errorf("%w: batch %d, conflict reason: %s", ErrConsolidationConflicting, i, batch.ConflictReason)
+			default:
+				return state, fmt.Errorf("unknown status %s of consolidation batch %d", batch.Status, i)
+			}
+			if err != nil {
+				return state, fmt.Errorf("unable to consolidate batch %d: %w", i, err)
+			}
+			if err := c.opts.store.StoreConsolidation(state); err != nil {
+				return state, err
+			}
+		}
+	}
+
+	return state, nil
+}
+
+// scans the unspent outputs of the source address and plans the batches.
+func (c *Consolidator) scan(ctx context.Context) (*ConsolidationState, error) {
+	_, unspentOutputs, err := c.nodeAPI.OutputsByEd25519Address(ctx, c.source, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query outputs of source address: %w", err)
+	}
+
+	sameAddr := c.source.String() == c.target.String()
+	batches, err := planConsolidationBatches(unspentOutputs, c.opts.maxInputs, sameAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConsolidationState{Source: c.source.String(), Target: c.target.String(), Batches: batches}, nil
+}
+
+type consolidationOutput struct {
+	id      OutputIDHex
+	deposit uint64
+}
+
+// plans the batches sweeping the given outputs as described by Consolidator.
+func planConsolidationBatches(outputs map[*UTXOInput]Output, maxInputs int, sameAddr bool) ([]*ConsolidationBatch, error) {
+	// nothing to consolidate
+	if sameAddr && len(outputs) < 2 {
+		return nil, nil
+	}
+
+	var dust, singles, allowances []consolidationOutput
+	for utxoInput, output := range outputs {
+		deposit, err := output.Deposit()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get deposit of output %s: %w", utxoInput.ID().ToHex(), err)
+		}
+		utxoID := utxoInput.ID()
+		out := consolidationOutput{id: OutputIDHex(utxoID.ToHex()), deposit: deposit}
+		switch {
+		case output.Type() == OutputSigLockedDustAllowanceOutput:
+			allowances = append(allowances, out)
+		case deposit < OutputSigLockedDustAllowanceOutputMinDeposit:
+			dust = append(dust, out)
+		default:
+			singles = append(singles, out)
+		}
+	}
+
+	// largest first, so that dust needs as few anchors as possible
+	for _, outs := range [][]consolidationOutput{dust, singles, allowances} {
+		outs := outs
+		sort.Slice(outs, func(i, j int) bool {
+			if outs[i].deposit != outs[j].deposit {
+				return outs[i].deposit > outs[j].deposit
+			}
+			return bytes.Compare([]byte(outs[i].id), []byte(outs[j].id)) < 0
+		})
+	}
+
+	var batches []*ConsolidationBatch
+	addBatch := func(outs []consolidationOutput) *ConsolidationBatch {
+		batch := &ConsolidationBatch{Status: ConsolidationBatchPending}
+		for _, out := range outs {
+			batch.Inputs = append(batch.Inputs, out.id)
+			batch.Amount += out.deposit
+		}
+		batches = append(batches, batch)
+		return batch
+	}
+
+	for len(dust) > 0 {
+		n := len(dust)
+		if n > maxInputs {
+			n = maxInputs
+		}
+		batch := append([]consolidationOutput{}, dust[:n]...)
+
+		if sumConsolidationOutputs(batch) < OutputSigLockedDustAllowanceOutputMinDeposit {
+			// the last dust output makes room for an anchor if the batch is full
+			if n == maxInputs && (len(singles) > 0 || len(allowances) > 0) {
+				n--
+				batch = batch[:n]
+			}
+			switch {
+			case len(singles) > 0:
+				batch = append(batch, singles[0])
+				singles = singles[1:]
+			case n == len(dust) && len(allowances) > 0:
+				// the allowance can only be swept together with the last dust outputs
+				batch = append(batch, allowances[0])
+				allowances = allowances[1:]
+			case !sameAddr:
+				return nil, fmt.Errorf("%w: %d dust outputs worth %d", ErrConsolidationBelowMinDeposit, len(dust), sumConsolidationOutputs(dust))
+			}
+		}
+
+		addBatch(batch)
+		dust = dust[n:]
+	}
+
+	rest := append(singles, allowances...)
+	for len(rest) > 0 {
+		n := len(rest)
+		if n > maxInputs {
+			n = maxInputs
+		}
+		addBatch(rest[:n])
+		rest = rest[n:]
+	}
+
+	// a lone output on the same address would just be moved
+	if sameAddr && len(batches) > 0 && len(batches[len(batches)-1].Inputs) == 1 {
+		batches = batches[:len(batches)-1]
+	}
+
+	return batches, nil
+}
+
+// returns the deposit sum of the given outputs.
+func sumConsolidationOutputs(outs []consolidationOutput) uint64 {
+	var sum uint64
+	for _, out := range outs {
+		sum += out.deposit
+	}
+	return sum
+}
+
+// builds the transaction of the given batch.
+func (c *Consolidator) buildTransaction(batch *ConsolidationBatch) (*Transaction, error) {
+	txBuilder := NewTransactionBuilder()
+	for _, id := range batch.Inputs {
+		utxoInput, err := id.AsUTXOInput()
+		if err != nil {
+			return nil, err
+		}
+		txBuilder.AddInput(&ToBeSignedUTXOInput{Address: c.source, Input: utxoInput})
+	}
+	txBuilder.AddOutput(&SigLockedSingleOutput{Address: c.target, Amount: batch.Amount})
+	return txBuilder.Build(c.signer)
+}
+
+// builds, signs and submits the transaction of the given batch within a new message.
+// As signatures are deterministic, a resubmitted batch always has the same transaction ID.
+func (c *Consolidator) submit(ctx context.Context, state *ConsolidationState, batch *ConsolidationBatch) error {
+	// the batch might have been confirmed while the message got lost, i.e. pruned
+	if batch.TransactionID != "" {
+		confirmed, err := c.outputExists(ctx, batch.TransactionID)
+		if err != nil {
+			return err
+		}
+		if confirmed {
+			batch.Status = ConsolidationBatchConfirmed
+			return nil
+		}
+	}
+
+	tx, err := c.buildTransaction(batch)
+	if err != nil {
+		return fmt.Errorf("unable to build transaction: %w", err)
+	}
+	txID, err := tx.ID()
+	if err != nil {
+		return err
+	}
+
+	if c.info == nil {
+		if c.info, err = c.nodeAPI.Info(ctx); err != nil {
+			return fmt.Errorf("unable to query node info: %w", err)
+		}
+	}
+
+	msgBuilder := NewMessageBuilder().NetworkIDFromString(c.info.NetworkID).Payload(tx).Tips(ctx, c.nodeAPI)
+	if c.info.MinPowScore > 0 {
+		msgBuilder.ProofOfWork(ctx, c.info.MinPowScore, c.opts.powWorkers...)
+	}
+	msg, err := msgBuilder.Build()
+	if err != nil {
+		return fmt.Errorf("unable to build message: %w", err)
+	}
+	msgID, err := msg.ID()
+	if err != nil {
+		return err
+	}
+
+	// persist before submitting, so that a crash in between is detected while awaiting the confirmation
+	batch.TransactionID = hex.EncodeToString(txID[:])
+	batch.MessageID = MessageIDToHexString(*msgID)
+	batch.Status = ConsolidationBatchSubmitted
+	if err := c.opts.store.StoreConsolidation(state); err != nil {
+		return err
+	}
+
+	if _, err := c.nodeAPI.SubmitMessage(ctx, msg); err != nil {
+		return fmt.Errorf("unable to submit message: %w", err)
+	}
+	return nil
+}
+
+// returns whether the first output of the given transaction exists.
+func (c *Consolidator) outputExists(ctx context.Context, txIDHex string) (bool, error) {
+	txIDBytes, err := hex.DecodeString(txIDHex)
+	if err != nil {
+		return false, fmt.Errorf("invalid transaction ID %s: %w", txIDHex, err)
+	}
+	utxoInput := &UTXOInput{}
+	copy(utxoInput.TransactionID[:], txIDBytes)
+	if _, err := c.nodeAPI.OutputByID(ctx, utxoInput.ID()); err != nil {
+		if errors.Is(err, ErrHTTPNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// waits until the message of the given batch is referenced by a milestone.
+// A message unknown to the node or one which should be reattached sets the batch back to pending.
+func (c *Consolidator) await(ctx context.Context, batch *ConsolidationBatch) error {
+	msgID, err := MessageIDFromHexString(batch.MessageID)
+	if err != nil {
+		return err
+	}
+
+	for {
+		metadata, err := c.nodeAPI.MessageMetadataByMessageID(ctx, msgID)
+		switch {
+		case errors.Is(err, ErrHTTPNotFound):
+			batch.Status = ConsolidationBatchPending
+			return nil
+		case err != nil:
+			return fmt.Errorf("unable to query message metadata: %w", err)
+		}
+
+		if metadata.ReferencedByMilestoneIndex != nil {
+			batch.ReferencedByMilestoneIndex = *metadata.ReferencedByMilestoneIndex
+			if metadata.LedgerInclusionState != nil && LedgerInclusionState(*metadata.LedgerInclusionState) == LedgerInclusionStateIncluded {
+				batch.Status = ConsolidationBatchConfirmed
+				return nil
+			}
+
+			// an earlier attachment of the same transaction might have been included
+			confirmed, err := c.outputExists(ctx, batch.TransactionID)
+			if err != nil {
+				return err
+			}
+			if confirmed {
+				batch.Status = ConsolidationBatchConfirmed
+				return nil
+			}
+			batch.Status = ConsolidationBatchConflicting
+			batch.ConflictReason = ConflictReason(metadata.ConflictReason)
+			return nil
+		}
+
+		if metadata.ShouldReattach != nil && *metadata.ShouldReattach {
+			batch.Status = ConsolidationBatchPending
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.opts.pollInterval):
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package iotago_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+// returns a FakeNode and a client talking to it.
+func newFakeNode(t *testing.T) (*tpkg.FakeNode, *iotago.NodeHTTPAPIClient) {
+	node := tpkg.NewFakeNode()
+	t.Cleanup(node.Close)
+	return node, node.Client()
+}
+
+func TestConsolidator_Consolidate(t *testing.T) {
+	node, nodeAPI := newFakeNode(t)
+
+	prvKey := tpkg.RandEd25519PrivateKey()
+	source := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+	signer := iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &source, Keys: prvKey})
+	target, _ := tpkg.RandEd25519Address()
+
+	node.CreateOutput(1, &iotago.SigLockedDustAllowanceOutput{Address: &source, Amount: 1_000_000})
+	for i := 0; i < 5; i++ {
+		node.CreateOutput(1, &iotago.SigLockedSingleOutput{Address: &source, Amount: 100})
+	}
+	for i := 0; i < 3; i++ {
+		node.CreateOutput(1, &iotago.SigLockedSingleOutput{Address: &source, Amount: 1_000_000})
+	}
+
+	consolidator := iotago.NewConsolidator(nodeAPI, &source, target, signer,
+		iotago.WithConsolidatorMaxInputs(3),
+		iotago.WithConsolidatorPollInterval(time.Millisecond),
+	)
+	state, err := consolidator.Consolidate(context.Background())
+	require.NoError(t, err)
+	assert.True(t, state.Done())
+
+	// dust is swept together with a larger output and the allowance comes last
+	require.Len(t, state.Batches, 4)
+	for i, inputs := range []int{3, 3, 2, 1} {
+		assert.Len(t, state.Batches[i].Inputs, inputs)
+		assert.EqualValues(t, 2, state.Batches[i].ReferencedByMilestoneIndex)
+	}
+	assert.EqualValues(t, 1_000_000, state.Batches[3].Amount)
+
+	assert.Empty(t, node.UnspentOutputs(&source))
+	var sum uint64
+	for _, output := range node.UnspentOutputs(target) {
+		deposit, _ := output.Deposit()
+		sum += deposit
+	}
+	assert.EqualValues(t, 4_000_500, sum)
+
+	// a second run starts a new consolidation with nothing left to sweep
+	state, err = consolidator.Consolidate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, state.Batches)
+}
+
+func TestConsolidator_Resume(t *testing.T) {
+	node, nodeAPI := newFakeNode(t)
+
+	prvKey := tpkg.RandEd25519PrivateKey()
+	source := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+	signer := iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &source, Keys: prvKey})
+	for i := 0; i < 4; i++ {
+		node.CreateOutput(1, &iotago.SigLockedSingleOutput{Address: &source, Amount: 1_000_000})
+	}
+
+	storePath := filepath.Join(t.TempDir(), "consolidation.json")
+	newConsolidator := func() *iotago.Consolidator {
+		return iotago.NewConsolidator(nodeAPI, &source, &source, signer,
+			iotago.WithConsolidatorStore(iotago.NewFileConsolidationStore(storePath)),
+			iotago.WithConsolidatorPollInterval(time.Millisecond),
+		)
+	}
+
+	// the message is lost after the batch got persisted as submitted
+	node.FailSubmits(1)
+	_, err := newConsolidator().Consolidate(context.Background())
+	assert.True(t, errors.Is(err, iotago.ErrHTTPInternalServerError))
+
+	stored, err := iotago.NewFileConsolidationStore(storePath).LoadConsolidation()
+	require.NoError(t, err)
+	require.Len(t, stored.Batches, 1)
+	assert.Equal(t, iotago.ConsolidationBatchSubmitted, stored.Batches[0].Status)
+	txID := stored.Batches[0].TransactionID
+
+	state, err := newConsolidator().Consolidate(context.Background())
+	require.NoError(t, err)
+	assert.True(t, state.Done())
+	assert.Equal(t, txID, state.Batches[0].TransactionID, "resubmitted batch must have the same transaction")
+	assert.Equal(t, 2, node.Submits())
+	assert.Len(t, node.UnspentOutputs(&source), 1)
+
+	// a consolidation of other addresses can't resume the stored state
+	stored.Batches[0].Status = iotago.ConsolidationBatchPending
+	require.NoError(t, iotago.NewFileConsolidationStore(storePath).StoreConsolidation(stored))
+	otherTarget, _ := tpkg.RandEd25519Address()
+	_, err = iotago.NewConsolidator(nodeAPI, &source, otherTarget, signer,
+		iotago.WithConsolidatorStore(iotago.NewFileConsolidationStore(storePath)),
+	).Consolidate(context.Background())
+	assert.True(t, errors.Is(err, iotago.ErrConsolidationStateMismatch))
+
+	// a batch confirmed through a pruned message is detected by its output
+	state, err = newConsolidator().Consolidate(context.Background())
+	require.NoError(t, err)
+	assert.True(t, state.Done())
+	assert.Equal(t, 2, node.Submits())
+}
+
+func TestConsolidator_Errors(t *testing.T) {
+	node, nodeAPI := newFakeNode(t)
+
+	prvKey := tpkg.RandEd25519PrivateKey()
+	source := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+	signer := iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &source, Keys: prvKey})
+	target, _ := tpkg.RandEd25519Address()
+	node.CreateOutput(1, &iotago.SigLockedSingleOutput{Address: &source, Amount: 100})
+	node.CreateOutput(1, &iotago.SigLockedSingleOutput{Address: &source, Amount: 100})
+
+	_, err := iotago.NewConsolidator(nodeAPI, &source, target, signer).Consolidate(context.Background())
+	assert.True(t, errors.Is(err, iotago.ErrConsolidationBelowMinDeposit))
+
+	// the node rejects the dust output on the source address as it has no allowance
+	node.CreateOutput(1, &iotago.SigLockedSingleOutput{Address: &source, Amount: 100})
+	consolidator := iotago.NewConsolidator(nodeAPI, &source, &source, signer, iotago.WithConsolidatorPollInterval(time.Millisecond))
+	state, err := consolidator.Consolidate(context.Background())
+	assert.True(t, errors.Is(err, iotago.ErrConsolidationConflicting))
+	assert.Equal(t, iotago.ConflictInvalidDustAllowance, state.Batches[0].ConflictReason)
+}
@@ -0,0 +1,245 @@
+// Package datachannel provides data channels on top of Indexation payloads.
+//
+// Content is wrapped in envelopes carrying its content type, a hash of the complete content and a sequence number.
+// Content exceeding the size of a single message is chunked across multiple messages, every chunk referencing the
+// message of its predecessor as a parent. The content can optionally be compressed and signed by its author, in which
+// case every envelope is signed so that other senders on the index can not interfere with the content:
+//
+//	sender, err := datachannel.NewSender(nodeHTTPAPIClient, []byte("my channel"), datachannel.WithSigner(prvKey))
+//	msgIDs, err := sender.Send(ctx, "application/json", data)
+//
+//	contents, err := datachannel.FromIndex(ctx, nodeHTTPAPIClient, []byte("my channel"))
+//
+// A Reassembler puts the chunks back together, for example from the messages of NodeEventAPIClient.MessagesWithIndex.
+package datachannel
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/hive.go/serializer"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+const (
+	// EnvelopeVersion is the version of the envelope format.
+	EnvelopeVersion byte = 1
+	// ContentTypeMaxLength is the max. length of a content type.
+	ContentTypeMaxLength = 255
+	// ContentHashLength is the length of the hash of the content.
+	ContentHashLength = blake2b.Size256
+
+	// the size of the envelope fields besides the content type and the chunk:
+	// version, flags, content type length, hash, sequence, total and chunk length
+	envelopeHeaderSize = 1 + 1 + 1 + ContentHashLength + serializer.UInt32ByteSize*3
+	// the size of the public key and signature of signed envelopes
+	envelopeSignatureSize = ed25519.PublicKeySize + ed25519.SignatureSize
+)
+
+// EnvelopeFlags are the flags of an Envelope.
+type EnvelopeFlags byte
+
+const (
+	// EnvelopeFlagCompressed denotes that the chunks of the content are DEFLATE compressed.
+	EnvelopeFlagCompressed EnvelopeFlags = 1 << iota
+	// EnvelopeFlagSigned denotes that the content is signed by its author.
+	EnvelopeFlagSigned
+)
+
+var (
+	// ErrInvalidEnvelope gets returned when data is not a valid Envelope.
+	ErrInvalidEnvelope = errors.New("invalid envelope")
+	// ErrContentIntegrity gets returned when reassembled content does not match its hash.
+	ErrContentIntegrity = errors.New("content does not match its hash")
+	// ErrInvalidContentSignature gets returned when the signature of signed content is invalid.
+	ErrInvalidContentSignature = errors.New("invalid content signature")
+	// ErrUntrustedAuthor gets returned when content is not signed by a trusted author.
+	ErrUntrustedAuthor = errors.New("content not signed by a trusted author")
+	// ErrContentTooLarge gets returned when content exceeds the max. content size.
+	ErrContentTooLarge = errors.New("content too large")
+	// ErrTooManyMessages gets returned when the node does not return all messages of an index
+	// because they exceed its max. results.
+	ErrTooManyMessages = errors.New("too many messages on index")
+)
+
+// Envelope wraps a chunk of content.
+type Envelope struct {
+	// The flags of the content.
+	Flags EnvelopeFlags
+	// The content type of the content, for example a MIME type.
+	ContentType string
+	// The BLAKE2b-256 hash of the complete uncompressed content.
+	Hash [ContentHashLength]byte
+	// The index of the chunk.
+	Sequence uint32
+	// The amount of chunks of the content.
+	Total uint32
+	// The chunk of the (compressed) content.
+	Chunk []byte
+	// The public key of the author, only present in signed envelopes.
+	PublicKey ed25519.PublicKey
+	// The signature of the author over the SigningMessage, only present in signed envelopes.
+	Signature []byte
+}
+
+// signed reports whether the Envelope carries the public key and signature of the author.
+func (e *Envelope) signed() bool {
+	return e.Flags&EnvelopeFlagSigned != 0
+}
+
+// SigningMessage returns the message an author signs: the serialized Envelope without the signature,
+// which binds all fields of the Envelope, including the chunk and the public key, to the signature.
+func (e *Envelope) SigningMessage() ([]byte, error) {
+	return e.serialize(false)
+}
+
+// Sign sets the signed flag and the public key of the Envelope and signs it with the given key.
+func (e *Envelope) Sign(prvKey ed25519.PrivateKey) error {
+	e.Flags |= EnvelopeFlagSigned
+	e.PublicKey = prvKey.Public().(ed25519.PublicKey)
+	msg, err := e.SigningMessage()
+	if err != nil {
+		return err
+	}
+	e.Signature = ed25519.Sign(prvKey, msg)
+	return nil
+}
+
+// Serialize serializes the Envelope.
+func (e *Envelope) Serialize() ([]byte, error) {
+	return e.serialize(true)
+}
+
+// serializes the Envelope with or without its signature.
+func (e *Envelope) serialize(withSignature bool) ([]byte, error) {
+	if len(e.ContentType) > ContentTypeMaxLength {
+		return nil, fmt.Errorf("%w: content type exceeds %d bytes", ErrInvalidEnvelope, ContentTypeMaxLength)
+	}
+	if e.Sequence >= e.Total {
+		return nil, fmt.Errorf("%w: sequence %d of %d chunks", ErrInvalidEnvelope, e.Sequence, e.Total)
+	}
+	if e.signed() && len(e.PublicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: signed envelope without public key", ErrInvalidEnvelope)
+	}
+	if withSignature && e.signed() && len(e.Signature) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("%w: signed envelope without signature", ErrInvalidEnvelope)
+	}
+
+	var b bytes.Buffer
+	b.WriteByte(EnvelopeVersion)
+	b.WriteByte(byte(e.Flags))
+	b.WriteByte(byte(len(e.ContentType)))
+	b.WriteString(e.ContentType)
+	b.Write(e.Hash[:])
+	for _, num := range []uint32{e.Sequence, e.Total, uint32(len(e.Chunk))} {
+		if err := binary.Write(&b, binary.LittleEndian, num); err != nil {
+			return nil, err
+		}
+	}
+	b.Write(e.Chunk)
+	if e.signed() {
+		b.Write(e.PublicKey)
+		if withSignature {
+			b.Write(e.Signature)
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// Deserialize deserializes the given data into the Envelope.
+func (e *Envelope) Deserialize(data []byte) error {
+	r := bytes.NewReader(data)
+	read := func(target interface{}, what string) error {
+		var err error
+		switch t := target.(type) {
+		case []byte:
+			_, err = io.ReadFull(r, t)
+		default:
+			err = binary.Read(r, binary.LittleEndian, target)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: unable to read %s", ErrInvalidEnvelope, what)
+		}
+		return nil
+	}
+
+	var version, contentTypeLength byte
+	if err := read(&version, "version"); err != nil {
+		return err
+	}
+	if version != EnvelopeVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidEnvelope, version)
+	}
+	if err := read(&e.Flags, "flags"); err != nil {
+		return err
+	}
+	if err := read(&contentTypeLength, "content type length"); err != nil {
+		return err
+	}
+	contentType := make([]byte, contentTypeLength)
+	if err := read(contentType, "content type"); err != nil {
+		return err
+	}
+	e.ContentType = string(contentType)
+	if err := read(e.Hash[:], "hash"); err != nil {
+		return err
+	}
+
+	var chunkLength uint32
+	for _, field := range []struct {
+		target *uint32
+		what   string
+	}{{&e.Sequence, "sequence"}, {&e.Total, "total"}, {&chunkLength, "chunk length"}} {
+		if err := read(field.target, field.what); err != nil {
+			return err
+		}
+	}
+	if e.Sequence >= e.Total {
+		return fmt.Errorf("%w: sequence %d of %d chunks", ErrInvalidEnvelope, e.Sequence, e.Total)
+	}
+	if int(chunkLength) > r.Len() {
+		return fmt.Errorf("%w: chunk length %d exceeds the data", ErrInvalidEnvelope, chunkLength)
+	}
+	e.Chunk = make([]byte, chunkLength)
+	if err := read(e.Chunk, "chunk"); err != nil {
+		return err
+	}
+
+	if e.signed() {
+		e.PublicKey = make(ed25519.PublicKey, ed25519.PublicKeySize)
+		e.Signature = make([]byte, ed25519.SignatureSize)
+		if err := read([]byte(e.PublicKey), "public key"); err != nil {
+			return err
+		}
+		if err := read(e.Signature, "signature"); err != nil {
+			return err
+		}
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidEnvelope, r.Len())
+	}
+	return nil
+}
+
+// MaxChunkSize returns the max. chunk size of envelopes with the given content type which fit into
+// a message with an Indexation with the given index.
+func MaxChunkSize(index []byte, contentType string, signed bool) int {
+	const messageOverhead = serializer.UInt64ByteSize + // network ID
+		serializer.OneByte + iotago.MaxParentsInAMessage*iotago.MessageIDLength + // parents
+		serializer.UInt32ByteSize + // payload length
+		serializer.UInt64ByteSize // nonce
+	const indexationOverhead = serializer.TypeDenotationByteSize + serializer.UInt16ByteSize + serializer.UInt32ByteSize
+
+	size := iotago.MessageBinSerializedMaxSize - messageOverhead - indexationOverhead - len(index) - envelopeHeaderSize - len(contentType)
+	if signed {
+		size -= envelopeSignatureSize
+	}
+	return size
+}
@@ -0,0 +1,116 @@
+package datachannel_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/datachannel"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+func TestEnvelope_SerializeDeserialize(t *testing.T) {
+	prvKey := tpkg.RandEd25519PrivateKey()
+	tests := []struct {
+		name     string
+		envelope *datachannel.Envelope
+	}{
+		{
+			name:     "plain",
+			envelope: &datachannel.Envelope{ContentType: "text/plain", Hash: tpkg.Rand32ByteArray(), Sequence: 1, Total: 3, Chunk: tpkg.RandBytes(100)},
+		},
+		{
+			name: "signed",
+			envelope: &datachannel.Envelope{
+				Flags:       datachannel.EnvelopeFlagSigned | datachannel.EnvelopeFlagCompressed,
+				ContentType: "application/json",
+				Hash:        tpkg.Rand32ByteArray(),
+				Total:       1,
+				Chunk:       tpkg.RandBytes(10),
+				PublicKey:   prvKey.Public().(ed25519.PublicKey),
+				Signature:   tpkg.RandBytes(64),
+			},
+		},
+		{
+			name: "signed chunk",
+			envelope: func() *datachannel.Envelope {
+				envelope := &datachannel.Envelope{ContentType: "text/plain", Hash: tpkg.Rand32ByteArray(), Sequence: 2, Total: 3, Chunk: tpkg.RandBytes(100)}
+				require.NoError(t, envelope.Sign(prvKey))
+				return envelope
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.envelope.Serialize()
+			require.NoError(t, err)
+			envelope := &datachannel.Envelope{}
+			require.NoError(t, envelope.Deserialize(data))
+			assert.Equal(t, tt.envelope, envelope)
+
+			assert.True(t, errors.Is((&datachannel.Envelope{}).Deserialize(data[:len(data)-1]), datachannel.ErrInvalidEnvelope))
+			assert.True(t, errors.Is((&datachannel.Envelope{}).Deserialize(append(data, 0)), datachannel.ErrInvalidEnvelope))
+		})
+	}
+
+	_, err := (&datachannel.Envelope{Sequence: 1, Total: 1}).Serialize()
+	assert.True(t, errors.Is(err, datachannel.ErrInvalidEnvelope))
+	_, err = (&datachannel.Envelope{ContentType: strings.Repeat("a", 256), Total: 1}).Serialize()
+	assert.True(t, errors.Is(err, datachannel.ErrInvalidEnvelope))
+	_, err = (&datachannel.Envelope{Flags: datachannel.EnvelopeFlagSigned, Total: 1}).Serialize()
+	assert.True(t, errors.Is(err, datachannel.ErrInvalidEnvelope))
+	assert.True(t, errors.Is((&datachannel.Envelope{}).Deserialize([]byte{2}), datachannel.ErrInvalidEnvelope))
+}
+
+func TestEnvelope_Sign(t *testing.T) {
+	prvKey := tpkg.RandEd25519PrivateKey()
+	envelope := &datachannel.Envelope{ContentType: "text/plain", Hash: tpkg.Rand32ByteArray(), Sequence: 1, Total: 2, Chunk: tpkg.RandBytes(10)}
+	require.NoError(t, envelope.Sign(prvKey))
+	assert.Equal(t, datachannel.EnvelopeFlagSigned, envelope.Flags)
+	assert.Equal(t, prvKey.Public(), envelope.PublicKey)
+
+	// the signature covers every field of the envelope
+	msg, err := envelope.SigningMessage()
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(envelope.PublicKey, msg, envelope.Signature))
+	for _, modify := range []func(e *datachannel.Envelope){
+		func(e *datachannel.Envelope) { e.Chunk = tpkg.RandBytes(10) },
+		func(e *datachannel.Envelope) { e.Total = 3 },
+		func(e *datachannel.Envelope) { e.Sequence = 0 },
+		func(e *datachannel.Envelope) { e.PublicKey = tpkg.RandEd25519PrivateKey().Public().(ed25519.PublicKey) },
+	} {
+		modified := *envelope
+		modify(&modified)
+		msg, err := modified.SigningMessage()
+		require.NoError(t, err)
+		assert.False(t, ed25519.Verify(modified.PublicKey, msg, modified.Signature))
+	}
+}
+
+func TestMaxChunkSize(t *testing.T) {
+	index := []byte(strings.Repeat("i", iotago.IndexationIndexMaxLength))
+	for _, signed := range []bool{false, true} {
+		envelopes, err := datachannel.Encode(index, "application/octet-stream", tpkg.RandBytes(100_000), datachannel.WithSigner(func() ed25519.PrivateKey {
+			if signed {
+				return tpkg.RandEd25519PrivateKey()
+			}
+			return nil
+		}()))
+		require.NoError(t, err)
+		require.Len(t, envelopes, 4)
+
+		// a message holding a full chunk and the max. amount of parents is exactly as large as allowed
+		data, err := envelopes[0].Serialize()
+		require.NoError(t, err)
+		msg := &iotago.Message{Parents: tpkg.SortedRand32BytArray(iotago.MaxParentsInAMessage), Payload: &iotago.Indexation{Index: index, Data: data}}
+		msgData, err := msg.Serialize(serializer.DeSeriModePerformValidation)
+		require.NoError(t, err)
+		assert.Len(t, msgData, iotago.MessageBinSerializedMaxSize)
+	}
+}
@@ -0,0 +1,320 @@
+package datachannel
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+// Content is content reassembled from its envelopes.
+type Content struct {
+	// The content type of the content.
+	ContentType string
+	// The content.
+	Data []byte
+	// The BLAKE2b-256 hash of the content.
+	Hash [ContentHashLength]byte
+	// The public key of the author, nil if the content is not signed.
+	Author ed25519.PublicKey
+	// The IDs of the messages holding the chunks, in chunk order.
+	MessageIDs iotago.MessageIDs
+}
+
+// the default options applied to the Reassembler.
+var defaultReassemblerOptions = []ReassemblerOption{
+	WithMaxContentSize(16 << 20),
+	WithMaxPendingContents(100),
+}
+
+// ReassemblerOptions define options for the Reassembler.
+type ReassemblerOptions struct {
+	// The max. size of reassembled content.
+	maxContentSize int
+	// The max. amount of incomplete contents held.
+	maxPendingContents int
+	// The public keys of the trusted authors, empty if unsigned content is accepted.
+	trustedAuthors map[string]struct{}
+}
+
+// applies the given ReassemblerOption.
+func (ro *ReassemblerOptions) apply(opts ...ReassemblerOption) {
+	for _, opt := range opts {
+		opt(ro)
+	}
+}
+
+// WithMaxContentSize sets the max. size of content, both compressed and decompressed.
+func WithMaxContentSize(size int) ReassemblerOption {
+	return func(opts *ReassemblerOptions) {
+		opts.maxContentSize = size
+	}
+}
+
+// WithMaxPendingContents sets the max. amount of incomplete contents held by the Reassembler.
+// The oldest incomplete content is dropped when a chunk of new content exceeds it.
+func WithMaxPendingContents(count int) ReassemblerOption {
+	return func(opts *ReassemblerOptions) {
+		opts.maxPendingContents = count
+	}
+}
+
+// WithTrustedAuthors defines that only content signed by one of the given authors is accepted.
+func WithTrustedAuthors(pubKeys ...ed25519.PublicKey) ReassemblerOption {
+	return func(opts *ReassemblerOptions) {
+		opts.trustedAuthors = make(map[string]struct{}, len(pubKeys))
+		for _, pubKey := range pubKeys {
+			opts.trustedAuthors[string(pubKey)] = struct{}{}
+		}
+	}
+}
+
+// ReassemblerOption is a function setting a Reassembler option.
+type ReassemblerOption func(opts *ReassemblerOptions)
+
+// NewReassembler creates a new Reassembler.
+func NewReassembler(opts ...ReassemblerOption) *Reassembler {
+	options := &ReassemblerOptions{}
+	options.apply(defaultReassemblerOptions...)
+	options.apply(opts...)
+	return &Reassembler{opts: options, pending: make(map[pendingKey]*pendingContent)}
+}
+
+// identifies the envelopes of the same content by the same author.
+type pendingKey struct {
+	hash        [ContentHashLength]byte
+	contentType string
+	flags       EnvelopeFlags
+	total       uint32
+	// the public key of the author, empty for unsigned content
+	author string
+}
+
+type pendingContent struct {
+	chunks map[uint32][]byte
+	msgIDs map[uint32]iotago.MessageID
+	size   int
+	// the order in which the pending contents were created
+	order uint64
+}
+
+// Reassembler reassembles content from the envelopes of the messages added to it, in any order.
+// Signed envelopes are verified when they are added and only reassembled with envelopes of the same author,
+// so that other senders on the index can not interfere with signed content. Unsigned content however can be
+// disrupted by anyone sending envelopes with the same hash to the index.
+type Reassembler struct {
+	opts      *ReassemblerOptions
+	mu        sync.Mutex
+	pending   map[pendingKey]*pendingContent
+	lastOrder uint64
+}
+
+// AddMessage adds the envelope held by the Indexation of the given message, which might also be embedded in a
+// transaction. It returns the content once all its chunks were added, nil otherwise.
+func (r *Reassembler) AddMessage(msg *iotago.Message) (*Content, error) {
+	var indexation *iotago.Indexation
+	switch payload := msg.Payload.(type) {
+	case *iotago.Indexation:
+		indexation = payload
+	case *iotago.Transaction:
+		if essence, ok := payload.Essence.(*iotago.TransactionEssence); ok {
+			indexation, _ = essence.Payload.(*iotago.Indexation)
+		}
+	}
+	if indexation == nil {
+		return nil, fmt.Errorf("%w: message holds no indexation", ErrInvalidEnvelope)
+	}
+
+	msgID, err := msg.ID()
+	if err != nil {
+		return nil, err
+	}
+	envelope := &Envelope{}
+	if err := envelope.Deserialize(indexation.Data); err != nil {
+		return nil, err
+	}
+	return r.Add(*msgID, envelope)
+}
+
+// Add adds the given envelope held by the message with the given ID.
+// It returns the content once all its chunks were added, nil otherwise.
+func (r *Reassembler) Add(msgID iotago.MessageID, envelope *Envelope) (*Content, error) {
+	if err := r.verify(envelope); err != nil {
+		return nil, err
+	}
+	key := pendingKey{hash: envelope.Hash, contentType: envelope.ContentType, flags: envelope.Flags, total: envelope.Total}
+	if envelope.signed() {
+		key.author = string(envelope.PublicKey)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pending, has := r.pending[key]
+	if !has {
+		if len(r.pending) >= r.opts.maxPendingContents {
+			r.dropOldest()
+		}
+		r.lastOrder++
+		pending = &pendingContent{chunks: make(map[uint32][]byte), msgIDs: make(map[uint32]iotago.MessageID), order: r.lastOrder}
+		r.pending[key] = pending
+	}
+	if _, has := pending.chunks[envelope.Sequence]; has {
+		return nil, nil
+	}
+
+	pending.size += len(envelope.Chunk)
+	if pending.size > r.opts.maxContentSize {
+		delete(r.pending, key)
+		return nil, fmt.Errorf("%w: chunks exceed %d bytes", ErrContentTooLarge, r.opts.maxContentSize)
+	}
+	pending.chunks[envelope.Sequence] = envelope.Chunk
+	pending.msgIDs[envelope.Sequence] = msgID
+
+	if uint32(len(pending.chunks)) < envelope.Total {
+		return nil, nil
+	}
+	delete(r.pending, key)
+	return r.assemble(key, pending)
+}
+
+// verifies the signature of the given envelope and whether its author is trusted.
+func (r *Reassembler) verify(envelope *Envelope) error {
+	if envelope.signed() {
+		msg, err := envelope.SigningMessage()
+		if err != nil {
+			return err
+		}
+		if !ed25519.Verify(envelope.PublicKey, msg, envelope.Signature) {
+			return ErrInvalidContentSignature
+		}
+	}
+	if r.opts.trustedAuthors != nil {
+		if _, trusted := r.opts.trustedAuthors[string(envelope.PublicKey)]; !envelope.signed() || !trusted {
+			return ErrUntrustedAuthor
+		}
+	}
+	return nil
+}
+
+// drops the oldest pending content.
+func (r *Reassembler) dropOldest() {
+	var oldestKey pendingKey
+	var oldest *pendingContent
+	for key, pending := range r.pending {
+		if oldest == nil || pending.order < oldest.order {
+			oldestKey, oldest = key, pending
+		}
+	}
+	delete(r.pending, oldestKey)
+}
+
+// concatenates, decompresses and verifies the chunks of complete content.
+func (r *Reassembler) assemble(key pendingKey, pending *pendingContent) (*Content, error) {
+	content := &Content{ContentType: key.contentType, Hash: key.hash, MessageIDs: make(iotago.MessageIDs, key.total)}
+	var data bytes.Buffer
+	for i := uint32(0); i < key.total; i++ {
+		data.Write(pending.chunks[i])
+		content.MessageIDs[i] = pending.msgIDs[i]
+	}
+	content.Data = data.Bytes()
+
+	if key.flags&EnvelopeFlagCompressed != 0 {
+		decompressed, err := io.ReadAll(io.LimitReader(flate.NewReader(&data), int64(r.opts.maxContentSize)+1))
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to decompress content: %s", ErrContentIntegrity, err)
+		}
+		if len(decompressed) > r.opts.maxContentSize {
+			return nil, fmt.Errorf("%w: decompressed content exceeds %d bytes", ErrContentTooLarge, r.opts.maxContentSize)
+		}
+		content.Data = decompressed
+	}
+
+	if blake2b.Sum256(content.Data) != key.hash {
+		return nil, ErrContentIntegrity
+	}
+
+	if key.author != "" {
+		content.Author = ed25519.PublicKey(key.author)
+	}
+	return content, nil
+}
+
+// Run adds the messages received from the given channel, such as NodeEventAPIClient.MessagesWithIndex(),
+// and passes reassembled content to the given function until the context is done or the channel is closed.
+// Messages which hold no valid envelope and content which fails verification are skipped, as anyone can
+// send messages to an index.
+func (r *Reassembler) Run(ctx context.Context, msgs <-chan *iotago.Message, f func(content *Content) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-msgs:
+			if !ok {
+				return nil
+			}
+			content, err := r.AddMessage(msg)
+			if err != nil || content == nil {
+				if err != nil && !skippable(err) {
+					return err
+				}
+				continue
+			}
+			if err := f(content); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// reports whether the given error is caused by invalid or unverified data.
+func skippable(err error) bool {
+	for _, target := range []error{ErrInvalidEnvelope, ErrContentIntegrity, ErrInvalidContentSignature, ErrUntrustedAuthor, ErrContentTooLarge} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// FromIndex fetches the messages with the given index from the given node and returns the content reassembled
+// from them. Messages which hold no valid envelope and content which fails verification are skipped.
+// FromIndex fails if the node does not return all messages of the index because they exceed its max. results.
+func FromIndex(ctx context.Context, nodeHTTPAPIClient *iotago.NodeHTTPAPIClient, index []byte, opts ...ReassemblerOption) ([]*Content, error) {
+	res, err := nodeHTTPAPIClient.MessageIDsByIndex(ctx, index)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query messages by index: %w", err)
+	}
+	if res.Count >= res.MaxResults {
+		return nil, fmt.Errorf("%w: index holds at least %d messages", ErrTooManyMessages, res.MaxResults)
+	}
+
+	r := NewReassembler(opts...)
+	var contents []*Content
+	for _, msgIDHex := range res.MessageIDs {
+		msgID, err := iotago.MessageIDFromHexString(msgIDHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid message ID %s: %w", msgIDHex, err)
+		}
+		msg, err := nodeHTTPAPIClient.MessageByMessageID(ctx, msgID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch message %s: %w", msgIDHex, err)
+		}
+		content, err := r.AddMessage(msg)
+		if err != nil && !skippable(err) {
+			return nil, err
+		}
+		if content != nil {
+			contents = append(contents, content)
+		}
+	}
+	return contents, nil
+}
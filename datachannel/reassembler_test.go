@@ -0,0 +1,190 @@
+package datachannel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/datachannel"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+func TestReassembler(t *testing.T) {
+	data := tpkg.RandBytes(1000)
+	author := tpkg.RandEd25519PrivateKey()
+	envelopes, err := datachannel.Encode([]byte("index"), "application/octet-stream", data, datachannel.WithChunkSize(300), datachannel.WithSigner(author))
+	require.NoError(t, err)
+	require.Len(t, envelopes, 4)
+	msgIDs := iotago.MessageIDs{tpkg.Rand32ByteArray(), tpkg.Rand32ByteArray(), tpkg.Rand32ByteArray(), tpkg.Rand32ByteArray()}
+
+	// chunks are reassembled in any order and duplicates are ignored
+	r := datachannel.NewReassembler(datachannel.WithTrustedAuthors(author.Public().(ed25519.PublicKey)))
+	for _, i := range []int{2, 0, 2, 3} {
+		content, err := r.Add(msgIDs[i], envelopes[i])
+		require.NoError(t, err)
+		assert.Nil(t, content)
+	}
+	content, err := r.Add(msgIDs[1], envelopes[1])
+	require.NoError(t, err)
+	require.NotNil(t, content)
+	assert.Equal(t, data, content.Data)
+	assert.Equal(t, msgIDs, content.MessageIDs)
+
+	tests := []struct {
+		name     string
+		modify   func(envelopes []*datachannel.Envelope)
+		opts     []datachannel.ReassemblerOption
+		expected error
+	}{
+		{
+			name: "tampered chunk",
+			modify: func(envelopes []*datachannel.Envelope) {
+				envelopes[3].Chunk = append([]byte{}, envelopes[3].Chunk...)
+				envelopes[3].Chunk[0]++
+			},
+			expected: datachannel.ErrInvalidContentSignature,
+		},
+		{
+			name: "invalid signature",
+			modify: func(envelopes []*datachannel.Envelope) {
+				envelopes[0].Signature = append([]byte{}, envelopes[0].Signature...)
+				envelopes[0].Signature[0]++
+			},
+			expected: datachannel.ErrInvalidContentSignature,
+		},
+		{
+			name:     "untrusted author",
+			modify:   func([]*datachannel.Envelope) {},
+			opts:     []datachannel.ReassemblerOption{datachannel.WithTrustedAuthors(tpkg.RandEd25519PrivateKey().Public().(ed25519.PublicKey))},
+			expected: datachannel.ErrUntrustedAuthor,
+		},
+		{
+			name:     "too large",
+			modify:   func([]*datachannel.Envelope) {},
+			opts:     []datachannel.ReassemblerOption{datachannel.WithMaxContentSize(500)},
+			expected: datachannel.ErrContentTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modified := make([]*datachannel.Envelope, len(envelopes))
+			for i, envelope := range envelopes {
+				e := *envelope
+				modified[i] = &e
+			}
+			tt.modify(modified)
+
+			r := datachannel.NewReassembler(tt.opts...)
+			var err error
+			for i, envelope := range modified {
+				if _, err = r.Add(msgIDs[i], envelope); err != nil {
+					break
+				}
+			}
+			assert.True(t, errors.Is(err, tt.expected), "expected %v, got %v", tt.expected, err)
+		})
+	}
+}
+
+func TestReassembler_Spoofing(t *testing.T) {
+	data := tpkg.RandBytes(100)
+	author, attacker := tpkg.RandEd25519PrivateKey(), tpkg.RandEd25519PrivateKey()
+	envelopes, err := datachannel.Encode([]byte("index"), "application/octet-stream", data, datachannel.WithChunkSize(60), datachannel.WithSigner(author))
+	require.NoError(t, err)
+	require.Len(t, envelopes, 2)
+
+	// the attacker re-signs the chunks of the content and tampers with the second one
+	spoofed := make([]*datachannel.Envelope, len(envelopes))
+	for i, envelope := range envelopes {
+		e := *envelope
+		if i == 1 {
+			e.Chunk = tpkg.RandBytes(len(e.Chunk))
+		}
+		require.NoError(t, e.Sign(attacker))
+		spoofed[i] = &e
+	}
+
+	// unsigned chunks and chunks signed with another key do not interfere with the content of the author
+	r := datachannel.NewReassembler()
+	unsigned := *envelopes[1]
+	unsigned.Flags, unsigned.PublicKey, unsigned.Signature = 0, nil, nil
+	content, err := r.Add(tpkg.Rand32ByteArray(), &unsigned)
+	require.NoError(t, err)
+	assert.Nil(t, content)
+	content, err = r.Add(tpkg.Rand32ByteArray(), spoofed[0])
+	require.NoError(t, err)
+	assert.Nil(t, content)
+	_, err = r.Add(tpkg.Rand32ByteArray(), spoofed[1])
+	assert.True(t, errors.Is(err, datachannel.ErrContentIntegrity))
+
+	content, err = r.Add(tpkg.Rand32ByteArray(), envelopes[0])
+	require.NoError(t, err)
+	assert.Nil(t, content)
+	content, err = r.Add(tpkg.Rand32ByteArray(), envelopes[1])
+	require.NoError(t, err)
+	require.NotNil(t, content)
+	assert.Equal(t, data, content.Data)
+	assert.Equal(t, author.Public(), content.Author)
+
+	// only signed envelopes of trusted authors are held
+	r = datachannel.NewReassembler(datachannel.WithTrustedAuthors(author.Public().(ed25519.PublicKey)))
+	_, err = r.Add(tpkg.Rand32ByteArray(), &unsigned)
+	assert.True(t, errors.Is(err, datachannel.ErrUntrustedAuthor))
+	_, err = r.Add(tpkg.Rand32ByteArray(), spoofed[0])
+	assert.True(t, errors.Is(err, datachannel.ErrUntrustedAuthor))
+}
+
+func TestReassembler_MaxPendingContents(t *testing.T) {
+	encode := func(data string) []*datachannel.Envelope {
+		envelopes, err := datachannel.Encode([]byte("index"), "text/plain", []byte(data), datachannel.WithChunkSize(4))
+		require.NoError(t, err)
+		require.Len(t, envelopes, 2)
+		return envelopes
+	}
+	first, second := encode("first"), encode("other")
+
+	// the first content is dropped when the chunk of the second one is added
+	r := datachannel.NewReassembler(datachannel.WithMaxPendingContents(1))
+	for _, envelope := range []*datachannel.Envelope{first[0], second[0]} {
+		content, err := r.Add(tpkg.Rand32ByteArray(), envelope)
+		require.NoError(t, err)
+		assert.Nil(t, content)
+	}
+	content, err := r.Add(tpkg.Rand32ByteArray(), second[1])
+	require.NoError(t, err)
+	require.NotNil(t, content)
+	assert.Equal(t, []byte("other"), content.Data)
+
+	content, err = r.Add(tpkg.Rand32ByteArray(), first[1])
+	require.NoError(t, err)
+	assert.Nil(t, content)
+}
+
+func TestReassembler_Run(t *testing.T) {
+	index := []byte("index")
+	envelopes, err := datachannel.Encode(index, "text/plain", []byte("hello world"), datachannel.WithChunkSize(4))
+	require.NoError(t, err)
+
+	msgs := make(chan *iotago.Message, len(envelopes)+1)
+	msgs <- &iotago.Message{Parents: tpkg.SortedRand32BytArray(1), Payload: &iotago.Indexation{Index: index, Data: []byte("garbage")}}
+	for _, envelope := range envelopes {
+		data, err := envelope.Serialize()
+		require.NoError(t, err)
+		msgs <- &iotago.Message{Parents: tpkg.SortedRand32BytArray(1), Payload: &iotago.Indexation{Index: index, Data: data}}
+	}
+	close(msgs)
+
+	var contents []*datachannel.Content
+	require.NoError(t, datachannel.NewReassembler().Run(context.Background(), msgs, func(content *datachannel.Content) error {
+		contents = append(contents, content)
+		return nil
+	}))
+	require.Len(t, contents, 1)
+	assert.Equal(t, []byte("hello world"), contents[0].Data)
+	assert.Nil(t, contents[0].Author)
+}
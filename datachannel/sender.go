@@ -0,0 +1,204 @@
+package datachannel
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+// the default options applied to the Sender.
+var defaultSenderOptions = []SenderOption{
+	WithCompression(false),
+}
+
+// SenderOptions define options for the Sender.
+type SenderOptions struct {
+	// Whether the content is compressed.
+	compress bool
+	// The key signing the content, nil if the content is not signed.
+	signer ed25519.PrivateKey
+	// The max. chunk size, zero to use the max. size fitting into a message.
+	chunkSize int
+	// The TipSelector selecting the parents of the messages, nil to use the tips of the node.
+	tipSelector iotago.TipSelector
+}
+
+// applies the given SenderOption.
+func (so *SenderOptions) apply(opts ...SenderOption) {
+	for _, opt := range opts {
+		opt(so)
+	}
+}
+
+// WithCompression defines whether content is DEFLATE compressed. Compression is skipped for content
+// which it does not shrink.
+func WithCompression(compress bool) SenderOption {
+	return func(opts *SenderOptions) {
+		opts.compress = compress
+	}
+}
+
+// WithSigner sets the key of the author signing the content.
+func WithSigner(prvKey ed25519.PrivateKey) SenderOption {
+	return func(opts *SenderOptions) {
+		opts.signer = prvKey
+	}
+}
+
+// WithChunkSize sets the max. chunk size, which is capped at the max. size fitting into a message.
+func WithChunkSize(size int) SenderOption {
+	return func(opts *SenderOptions) {
+		opts.chunkSize = size
+	}
+}
+
+// WithTipSelector sets the TipSelector selecting the parents of the messages.
+func WithTipSelector(selector iotago.TipSelector) SenderOption {
+	return func(opts *SenderOptions) {
+		opts.tipSelector = selector
+	}
+}
+
+// SenderOption is a function setting a Sender option.
+type SenderOption func(opts *SenderOptions)
+
+// Encode wraps the given content into envelopes which fit into messages with an Indexation with the given index.
+func Encode(index []byte, contentType string, data []byte, opts ...SenderOption) ([]*Envelope, error) {
+	options := &SenderOptions{}
+	options.apply(defaultSenderOptions...)
+	options.apply(opts...)
+	return encode(index, contentType, data, options)
+}
+
+func encode(index []byte, contentType string, data []byte, opts *SenderOptions) ([]*Envelope, error) {
+	if len(contentType) > ContentTypeMaxLength {
+		return nil, fmt.Errorf("%w: content type exceeds %d bytes", ErrInvalidEnvelope, ContentTypeMaxLength)
+	}
+
+	template := Envelope{ContentType: contentType, Hash: blake2b.Sum256(data)}
+	if opts.compress {
+		var compressed bytes.Buffer
+		compressor, err := flate.NewWriter(&compressed, flate.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := compressor.Write(data); err != nil {
+			return nil, fmt.Errorf("unable to compress content: %w", err)
+		}
+		if err := compressor.Close(); err != nil {
+			return nil, fmt.Errorf("unable to compress content: %w", err)
+		}
+		if compressed.Len() < len(data) {
+			data = compressed.Bytes()
+			template.Flags |= EnvelopeFlagCompressed
+		}
+	}
+
+	chunkSize := MaxChunkSize(index, contentType, opts.signer != nil)
+	if opts.chunkSize > 0 && opts.chunkSize < chunkSize {
+		chunkSize = opts.chunkSize
+	}
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("%w: no space for content in message", ErrContentTooLarge)
+	}
+
+	total := (len(data) + chunkSize - 1) / chunkSize
+	if total == 0 {
+		total = 1
+	}
+	envelopes := make([]*Envelope, total)
+	for i := range envelopes {
+		envelope := template
+		envelope.Sequence, envelope.Total = uint32(i), uint32(total)
+		end := (i + 1) * chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		envelope.Chunk = data[i*chunkSize : end]
+		if opts.signer != nil {
+			if err := envelope.Sign(opts.signer); err != nil {
+				return nil, err
+			}
+		}
+		envelopes[i] = &envelope
+	}
+	return envelopes, nil
+}
+
+// NewSender creates a new Sender sending content to the given index via the given node.
+func NewSender(nodeHTTPAPIClient *iotago.NodeHTTPAPIClient, index []byte, opts ...SenderOption) (*Sender, error) {
+	switch {
+	case len(index) < iotago.IndexationIndexMinLength:
+		return nil, fmt.Errorf("%w: index has %d bytes", iotago.ErrIndexationIndexUnderMinSize, len(index))
+	case len(index) > iotago.IndexationIndexMaxLength:
+		return nil, fmt.Errorf("%w: index has %d bytes", iotago.ErrIndexationIndexExceedsMaxSize, len(index))
+	}
+	options := &SenderOptions{}
+	options.apply(defaultSenderOptions...)
+	options.apply(opts...)
+	if options.tipSelector == nil {
+		options.tipSelector = iotago.NodeTipSelector(nodeHTTPAPIClient)
+	}
+	return &Sender{nodeAPI: nodeHTTPAPIClient, index: index, opts: options}, nil
+}
+
+// Sender sends content to an index.
+type Sender struct {
+	nodeAPI *iotago.NodeHTTPAPIClient
+	index   []byte
+	opts    *SenderOptions
+}
+
+// Send sends the given content in as many messages as needed and returns their IDs in chunk order.
+// Every message of a chunk references the message of the preceding chunk as a parent.
+// Missing information such as the nonce is filled in by the node.
+func (s *Sender) Send(ctx context.Context, contentType string, data []byte) (iotago.MessageIDs, error) {
+	envelopes, err := encode(s.index, contentType, data, s.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	msgIDs := make(iotago.MessageIDs, 0, len(envelopes))
+	for _, envelope := range envelopes {
+		envelopeData, err := envelope.Serialize()
+		if err != nil {
+			return msgIDs, err
+		}
+
+		tips, err := s.opts.tipSelector.SelectTips(ctx)
+		if err != nil {
+			return msgIDs, fmt.Errorf("unable to select tips: %w", err)
+		}
+		parents := tips
+		if len(msgIDs) > 0 {
+			if len(parents) >= iotago.MaxParentsInAMessage {
+				parents = parents[:iotago.MaxParentsInAMessage-1]
+			}
+			parents = append(iotago.MessageIDs{msgIDs[len(msgIDs)-1]}, parents...)
+		}
+
+		msg, err := iotago.NewMessageBuilder().
+			ParentsMessageIDs(parents).
+			Payload(&iotago.Indexation{Index: s.index, Data: envelopeData}).
+			Build()
+		if err != nil {
+			return msgIDs, err
+		}
+		submitted, err := s.nodeAPI.SubmitMessage(ctx, msg)
+		if err != nil {
+			return msgIDs, fmt.Errorf("unable to submit chunk %d of %d: %w", envelope.Sequence, envelope.Total, err)
+		}
+		msgID, err := submitted.ID()
+		if err != nil {
+			return msgIDs, err
+		}
+		msgIDs = append(msgIDs, *msgID)
+	}
+	return msgIDs, nil
+}
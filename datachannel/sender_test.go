@@ -0,0 +1,65 @@
+package datachannel_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/datachannel"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+func TestSender(t *testing.T) {
+	ctx := context.Background()
+	node := tpkg.NewFakeNode()
+	defer node.Close()
+	nodeAPI := node.Client()
+	index := []byte("data channel")
+	author := tpkg.RandEd25519PrivateKey()
+
+	_, err := datachannel.NewSender(nodeAPI, nil)
+	assert.True(t, errors.Is(err, iotago.ErrIndexationIndexUnderMinSize))
+
+	sender, err := datachannel.NewSender(nodeAPI, index, datachannel.WithSigner(author), datachannel.WithCompression(true), datachannel.WithChunkSize(20))
+	require.NoError(t, err)
+	data := []byte(strings.Repeat("compressible content ", 100))
+	msgIDs, err := sender.Send(ctx, "text/plain", data)
+	require.NoError(t, err)
+	require.Greater(t, len(msgIDs), 1)
+	assert.Less(t, len(msgIDs), len(data)/20)
+
+	// every chunk references its predecessor
+	for i := 1; i < len(msgIDs); i++ {
+		assert.Contains(t, node.Message(msgIDs[i]).Parents, msgIDs[i-1])
+	}
+
+	// garbage and content of other authors on the same index are skipped
+	node.AttachMessage(&iotago.Message{Parents: tpkg.SortedRand32BytArray(1), Payload: &iotago.Indexation{Index: index, Data: []byte("garbage")}})
+	other, err := datachannel.NewSender(nodeAPI, index, datachannel.WithSigner(tpkg.RandEd25519PrivateKey()))
+	require.NoError(t, err)
+	_, err = other.Send(ctx, "text/plain", []byte("other"))
+	require.NoError(t, err)
+
+	contents, err := datachannel.FromIndex(ctx, nodeAPI, index, datachannel.WithTrustedAuthors(author.Public().(ed25519.PublicKey)))
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	assert.Equal(t, "text/plain", contents[0].ContentType)
+	assert.Equal(t, data, contents[0].Data)
+	assert.Equal(t, author.Public(), contents[0].Author)
+	assert.Equal(t, msgIDs, contents[0].MessageIDs)
+
+	contents, err = datachannel.FromIndex(ctx, nodeAPI, index)
+	require.NoError(t, err)
+	assert.Len(t, contents, 2)
+
+	// content beyond the max. results of the node is not silently dropped
+	node.SetMaxResults(len(msgIDs) + 2)
+	_, err = datachannel.FromIndex(ctx, nodeAPI, index)
+	assert.True(t, errors.Is(err, datachannel.ErrTooManyMessages))
+}
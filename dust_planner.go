@@ -0,0 +1,327 @@
+package iotago
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrDustPaymentNotPossible gets returned when a dust output can not be created on an address,
+	// neither by topping up its dust allowance nor by consolidating its dust outputs.
+	ErrDustPaymentNotPossible = errors.New("dust payment not possible")
+)
+
+// DustPlanAction defines what has to be done in order to create a dust output on an address.
+type DustPlanAction byte
+
+const (
+	// DustPlanActionNone denotes that the payment can be made as is.
+	DustPlanActionNone DustPlanAction = iota
+	// DustPlanActionTopUp denotes that a SigLockedDustAllowanceOutput has to be deposited on the address
+	// together with the payment.
+	DustPlanActionTopUp
+	// DustPlanActionConsolidate denotes that existing dust outputs on the address have to be consolidated
+	// together with the payment into one output. This requires the keys of the address.
+	DustPlanActionConsolidate
+)
+
+// String returns a human readable name of the action.
+func (a DustPlanAction) String() string {
+	switch a {
+	case DustPlanActionNone:
+		return "none"
+	case DustPlanActionTopUp:
+		return "top-up"
+	case DustPlanActionConsolidate:
+		return "consolidate"
+	default:
+		return fmt.Sprintf("unknown dust plan action %d", a)
+	}
+}
+
+// DustAllowanceState is the dust allowance state of an address.
+type DustAllowanceState struct {
+	// The address the state belongs to.
+	Address Address
+	// The deposit sum of the dust allowance outputs on the address.
+	DustAllowanceSum uint64
+	// The amount of dust outputs on the address.
+	NumDustOutputs int64
+	// The known dust outputs on the address. Only if set, a consolidation can be planned.
+	// As consolidating them requires the keys of the address, they must only be set if the payer owns it.
+	DustOutputs map[*UTXOInput]Output
+}
+
+// DustAllowanceStateFromFunc returns the DustAllowanceState of the given address using the given DustAllowanceFunc.
+// The returned state does not contain the dust outputs.
+func DustAllowanceStateFromFunc(addr Address, dustAllowanceFunc DustAllowanceFunc) (*DustAllowanceState, error) {
+	dustAllowanceSum, numDustOutputs, err := dustAllowanceFunc(addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch dust allowance information on address %v: %w", addr, err)
+	}
+	return &DustAllowanceState{Address: addr, DustAllowanceSum: dustAllowanceSum, NumDustOutputs: numDustOutputs}, nil
+}
+
+// DustAllowanceStateViaNodeQuery returns the DustAllowanceState of the given address by querying
+// its unspent outputs from the node. The state contains the dust outputs, which must be removed
+// if the keys of the address are not available. It is the caller's job to ensure that the limit of returned outputs
+// on the queried node is enough high to contain all outputs of the address.
+func DustAllowanceStateViaNodeQuery(ctx context.Context, addr *Ed25519Address, nodeHTTPAPIClient *NodeHTTPAPIClient) (*DustAllowanceState, error) {
+	_, unspentOutputs, err := nodeHTTPAPIClient.OutputsByEd25519Address(ctx, addr, false)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &DustAllowanceState{Address: addr, DustOutputs: make(map[*UTXOInput]Output)}
+	for utxoInput, output := range unspentOutputs {
+		deposit, err := output.Deposit()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get deposit of output %s: %w", utxoInput.ID().ToHex(), err)
+		}
+
+		switch output.(type) {
+		case *SigLockedDustAllowanceOutput:
+			state.DustAllowanceSum += deposit
+		case *SigLockedSingleOutput:
+			if deposit < OutputSigLockedDustAllowanceOutputMinDeposit {
+				state.NumDustOutputs++
+				state.DustOutputs[utxoInput] = output
+			}
+		}
+	}
+	return state, nil
+}
+
+// NodeDustAllowanceFunc returns a DustAllowanceFunc which queries the dust allowance state of Ed25519 addresses
+// from the node.
+func NodeDustAllowanceFunc(ctx context.Context, nodeHTTPAPIClient *NodeHTTPAPIClient) DustAllowanceFunc {
+	return func(addr Address) (uint64, int64, error) {
+		ed25519Addr, ok := addr.(*Ed25519Address)
+		if !ok {
+			return 0, 0, fmt.Errorf("%w: dust allowance via node query only supports Ed25519Address but got %T", ErrTransactionBuilderUnsupportedAddress, addr)
+		}
+		state, err := DustAllowanceStateViaNodeQuery(ctx, ed25519Addr, nodeHTTPAPIClient)
+		if err != nil {
+			return 0, 0, err
+		}
+		return state.DustAllowanceSum, state.NumDustOutputs, nil
+	}
+}
+
+// DustPlan describes how a payment to an address can be made without violating the dust allowance rules.
+type DustPlan struct {
+	// The action needed to make the payment.
+	Action DustPlanAction
+	// The address receiving the payment.
+	Address Address
+	// The amount of the payment.
+	Amount uint64
+	// The deposit of the SigLockedDustAllowanceOutput to create on the address if Action is DustPlanActionTopUp.
+	TopUp uint64
+	// The dust outputs to consolidate if Action is DustPlanActionConsolidate.
+	Consolidate []*UTXOInput
+	// The deposit sum of the dust outputs to consolidate.
+	ConsolidatedAmount uint64
+	// The amount of allowed dust outputs on the address after the plan is executed.
+	AllowedDustOutputs int64
+	// The amount of dust outputs on the address after the plan is executed.
+	NumDustOutputs int64
+}
+
+// Outputs returns the outputs needed to execute the plan. If Action is DustPlanActionConsolidate,
+// the payment and the consolidated dust outputs are merged into one output.
+func (p *DustPlan) Outputs() Outputs {
+	switch p.Action {
+	case DustPlanActionTopUp:
+		return Outputs{
+			&SigLockedSingleOutput{Address: p.Address, Amount: p.Amount},
+			&SigLockedDustAllowanceOutput{Address: p.Address, Amount: p.TopUp},
+		}
+	case DustPlanActionConsolidate:
+		return Outputs{&SigLockedSingleOutput{Address: p.Address, Amount: p.Amount + p.ConsolidatedAmount}}
+	default:
+		return Outputs{&SigLockedSingleOutput{Address: p.Address, Amount: p.Amount}}
+	}
+}
+
+var defaultDustPlannerOptions = []DustPlannerOption{
+	WithDustPlannerDivisor(DustAllowanceDivisor),
+	WithDustPlannerDustOutputsCountLimit(MaxDustOutputsOnAddress),
+}
+
+// DustPlannerOptions define options for the DustPlanner.
+type DustPlannerOptions struct {
+	// The divisor used to compute the allowed dust outputs on an address.
+	div int64
+	// The maximum amount of dust outputs allowed on an address.
+	dustOutputsCountLimit int64
+	// Whether to consolidate dust outputs instead of topping up the dust allowance.
+	preferConsolidation bool
+}
+
+// applies the given DustPlannerOption.
+func (po *DustPlannerOptions) apply(opts ...DustPlannerOption) {
+	for _, opt := range opts {
+		opt(po)
+	}
+}
+
+// WithDustPlannerDivisor sets the divisor used to compute the allowed dust outputs on an address.
+func WithDustPlannerDivisor(div int64) DustPlannerOption {
+	return func(opts *DustPlannerOptions) {
+		opts.div = div
+	}
+}
+
+// WithDustPlannerDustOutputsCountLimit sets the maximum amount of dust outputs allowed on an address.
+func WithDustPlannerDustOutputsCountLimit(limit int64) DustPlannerOption {
+	return func(opts *DustPlannerOptions) {
+		opts.dustOutputsCountLimit = limit
+	}
+}
+
+// WithDustPlannerPreferConsolidation defines whether the dust outputs of an address are consolidated
+// instead of topping up its dust allowance, given the dust outputs are known.
+func WithDustPlannerPreferConsolidation(prefer bool) DustPlannerOption {
+	return func(opts *DustPlannerOptions) {
+		opts.preferConsolidation = prefer
+	}
+}
+
+// DustPlannerOption is a function setting a DustPlanner option.
+type DustPlannerOption func(opts *DustPlannerOptions)
+
+// NewDustPlanner creates a new DustPlanner.
+func NewDustPlanner(opts ...DustPlannerOption) *DustPlanner {
+	options := &DustPlannerOptions{}
+	options.apply(defaultDustPlannerOptions...)
+	options.apply(opts...)
+	return &DustPlanner{opts: options}
+}
+
+// DustPlanner plans payments which comply with the dust allowance rules described by NewDustSemanticValidation.
+type DustPlanner struct {
+	opts *DustPlannerOptions
+}
+
+// returns the amount of allowed dust outputs given the deposit sum of dust allowance outputs.
+func (p *DustPlanner) allowed(dustAllowanceSum uint64) int64 {
+	allowed := int64(dustAllowanceSum) / p.opts.div
+	if allowed > p.opts.dustOutputsCountLimit {
+		allowed = p.opts.dustOutputsCountLimit
+	}
+	return allowed
+}
+
+// Plan plans a payment of the given amount to the address of the given state.
+// Payments of at least OutputSigLockedDustAllowanceOutputMinDeposit are not dust and never need any action.
+// If the address can't hold another dust output, the plan either tops up its dust allowance or, if the dust outputs
+// of the address are known and the dust outputs count limit is reached (or consolidation is preferred),
+// consolidates them. ErrDustPaymentNotPossible is returned if neither is possible.
+func (p *DustPlanner) Plan(state *DustAllowanceState, amount uint64) (*DustPlan, error) {
+	return p.plan(state, amount, MaxInputsCount)
+}
+
+// plans a payment like Plan but consolidates at most maxInputs dust outputs.
+func (p *DustPlanner) plan(state *DustAllowanceState, amount uint64, maxInputs int) (*DustPlan, error) {
+	if amount == 0 {
+		return nil, fmt.Errorf("%w: payment to %s", ErrDepositAmountMustBeGreaterThanZero, state.Address)
+	}
+
+	plan := &DustPlan{
+		Action:             DustPlanActionNone,
+		Address:            state.Address,
+		Amount:             amount,
+		AllowedDustOutputs: p.allowed(state.DustAllowanceSum),
+		NumDustOutputs:     state.NumDustOutputs,
+	}
+
+	if amount >= OutputSigLockedDustAllowanceOutputMinDeposit {
+		return plan, nil
+	}
+
+	numDustOutputs := state.NumDustOutputs + 1
+	if numDustOutputs <= plan.AllowedDustOutputs {
+		plan.NumDustOutputs = numDustOutputs
+		return plan, nil
+	}
+
+	canTopUp := numDustOutputs <= p.opts.dustOutputsCountLimit
+	canConsolidate := len(state.DustOutputs) > 0 && maxInputs > 0
+	if canConsolidate && (!canTopUp || p.opts.preferConsolidation) {
+		if err := p.planConsolidation(plan, state, maxInputs); err == nil || !canTopUp {
+			return plan, err
+		}
+	}
+
+	if !canTopUp {
+		return nil, fmt.Errorf("%w: address %s already holds %d dust outputs (limit %d)", ErrDustPaymentNotPossible, state.Address, state.NumDustOutputs, p.opts.dustOutputsCountLimit)
+	}
+
+	// the deposit needed to allow one more dust output, but at least the min deposit of a dust allowance output
+	topUp := uint64(numDustOutputs*p.opts.div) - state.DustAllowanceSum
+	if topUp < OutputSigLockedDustAllowanceOutputMinDeposit {
+		topUp = OutputSigLockedDustAllowanceOutputMinDeposit
+	}
+
+	plan.Action = DustPlanActionTopUp
+	plan.TopUp = topUp
+	plan.AllowedDustOutputs = p.allowed(state.DustAllowanceSum + topUp)
+	plan.NumDustOutputs = numDustOutputs
+	return plan, nil
+}
+
+// plans the consolidation of the fewest dust outputs needed to make the payment, starting with the largest ones.
+// At most maxInputs dust outputs are consolidated.
+func (p *DustPlanner) planConsolidation(plan *DustPlan, state *DustAllowanceState, maxInputs int) error {
+	type dustOutput struct {
+		input   *UTXOInput
+		deposit uint64
+	}
+
+	dustOutputs := make([]dustOutput, 0, len(state.DustOutputs))
+	for input, output := range state.DustOutputs {
+		deposit, err := output.Deposit()
+		if err != nil {
+			return fmt.Errorf("unable to get deposit of output %s: %w", input.ID().ToHex(), err)
+		}
+		dustOutputs = append(dustOutputs, dustOutput{input: input, deposit: deposit})
+	}
+
+	sort.Slice(dustOutputs, func(i, j int) bool {
+		if dustOutputs[i].deposit != dustOutputs[j].deposit {
+			return dustOutputs[i].deposit > dustOutputs[j].deposit
+		}
+		idI, idJ := dustOutputs[i].input.ID(), dustOutputs[j].input.ID()
+		return bytes.Compare(idI[:], idJ[:]) < 0
+	})
+
+	var consolidated []*UTXOInput
+	var consolidatedAmount uint64
+	for _, dustOutput := range dustOutputs {
+		if len(consolidated) == maxInputs {
+			break
+		}
+		consolidated = append(consolidated, dustOutput.input)
+		consolidatedAmount += dustOutput.deposit
+
+		// the merged output only stays dust if it's still below the min deposit
+		numDustOutputs := state.NumDustOutputs - int64(len(consolidated))
+		if plan.Amount+consolidatedAmount < OutputSigLockedDustAllowanceOutputMinDeposit {
+			numDustOutputs++
+		}
+
+		if numDustOutputs <= plan.AllowedDustOutputs {
+			plan.Action = DustPlanActionConsolidate
+			plan.Consolidate = consolidated
+			plan.ConsolidatedAmount = consolidatedAmount
+			plan.NumDustOutputs = numDustOutputs
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: consolidating %d dust outputs on address %s is not enough", ErrDustPaymentNotPossible, len(consolidated), state.Address)
+}
@@ -0,0 +1,276 @@
+package iotago_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+// returns n dust outputs with the given deposit.
+func randDustOutputs(addr iotago.Address, n int, deposit uint64) map[*iotago.UTXOInput]iotago.Output {
+	outputs := make(map[*iotago.UTXOInput]iotago.Output, n)
+	for i := 0; i < n; i++ {
+		outputs[&iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}] = &iotago.SigLockedSingleOutput{Address: addr, Amount: deposit}
+	}
+	return outputs
+}
+
+func TestDustPlanner_Plan(t *testing.T) {
+	addr, _ := tpkg.RandEd25519Address()
+
+	tests := []struct {
+		name    string
+		planner *iotago.DustPlanner
+		state   *iotago.DustAllowanceState
+		amount  uint64
+		plan    *iotago.DustPlan
+		err     error
+	}{
+		{
+			name:    "ok - not dust",
+			planner: iotago.NewDustPlanner(),
+			state:   &iotago.DustAllowanceState{Address: addr},
+			amount:  iotago.OutputSigLockedDustAllowanceOutputMinDeposit,
+			plan: &iotago.DustPlan{
+				Action: iotago.DustPlanActionNone, Address: addr, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit,
+			},
+		},
+		{
+			name:    "ok - dust within allowance",
+			planner: iotago.NewDustPlanner(),
+			state:   &iotago.DustAllowanceState{Address: addr, DustAllowanceSum: 1_000_000, NumDustOutputs: 9},
+			amount:  1,
+			plan: &iotago.DustPlan{
+				Action: iotago.DustPlanActionNone, Address: addr, Amount: 1,
+				AllowedDustOutputs: 10, NumDustOutputs: 10,
+			},
+		},
+		{
+			name:    "ok - top-up with min deposit",
+			planner: iotago.NewDustPlanner(),
+			state:   &iotago.DustAllowanceState{Address: addr},
+			amount:  1,
+			plan: &iotago.DustPlan{
+				Action: iotago.DustPlanActionTopUp, Address: addr, Amount: 1, TopUp: 1_000_000,
+				AllowedDustOutputs: 10, NumDustOutputs: 1,
+			},
+		},
+		{
+			name:    "ok - top-up above min deposit",
+			planner: iotago.NewDustPlanner(),
+			state:   &iotago.DustAllowanceState{Address: addr, DustAllowanceSum: 1_000_000, NumDustOutputs: 25},
+			amount:  1,
+			plan: &iotago.DustPlan{
+				Action: iotago.DustPlanActionTopUp, Address: addr, Amount: 1, TopUp: 1_600_000,
+				AllowedDustOutputs: 26, NumDustOutputs: 26,
+			},
+		},
+		{
+			name:    "ok - top-up with custom divisor",
+			planner: iotago.NewDustPlanner(iotago.WithDustPlannerDivisor(1_000_000)),
+			state:   &iotago.DustAllowanceState{Address: addr, DustAllowanceSum: 1_000_000, NumDustOutputs: 1},
+			amount:  1,
+			plan: &iotago.DustPlan{
+				Action: iotago.DustPlanActionTopUp, Address: addr, Amount: 1, TopUp: 1_000_000,
+				AllowedDustOutputs: 2, NumDustOutputs: 2,
+			},
+		},
+		{
+			name:    "err - limit reached without known dust outputs",
+			planner: iotago.NewDustPlanner(iotago.WithDustPlannerDustOutputsCountLimit(3)),
+			state:   &iotago.DustAllowanceState{Address: addr, DustAllowanceSum: 1_000_000, NumDustOutputs: 3},
+			amount:  1,
+			err:     iotago.ErrDustPaymentNotPossible,
+		},
+		{
+			name:    "err - zero amount",
+			planner: iotago.NewDustPlanner(),
+			state:   &iotago.DustAllowanceState{Address: addr},
+			amount:  0,
+			err:     iotago.ErrDepositAmountMustBeGreaterThanZero,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := tt.planner.Plan(tt.state, tt.amount)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.plan, plan)
+		})
+	}
+}
+
+func TestDustPlanner_Consolidate(t *testing.T) {
+	addr, _ := tpkg.RandEd25519Address()
+
+	dustOutputs := randDustOutputs(addr, 3, 100)
+	largest := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}
+	dustOutputs[largest] = &iotago.SigLockedSingleOutput{Address: addr, Amount: 999_000}
+
+	// the limit is reached, the largest dust output together with the payment is no dust anymore
+	state := &iotago.DustAllowanceState{Address: addr, DustAllowanceSum: 1_000_000, NumDustOutputs: 4, DustOutputs: dustOutputs}
+	planner := iotago.NewDustPlanner(iotago.WithDustPlannerDustOutputsCountLimit(4))
+	plan, err := planner.Plan(state, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, iotago.DustPlanActionConsolidate, plan.Action)
+	assert.Equal(t, []*iotago.UTXOInput{largest}, plan.Consolidate)
+	assert.EqualValues(t, 999_000, plan.ConsolidatedAmount)
+	assert.EqualValues(t, 3, plan.NumDustOutputs)
+	assert.Equal(t, iotago.Outputs{&iotago.SigLockedSingleOutput{Address: addr, Amount: 1_000_000}}, plan.Outputs())
+
+	// the merged output stays dust but takes the place of the consolidated one
+	state.DustOutputs = randDustOutputs(addr, 4, 100)
+	plan, err = planner.Plan(state, 1000)
+	require.NoError(t, err)
+	assert.Len(t, plan.Consolidate, 1)
+	assert.EqualValues(t, 100, plan.ConsolidatedAmount)
+	assert.EqualValues(t, 4, plan.NumDustOutputs)
+	assert.Equal(t, iotago.Outputs{&iotago.SigLockedSingleOutput{Address: addr, Amount: 1100}}, plan.Outputs())
+
+	// topping up is preferred unless consolidation is requested
+	state = &iotago.DustAllowanceState{Address: addr, NumDustOutputs: 2, DustOutputs: randDustOutputs(addr, 2, 100)}
+	plan, err = iotago.NewDustPlanner().Plan(state, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, iotago.DustPlanActionTopUp, plan.Action)
+
+	plan, err = iotago.NewDustPlanner(iotago.WithDustPlannerPreferConsolidation(true)).Plan(state, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, iotago.DustPlanActionTopUp, plan.Action, "without any allowance, consolidation can't help")
+
+	state.DustAllowanceSum = 1_000_000
+	state.NumDustOutputs = 10
+	state.DustOutputs = randDustOutputs(addr, 10, 100)
+	plan, err = iotago.NewDustPlanner(iotago.WithDustPlannerPreferConsolidation(true)).Plan(state, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, iotago.DustPlanActionConsolidate, plan.Action)
+	assert.Len(t, plan.Consolidate, 1)
+	assert.EqualValues(t, 10, plan.NumDustOutputs)
+}
+
+func TestTransactionBuilder_AddDustSafeOutput(t *testing.T) {
+	prvKey := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: prvKey}
+	recipient, _ := tpkg.RandEd25519Address()
+	inputUTXO := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}
+
+	dustAllowanceFunc := func(addr iotago.Address) (uint64, int64, error) {
+		return 0, 0, nil
+	}
+
+	tx, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO}).
+		AddDustSafeOutput(recipient, 500, dustAllowanceFunc, nil).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 1_999_500}).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	require.NoError(t, err)
+	require.NoError(t, tx.SyntacticallyValidate())
+
+	utxos := iotago.InputToOutputMapping{
+		inputUTXO.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 3_000_000},
+	}
+	assert.NoError(t, tx.SemanticallyValidate(utxos, iotago.NewDustSemanticValidation(iotago.DustAllowanceDivisor, iotago.MaxDustOutputsOnAddress, dustAllowanceFunc)))
+	assert.Len(t, tx.Essence.(*iotago.TransactionEssence).Outputs, 3)
+
+	_, err = iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO}).
+		AddDustSafeOutput(recipient, 500, func(addr iotago.Address) (uint64, int64, error) {
+			return 1_000_000, iotago.MaxDustOutputsOnAddress, nil
+		}, nil).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.True(t, errors.Is(err, iotago.ErrDustPaymentNotPossible))
+}
+
+func TestTransactionBuilder_AddDustSafeOutputViaNodeQuery(t *testing.T) {
+	defer gock.Off()
+
+	prvKey := tpkg.RandEd25519PrivateKey()
+	addr := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &addr, Keys: prvKey}
+	ownInput := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}
+
+	allowanceInput := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}
+	dustInput := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}
+	outputs := map[*iotago.UTXOInput]iotago.Output{
+		allowanceInput: &iotago.SigLockedDustAllowanceOutput{Address: &addr, Amount: 1_000_000},
+		dustInput:      &iotago.SigLockedSingleOutput{Address: &addr, Amount: 999_000},
+	}
+
+	// mocks the unspent outputs of the address for the given amount of queries
+	mockOutputs := func(times int) {
+		outputIDs := make([]iotago.OutputIDHex, 0, len(outputs))
+		for input, output := range outputs {
+			outputJSON, err := json.Marshal(output)
+			require.NoError(t, err)
+			rawOutputJSON := json.RawMessage(outputJSON)
+
+			inputID := input.ID()
+			outputIDs = append(outputIDs, iotago.OutputIDHex(inputID.ToHex()))
+			gock.New(nodeAPIUrl).
+				Get(fmt.Sprintf(iotago.NodeAPIRouteOutput, inputID.ToHex())).
+				Times(times).
+				Reply(200).
+				JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeOutputResponse{RawOutput: &rawOutputJSON}})
+		}
+
+		gock.New(nodeAPIUrl).
+			Get(fmt.Sprintf(iotago.NodeAPIRouteAddressEd25519Outputs, addr.String())).
+			Times(times).
+			Reply(200).
+			JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.AddressOutputsResponse{
+				Address: addr.String(), Count: uint32(len(outputIDs)), OutputIDs: outputIDs,
+			}})
+	}
+	mockOutputs(3)
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	planner := iotago.NewDustPlanner(iotago.WithDustPlannerDustOutputsCountLimit(1))
+	tx, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &addr, Input: ownInput}).
+		AddDustSafeOutputViaNodeQuery(context.Background(), &addr, 1000, nodeAPI, planner).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	require.NoError(t, err)
+
+	// the dust output got consolidated together with the payment
+	essence := tx.Essence.(*iotago.TransactionEssence)
+	inputIDs := make([]iotago.UTXOInputID, len(essence.Inputs))
+	for i, input := range essence.Inputs {
+		inputIDs[i] = input.(*iotago.UTXOInput).ID()
+	}
+	assert.ElementsMatch(t, []iotago.UTXOInputID{ownInput.ID(), dustInput.ID()}, inputIDs)
+	assert.Equal(t, &iotago.SigLockedSingleOutput{Address: &addr, Amount: 1_000_000}, essence.Outputs[0])
+
+	// the dust outputs of an address the payer doesn't own are not consolidated
+	otherPrvKey := tpkg.RandEd25519PrivateKey()
+	otherAddr := iotago.AddressFromEd25519PubKey(otherPrvKey.Public().(ed25519.PublicKey))
+	_, err = iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &otherAddr, Input: ownInput}).
+		AddDustSafeOutputViaNodeQuery(context.Background(), &addr, 1000, nodeAPI, planner).
+		Build(iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &otherAddr, Keys: otherPrvKey}))
+	assert.True(t, errors.Is(err, iotago.ErrDustPaymentNotPossible))
+
+	// the inputs already in the builder count towards the inputs count limit
+	builder := iotago.NewTransactionBuilder()
+	for i := 0; i < iotago.MaxInputsCount; i++ {
+		builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: &addr, Input: &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}})
+	}
+	_, err = builder.
+		AddDustSafeOutputViaNodeQuery(context.Background(), &addr, 1000, nodeAPI, planner).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.True(t, errors.Is(err, iotago.ErrDustPaymentNotPossible))
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,108 @@
+package ed25519
+
+import (
+	cryptorand "crypto/rand"
+
+	"filippo.io/edwards25519"
+)
+
+// size in bytes of the random coefficients used to combine the signatures of a batch.
+const batchCoefficientSize = 16
+
+// BatchVerifier verifies multiple signatures at once, which is considerably faster than verifying them one by one.
+//
+// It applies the same validation criteria (ZIP 215) as Verify: as the batch equation is cofactored,
+// a batch is valid if and only if each of its signatures is valid according to Verify,
+// except for a negligible probability. If the batch is invalid, each signature is verified
+// individually to identify the invalid ones.
+type BatchVerifier struct {
+	entries []batchEntry
+}
+
+type batchEntry struct {
+	publicKey PublicKey
+	message   []byte
+	sig       []byte
+}
+
+// NewBatchVerifier creates a new BatchVerifier with capacity for the given amount of signatures.
+func NewBatchVerifier(size int) *BatchVerifier {
+	return &BatchVerifier{entries: make([]batchEntry, 0, size)}
+}
+
+// Add adds the signature sig of message by publicKey to the batch.
+// The given slices are not copied and must not be modified until Verify returns.
+func (v *BatchVerifier) Add(publicKey PublicKey, message, sig []byte) {
+	v.entries = append(v.entries, batchEntry{publicKey: publicKey, message: message, sig: sig})
+}
+
+// Len returns the amount of signatures in the batch.
+func (v *BatchVerifier) Len() int {
+	return len(v.entries)
+}
+
+// Verify reports whether all signatures of the batch are valid and the validity of each signature,
+// in the order they were added.
+func (v *BatchVerifier) Verify() (bool, []bool) {
+	valid := make([]bool, len(v.entries))
+	switch len(v.entries) {
+	case 0:
+		return true, valid
+	case 1:
+		valid[0] = Verify(v.entries[0].publicKey, v.entries[0].message, v.entries[0].sig)
+		return valid[0], valid
+	}
+
+	// the batch equation is [8](sum(z_i * (s_i * B - R_i - k_i * A_i))) == 0 for random z_i
+	n := len(v.entries)
+	scalars := make([]*edwards25519.Scalar, 1, 1+2*n)
+	points := make([]*edwards25519.Point, 1, 1+2*n)
+	scalars[0], points[0] = edwards25519.NewScalar(), edwards25519.NewGeneratorPoint()
+
+	allDecoded := true
+	var decoded []int
+	for i, entry := range v.entries {
+		A, R, s, k, ok := decodeVerificationInput(entry.publicKey, entry.message, entry.sig)
+		if !ok {
+			allDecoded = false
+			continue
+		}
+		decoded = append(decoded, i)
+
+		z := randomCoefficient()
+		scalars[0].MultiplyAdd(z, s, scalars[0])
+		scalars = append(scalars, new(edwards25519.Scalar).Negate(z), new(edwards25519.Scalar).Negate(new(edwards25519.Scalar).Multiply(z, k)))
+		points = append(points, R, A)
+	}
+
+	if len(decoded) == 0 {
+		return false, valid
+	}
+
+	p := new(edwards25519.Point).VarTimeMultiScalarMult(scalars, points)
+	if p.MultByCofactor(p).Equal(edwards25519.NewIdentityPoint()) == 1 {
+		for _, i := range decoded {
+			valid[i] = true
+		}
+		return allDecoded, valid
+	}
+
+	// fall back to individual verification to identify the invalid signatures
+	for _, i := range decoded {
+		valid[i] = Verify(v.entries[i].publicKey, v.entries[i].message, v.entries[i].sig)
+	}
+	return false, valid
+}
+
+// returns a random scalar of batchCoefficientSize bytes.
+func randomCoefficient() *edwards25519.Scalar {
+	var buf [32]byte
+	if _, err := cryptorand.Read(buf[:batchCoefficientSize]); err != nil {
+		panic("ed25519: unable to read randomness: " + err.Error())
+	}
+	z, err := new(edwards25519.Scalar).SetCanonicalBytes(buf[:])
+	if err != nil {
+		panic(err)
+	}
+	return z
+}
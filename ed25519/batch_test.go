@@ -0,0 +1,107 @@
+package ed25519_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signs n distinct messages with n distinct keys and adds them to a new BatchVerifier.
+func newSignedBatch(t testing.TB, n int) (*ed25519.BatchVerifier, []ed25519.PublicKey, [][]byte, [][]byte) {
+	v := ed25519.NewBatchVerifier(n)
+	publicKeys := make([]ed25519.PublicKey, n)
+	messages := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		public, private, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+		publicKeys[i], messages[i] = public, []byte(fmt.Sprintf("message %d", i))
+		sigs[i] = ed25519.Sign(private, messages[i])
+		v.Add(publicKeys[i], messages[i], sigs[i])
+	}
+	return v, publicKeys, messages, sigs
+}
+
+func TestBatchVerifier(t *testing.T) {
+	v, _, _, _ := newSignedBatch(t, 16)
+	assert.Equal(t, 16, v.Len())
+	ok, valid := v.Verify()
+	assert.True(t, ok)
+	for i := range valid {
+		assert.Truef(t, valid[i], "signature %d is invalid", i)
+	}
+
+	ok, valid = ed25519.NewBatchVerifier(0).Verify()
+	assert.True(t, ok)
+	assert.Empty(t, valid)
+}
+
+func TestBatchVerifier_IdentifiesInvalid(t *testing.T) {
+	_, publicKeys, messages, sigs := newSignedBatch(t, 8)
+
+	// wrong message, wrong key, malleable and undecodable signatures
+	otherPublic, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	malleable := append([]byte{}, sigs[5]...)
+	malleable[63] |= 0x80
+
+	v := ed25519.NewBatchVerifier(len(sigs))
+	for i := range sigs {
+		switch i {
+		case 1:
+			v.Add(publicKeys[i], []byte("other message"), sigs[i])
+		case 3:
+			v.Add(otherPublic, messages[i], sigs[i])
+		case 5:
+			v.Add(publicKeys[i], messages[i], malleable)
+		case 6:
+			v.Add(publicKeys[i], messages[i], sigs[i][:ed25519.SignatureSize-1])
+		default:
+			v.Add(publicKeys[i], messages[i], sigs[i])
+		}
+	}
+
+	ok, valid := v.Verify()
+	assert.False(t, ok)
+	assert.Equal(t, []bool{true, false, true, false, true, false, false, true}, valid)
+}
+
+func TestBatchVerifier_Single(t *testing.T) {
+	_, publicKeys, messages, sigs := newSignedBatch(t, 2)
+	single := ed25519.NewBatchVerifier(1)
+	single.Add(publicKeys[0], messages[1], sigs[0])
+	ok, valid := single.Verify()
+	assert.False(t, ok)
+	assert.Equal(t, []bool{false}, valid)
+}
+
+func TestBatchVerifier_ZIP215(t *testing.T) {
+	v := ed25519.NewBatchVerifier(len(tests))
+	for _, tt := range tests {
+		publicKey, _ := hex.DecodeString(tt.pk)
+		sig, _ := hex.DecodeString(tt.s)
+		v.Add(publicKey, message, sig)
+	}
+
+	ok, valid := v.Verify()
+	assert.True(t, ok)
+	for i := range valid {
+		assert.Truef(t, valid[i], "test %d failed to verify", i)
+	}
+}
+
+func BenchmarkBatchVerifier(b *testing.B) {
+	for _, n := range []int{8, 64} {
+		v, _, _, _ := newSignedBatch(b, n)
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				v.Verify()
+			}
+		})
+	}
+}
@@ -188,19 +188,38 @@ func sign(signature, privateKey, message []byte) {
 // Verify reports whether sig is a valid signature of message by publicKey.
 // It uses precisely-specified validation criteria (ZIP 215) suitable for use in consensus-critical contexts.
 func Verify(publicKey PublicKey, message, sig []byte) bool {
-	if len(publicKey) != PublicKeySize {
+	A, checkR, s, k, ok := decodeVerificationInput(publicKey, message, sig)
+	if !ok {
 		return false
 	}
+	A.Negate(A)
+
+	R := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(k, A, s)
+
+	// ZIP215: We want to check [8](R - checkR) == 0
+	p := new(edwards25519.Point).Subtract(R, checkR)     // p = R - checkR
+	p.Add(p, p)                                          // p = [2]p
+	p.Add(p, p)                                          // p = [4]p
+	p.Add(p, p)                                          // p = [8]p
+	return p.Equal(edwards25519.NewIdentityPoint()) == 1 // p == 0
+}
+
+// decodeVerificationInput decodes the public key A, the commitment R and the scalar s of the signature
+// and computes the challenge k = H(R || A || message). It returns false if any of the encodings are invalid.
+// Verify and the BatchVerifier share it to apply the same criteria.
+func decodeVerificationInput(publicKey PublicKey, message, sig []byte) (A, R *edwards25519.Point, s, k *edwards25519.Scalar, ok bool) {
+	if len(publicKey) != PublicKeySize {
+		return nil, nil, nil, nil, false
+	}
 	if len(sig) != SignatureSize || sig[63]&224 != 0 {
-		return false
+		return nil, nil, nil, nil, false
 	}
 
 	// ZIP215: this works because SetBytes does not check that encodings are canonical
 	A, err := new(edwards25519.Point).SetBytes(publicKey)
 	if err != nil {
-		return false
+		return nil, nil, nil, nil, false
 	}
-	A.Negate(A)
 
 	h := sha512.New()
 	h.Write(sig[:32])
@@ -209,30 +228,23 @@ func Verify(publicKey PublicKey, message, sig []byte) bool {
 	var digest [64]byte
 	h.Sum(digest[:0])
 
-	hReduced, err := new(edwards25519.Scalar).SetUniformBytes(digest[:])
+	k, err = new(edwards25519.Scalar).SetUniformBytes(digest[:])
 	if err != nil {
 		panic(err)
 	}
 
 	// ZIP215: this works because SetBytes does not check that encodings are canonical
-	checkR, err := new(edwards25519.Point).SetBytes(sig[:32])
+	R, err = new(edwards25519.Point).SetBytes(sig[:32])
 	if err != nil {
-		return false
+		return nil, nil, nil, nil, false
 	}
 
 	// https://tools.ietf.org/html/rfc8032#section-5.1.7 requires that s be in
 	// the range [0, order) in order to prevent signature malleability
-	s, err := new(edwards25519.Scalar).SetCanonicalBytes(sig[32:])
+	s, err = new(edwards25519.Scalar).SetCanonicalBytes(sig[32:])
 	if err != nil {
-		return false
+		return nil, nil, nil, nil, false
 	}
 
-	R := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(hReduced, A, s)
-
-	// ZIP215: We want to check [8](R - checkR) == 0
-	p := new(edwards25519.Point).Subtract(R, checkR)     // p = R - checkR
-	p.Add(p, p)                                          // p = [2]p
-	p.Add(p, p)                                          // p = [4]p
-	p.Add(p, p)                                          // p = [8]p
-	return p.Equal(edwards25519.NewIdentityPoint()) == 1 // p == 0
+	return A, R, s, k, true
 }
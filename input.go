@@ -52,7 +52,7 @@ func InputsUTXORefsUniqueValidator() InputsValidatorFunc {
 		}
 		k := b.String()
 		if j, has := set[k]; has {
-			return fmt.Errorf("%w: input %d and %d share the same UTXO ref", ErrInputUTXORefsNotUnique, j, index)
+			return newViolation(RuleInputUTXORefsUnique, index, nil, input.ID().ToHex(), fmt.Errorf("%w: input %d and %d share the same UTXO ref", ErrInputUTXORefsNotUnique, j, index))
 		}
 		set[k] = index
 		return nil
@@ -63,7 +63,7 @@ func InputsUTXORefsUniqueValidator() InputsValidatorFunc {
 func InputsUTXORefIndexBoundsValidator() InputsValidatorFunc {
 	return func(index int, input *UTXOInput) error {
 		if input.TransactionOutputIndex < RefUTXOIndexMin || input.TransactionOutputIndex > RefUTXOIndexMax {
-			return fmt.Errorf("%w: input %d", ErrRefUTXOIndexInvalid, index)
+			return newViolation(RuleInputUTXORefIndexBounds, index, RefUTXOIndexMax, input.TransactionOutputIndex, fmt.Errorf("%w: input %d", ErrRefUTXOIndexInvalid, index))
 		}
 		return nil
 	}
@@ -73,13 +73,21 @@ var utxoInputRefBoundsValidator = InputsUTXORefIndexBoundsValidator()
 
 // ValidateInputs validates the inputs by running them against the given InputsValidatorFunc.
 func ValidateInputs(inputs serializer.Serializables, funcs ...InputsValidatorFunc) error {
+	return validateInputs(inputs, nil, funcs...)
+}
+
+// validates the inputs and adds the violations to the given collector.
+func validateInputs(inputs serializer.Serializables, c *violationCollector, funcs ...InputsValidatorFunc) error {
 	for i, input := range inputs {
 		dep, ok := input.(*UTXOInput)
 		if !ok {
-			return fmt.Errorf("%w: can only validate on UTXO inputs", ErrUnknownInputType)
+			if err := c.add(newViolation(RuleInputType, i, nil, fmt.Sprintf("%T", input), fmt.Errorf("%w: can only validate on UTXO inputs", ErrUnknownInputType))); err != nil {
+				return err
+			}
+			continue
 		}
 		for _, f := range funcs {
-			if err := f(i, dep); err != nil {
+			if err := c.add(f(i, dep)); err != nil {
 				return err
 			}
 		}
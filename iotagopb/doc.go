@@ -0,0 +1,11 @@
+// Package iotagopb provides a Protocol Buffers representation of the iotago data models and functions
+// to losslessly convert between both representations.
+//
+// The schema is defined in proto/iotago.proto. Use Marshal to produce a canonical (deterministic) encoding:
+//
+//	pbMsg, err := iotagopb.MessageToProto(msg)
+//	if err != nil {
+//		return err
+//	}
+//	data, err := iotagopb.Marshal(pbMsg)
+package iotagopb
@@ -0,0 +1,2057 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.12.3
+// source: proto/iotago.proto
+
+package iotagopb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NetworkId uint64   `protobuf:"varint,1,opt,name=networkId,proto3" json:"networkId,omitempty"`
+	Parents   [][]byte `protobuf:"bytes,2,rep,name=parents,proto3" json:"parents,omitempty"`
+	Payload   *Payload `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Nonce     uint64   `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Message) GetNetworkId() uint64 {
+	if x != nil {
+		return x.NetworkId
+	}
+	return 0
+}
+
+func (x *Message) GetParents() [][]byte {
+	if x != nil {
+		return x.Parents
+	}
+	return nil
+}
+
+func (x *Message) GetPayload() *Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Message) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+type Payload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Payload:
+	//	*Payload_Transaction
+	//	*Payload_Milestone
+	//	*Payload_Indexation
+	//	*Payload_Receipt
+	//	*Payload_TreasuryTransaction
+	Payload isPayload_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *Payload) Reset() {
+	*x = Payload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Payload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payload) ProtoMessage() {}
+
+func (x *Payload) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payload.ProtoReflect.Descriptor instead.
+func (*Payload) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{1}
+}
+
+func (m *Payload) GetPayload() isPayload_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *Payload) GetTransaction() *Transaction {
+	if x, ok := x.GetPayload().(*Payload_Transaction); ok {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *Payload) GetMilestone() *Milestone {
+	if x, ok := x.GetPayload().(*Payload_Milestone); ok {
+		return x.Milestone
+	}
+	return nil
+}
+
+func (x *Payload) GetIndexation() *Indexation {
+	if x, ok := x.GetPayload().(*Payload_Indexation); ok {
+		return x.Indexation
+	}
+	return nil
+}
+
+func (x *Payload) GetReceipt() *Receipt {
+	if x, ok := x.GetPayload().(*Payload_Receipt); ok {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *Payload) GetTreasuryTransaction() *TreasuryTransaction {
+	if x, ok := x.GetPayload().(*Payload_TreasuryTransaction); ok {
+		return x.TreasuryTransaction
+	}
+	return nil
+}
+
+type isPayload_Payload interface {
+	isPayload_Payload()
+}
+
+type Payload_Transaction struct {
+	Transaction *Transaction `protobuf:"bytes,1,opt,name=transaction,proto3,oneof"`
+}
+
+type Payload_Milestone struct {
+	Milestone *Milestone `protobuf:"bytes,2,opt,name=milestone,proto3,oneof"`
+}
+
+type Payload_Indexation struct {
+	Indexation *Indexation `protobuf:"bytes,3,opt,name=indexation,proto3,oneof"`
+}
+
+type Payload_Receipt struct {
+	Receipt *Receipt `protobuf:"bytes,4,opt,name=receipt,proto3,oneof"`
+}
+
+type Payload_TreasuryTransaction struct {
+	TreasuryTransaction *TreasuryTransaction `protobuf:"bytes,5,opt,name=treasuryTransaction,proto3,oneof"`
+}
+
+func (*Payload_Transaction) isPayload_Payload() {}
+
+func (*Payload_Milestone) isPayload_Payload() {}
+
+func (*Payload_Indexation) isPayload_Payload() {}
+
+func (*Payload_Receipt) isPayload_Payload() {}
+
+func (*Payload_TreasuryTransaction) isPayload_Payload() {}
+
+type Indexation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index []byte `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	Data  []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *Indexation) Reset() {
+	*x = Indexation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Indexation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Indexation) ProtoMessage() {}
+
+func (x *Indexation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Indexation.ProtoReflect.Descriptor instead.
+func (*Indexation) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Indexation) GetIndex() []byte {
+	if x != nil {
+		return x.Index
+	}
+	return nil
+}
+
+func (x *Indexation) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type Transaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Essence      *TransactionEssence `protobuf:"bytes,1,opt,name=essence,proto3" json:"essence,omitempty"`
+	UnlockBlocks []*UnlockBlock      `protobuf:"bytes,2,rep,name=unlockBlocks,proto3" json:"unlockBlocks,omitempty"`
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Transaction) GetEssence() *TransactionEssence {
+	if x != nil {
+		return x.Essence
+	}
+	return nil
+}
+
+func (x *Transaction) GetUnlockBlocks() []*UnlockBlock {
+	if x != nil {
+		return x.UnlockBlocks
+	}
+	return nil
+}
+
+type TransactionEssence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Inputs  []*Input    `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs []*Output   `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	Payload *Indexation `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *TransactionEssence) Reset() {
+	*x = TransactionEssence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionEssence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionEssence) ProtoMessage() {}
+
+func (x *TransactionEssence) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionEssence.ProtoReflect.Descriptor instead.
+func (*TransactionEssence) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TransactionEssence) GetInputs() []*Input {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *TransactionEssence) GetOutputs() []*Output {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *TransactionEssence) GetPayload() *Indexation {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type Input struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Input:
+	//	*Input_Utxo
+	//	*Input_Treasury
+	Input isInput_Input `protobuf_oneof:"input"`
+}
+
+func (x *Input) Reset() {
+	*x = Input{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Input) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Input) ProtoMessage() {}
+
+func (x *Input) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Input.ProtoReflect.Descriptor instead.
+func (*Input) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{5}
+}
+
+func (m *Input) GetInput() isInput_Input {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+func (x *Input) GetUtxo() *UTXOInput {
+	if x, ok := x.GetInput().(*Input_Utxo); ok {
+		return x.Utxo
+	}
+	return nil
+}
+
+func (x *Input) GetTreasury() *TreasuryInput {
+	if x, ok := x.GetInput().(*Input_Treasury); ok {
+		return x.Treasury
+	}
+	return nil
+}
+
+type isInput_Input interface {
+	isInput_Input()
+}
+
+type Input_Utxo struct {
+	Utxo *UTXOInput `protobuf:"bytes,1,opt,name=utxo,proto3,oneof"`
+}
+
+type Input_Treasury struct {
+	Treasury *TreasuryInput `protobuf:"bytes,2,opt,name=treasury,proto3,oneof"`
+}
+
+func (*Input_Utxo) isInput_Input() {}
+
+func (*Input_Treasury) isInput_Input() {}
+
+type UTXOInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionId          []byte `protobuf:"bytes,1,opt,name=transactionId,proto3" json:"transactionId,omitempty"`
+	TransactionOutputIndex uint32 `protobuf:"varint,2,opt,name=transactionOutputIndex,proto3" json:"transactionOutputIndex,omitempty"`
+}
+
+func (x *UTXOInput) Reset() {
+	*x = UTXOInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UTXOInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UTXOInput) ProtoMessage() {}
+
+func (x *UTXOInput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UTXOInput.ProtoReflect.Descriptor instead.
+func (*UTXOInput) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UTXOInput) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+func (x *UTXOInput) GetTransactionOutputIndex() uint32 {
+	if x != nil {
+		return x.TransactionOutputIndex
+	}
+	return 0
+}
+
+type TreasuryInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MilestoneId []byte `protobuf:"bytes,1,opt,name=milestoneId,proto3" json:"milestoneId,omitempty"`
+}
+
+func (x *TreasuryInput) Reset() {
+	*x = TreasuryInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TreasuryInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreasuryInput) ProtoMessage() {}
+
+func (x *TreasuryInput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreasuryInput.ProtoReflect.Descriptor instead.
+func (*TreasuryInput) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TreasuryInput) GetMilestoneId() []byte {
+	if x != nil {
+		return x.MilestoneId
+	}
+	return nil
+}
+
+type Address struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Address:
+	//	*Address_Ed25519
+	Address isAddress_Address `protobuf_oneof:"address"`
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{8}
+}
+
+func (m *Address) GetAddress() isAddress_Address {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (x *Address) GetEd25519() []byte {
+	if x, ok := x.GetAddress().(*Address_Ed25519); ok {
+		return x.Ed25519
+	}
+	return nil
+}
+
+type isAddress_Address interface {
+	isAddress_Address()
+}
+
+type Address_Ed25519 struct {
+	Ed25519 []byte `protobuf:"bytes,1,opt,name=ed25519,proto3,oneof"`
+}
+
+func (*Address_Ed25519) isAddress_Address() {}
+
+type Output struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Output:
+	//	*Output_SigLockedSingle
+	//	*Output_SigLockedDustAllowance
+	//	*Output_Treasury
+	Output isOutput_Output `protobuf_oneof:"output"`
+}
+
+func (x *Output) Reset() {
+	*x = Output{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Output) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Output) ProtoMessage() {}
+
+func (x *Output) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Output.ProtoReflect.Descriptor instead.
+func (*Output) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{9}
+}
+
+func (m *Output) GetOutput() isOutput_Output {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (x *Output) GetSigLockedSingle() *SigLockedSingleOutput {
+	if x, ok := x.GetOutput().(*Output_SigLockedSingle); ok {
+		return x.SigLockedSingle
+	}
+	return nil
+}
+
+func (x *Output) GetSigLockedDustAllowance() *SigLockedDustAllowanceOutput {
+	if x, ok := x.GetOutput().(*Output_SigLockedDustAllowance); ok {
+		return x.SigLockedDustAllowance
+	}
+	return nil
+}
+
+func (x *Output) GetTreasury() *TreasuryOutput {
+	if x, ok := x.GetOutput().(*Output_Treasury); ok {
+		return x.Treasury
+	}
+	return nil
+}
+
+type isOutput_Output interface {
+	isOutput_Output()
+}
+
+type Output_SigLockedSingle struct {
+	SigLockedSingle *SigLockedSingleOutput `protobuf:"bytes,1,opt,name=sigLockedSingle,proto3,oneof"`
+}
+
+type Output_SigLockedDustAllowance struct {
+	SigLockedDustAllowance *SigLockedDustAllowanceOutput `protobuf:"bytes,2,opt,name=sigLockedDustAllowance,proto3,oneof"`
+}
+
+type Output_Treasury struct {
+	Treasury *TreasuryOutput `protobuf:"bytes,3,opt,name=treasury,proto3,oneof"`
+}
+
+func (*Output_SigLockedSingle) isOutput_Output() {}
+
+func (*Output_SigLockedDustAllowance) isOutput_Output() {}
+
+func (*Output_Treasury) isOutput_Output() {}
+
+type SigLockedSingleOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address *Address `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Amount  uint64   `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *SigLockedSingleOutput) Reset() {
+	*x = SigLockedSingleOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SigLockedSingleOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SigLockedSingleOutput) ProtoMessage() {}
+
+func (x *SigLockedSingleOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SigLockedSingleOutput.ProtoReflect.Descriptor instead.
+func (*SigLockedSingleOutput) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SigLockedSingleOutput) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *SigLockedSingleOutput) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type SigLockedDustAllowanceOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address *Address `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Amount  uint64   `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *SigLockedDustAllowanceOutput) Reset() {
+	*x = SigLockedDustAllowanceOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SigLockedDustAllowanceOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SigLockedDustAllowanceOutput) ProtoMessage() {}
+
+func (x *SigLockedDustAllowanceOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SigLockedDustAllowanceOutput.ProtoReflect.Descriptor instead.
+func (*SigLockedDustAllowanceOutput) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SigLockedDustAllowanceOutput) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *SigLockedDustAllowanceOutput) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type TreasuryOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amount uint64 `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *TreasuryOutput) Reset() {
+	*x = TreasuryOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TreasuryOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreasuryOutput) ProtoMessage() {}
+
+func (x *TreasuryOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreasuryOutput.ProtoReflect.Descriptor instead.
+func (*TreasuryOutput) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TreasuryOutput) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type UnlockBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to UnlockBlock:
+	//	*UnlockBlock_Signature
+	//	*UnlockBlock_Reference
+	UnlockBlock isUnlockBlock_UnlockBlock `protobuf_oneof:"unlockBlock"`
+}
+
+func (x *UnlockBlock) Reset() {
+	*x = UnlockBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnlockBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockBlock) ProtoMessage() {}
+
+func (x *UnlockBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockBlock.ProtoReflect.Descriptor instead.
+func (*UnlockBlock) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{13}
+}
+
+func (m *UnlockBlock) GetUnlockBlock() isUnlockBlock_UnlockBlock {
+	if m != nil {
+		return m.UnlockBlock
+	}
+	return nil
+}
+
+func (x *UnlockBlock) GetSignature() *SignatureUnlockBlock {
+	if x, ok := x.GetUnlockBlock().(*UnlockBlock_Signature); ok {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *UnlockBlock) GetReference() *ReferenceUnlockBlock {
+	if x, ok := x.GetUnlockBlock().(*UnlockBlock_Reference); ok {
+		return x.Reference
+	}
+	return nil
+}
+
+type isUnlockBlock_UnlockBlock interface {
+	isUnlockBlock_UnlockBlock()
+}
+
+type UnlockBlock_Signature struct {
+	Signature *SignatureUnlockBlock `protobuf:"bytes,1,opt,name=signature,proto3,oneof"`
+}
+
+type UnlockBlock_Reference struct {
+	Reference *ReferenceUnlockBlock `protobuf:"bytes,2,opt,name=reference,proto3,oneof"`
+}
+
+func (*UnlockBlock_Signature) isUnlockBlock_UnlockBlock() {}
+
+func (*UnlockBlock_Reference) isUnlockBlock_UnlockBlock() {}
+
+type SignatureUnlockBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Signature *Signature `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *SignatureUnlockBlock) Reset() {
+	*x = SignatureUnlockBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignatureUnlockBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignatureUnlockBlock) ProtoMessage() {}
+
+func (x *SignatureUnlockBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignatureUnlockBlock.ProtoReflect.Descriptor instead.
+func (*SignatureUnlockBlock) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SignatureUnlockBlock) GetSignature() *Signature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type ReferenceUnlockBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reference uint32 `protobuf:"varint,1,opt,name=reference,proto3" json:"reference,omitempty"`
+}
+
+func (x *ReferenceUnlockBlock) Reset() {
+	*x = ReferenceUnlockBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReferenceUnlockBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferenceUnlockBlock) ProtoMessage() {}
+
+func (x *ReferenceUnlockBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferenceUnlockBlock.ProtoReflect.Descriptor instead.
+func (*ReferenceUnlockBlock) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReferenceUnlockBlock) GetReference() uint32 {
+	if x != nil {
+		return x.Reference
+	}
+	return 0
+}
+
+type Signature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Signature:
+	//	*Signature_Ed25519
+	Signature isSignature_Signature `protobuf_oneof:"signature"`
+}
+
+func (x *Signature) Reset() {
+	*x = Signature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Signature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Signature) ProtoMessage() {}
+
+func (x *Signature) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Signature.ProtoReflect.Descriptor instead.
+func (*Signature) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{16}
+}
+
+func (m *Signature) GetSignature() isSignature_Signature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (x *Signature) GetEd25519() *Ed25519Signature {
+	if x, ok := x.GetSignature().(*Signature_Ed25519); ok {
+		return x.Ed25519
+	}
+	return nil
+}
+
+type isSignature_Signature interface {
+	isSignature_Signature()
+}
+
+type Signature_Ed25519 struct {
+	Ed25519 *Ed25519Signature `protobuf:"bytes,1,opt,name=ed25519,proto3,oneof"`
+}
+
+func (*Signature_Ed25519) isSignature_Signature() {}
+
+type Ed25519Signature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKey []byte `protobuf:"bytes,1,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *Ed25519Signature) Reset() {
+	*x = Ed25519Signature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ed25519Signature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ed25519Signature) ProtoMessage() {}
+
+func (x *Ed25519Signature) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ed25519Signature.ProtoReflect.Descriptor instead.
+func (*Ed25519Signature) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Ed25519Signature) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *Ed25519Signature) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type Milestone struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index                      uint32   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Timestamp                  uint64   `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Parents                    [][]byte `protobuf:"bytes,3,rep,name=parents,proto3" json:"parents,omitempty"`
+	InclusionMerkleProof       []byte   `protobuf:"bytes,4,opt,name=inclusionMerkleProof,proto3" json:"inclusionMerkleProof,omitempty"`
+	NextPoWScore               uint32   `protobuf:"varint,5,opt,name=nextPoWScore,proto3" json:"nextPoWScore,omitempty"`
+	NextPoWScoreMilestoneIndex uint32   `protobuf:"varint,6,opt,name=nextPoWScoreMilestoneIndex,proto3" json:"nextPoWScoreMilestoneIndex,omitempty"`
+	PublicKeys                 [][]byte `protobuf:"bytes,7,rep,name=publicKeys,proto3" json:"publicKeys,omitempty"`
+	Receipt                    *Receipt `protobuf:"bytes,8,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	Signatures                 [][]byte `protobuf:"bytes,9,rep,name=signatures,proto3" json:"signatures,omitempty"`
+}
+
+func (x *Milestone) Reset() {
+	*x = Milestone{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Milestone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Milestone) ProtoMessage() {}
+
+func (x *Milestone) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Milestone.ProtoReflect.Descriptor instead.
+func (*Milestone) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Milestone) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Milestone) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Milestone) GetParents() [][]byte {
+	if x != nil {
+		return x.Parents
+	}
+	return nil
+}
+
+func (x *Milestone) GetInclusionMerkleProof() []byte {
+	if x != nil {
+		return x.InclusionMerkleProof
+	}
+	return nil
+}
+
+func (x *Milestone) GetNextPoWScore() uint32 {
+	if x != nil {
+		return x.NextPoWScore
+	}
+	return 0
+}
+
+func (x *Milestone) GetNextPoWScoreMilestoneIndex() uint32 {
+	if x != nil {
+		return x.NextPoWScoreMilestoneIndex
+	}
+	return 0
+}
+
+func (x *Milestone) GetPublicKeys() [][]byte {
+	if x != nil {
+		return x.PublicKeys
+	}
+	return nil
+}
+
+func (x *Milestone) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *Milestone) GetSignatures() [][]byte {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+type Receipt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MigratedAt  uint32                `protobuf:"varint,1,opt,name=migratedAt,proto3" json:"migratedAt,omitempty"`
+	Final       bool                  `protobuf:"varint,2,opt,name=final,proto3" json:"final,omitempty"`
+	Funds       []*MigratedFundsEntry `protobuf:"bytes,3,rep,name=funds,proto3" json:"funds,omitempty"`
+	Transaction *TreasuryTransaction  `protobuf:"bytes,4,opt,name=transaction,proto3" json:"transaction,omitempty"`
+}
+
+func (x *Receipt) Reset() {
+	*x = Receipt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Receipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Receipt) ProtoMessage() {}
+
+func (x *Receipt) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Receipt) GetMigratedAt() uint32 {
+	if x != nil {
+		return x.MigratedAt
+	}
+	return 0
+}
+
+func (x *Receipt) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+func (x *Receipt) GetFunds() []*MigratedFundsEntry {
+	if x != nil {
+		return x.Funds
+	}
+	return nil
+}
+
+func (x *Receipt) GetTransaction() *TreasuryTransaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+type MigratedFundsEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TailTransactionHash []byte   `protobuf:"bytes,1,opt,name=tailTransactionHash,proto3" json:"tailTransactionHash,omitempty"`
+	Address             *Address `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Deposit             uint64   `protobuf:"varint,3,opt,name=deposit,proto3" json:"deposit,omitempty"`
+}
+
+func (x *MigratedFundsEntry) Reset() {
+	*x = MigratedFundsEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MigratedFundsEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigratedFundsEntry) ProtoMessage() {}
+
+func (x *MigratedFundsEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigratedFundsEntry.ProtoReflect.Descriptor instead.
+func (*MigratedFundsEntry) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MigratedFundsEntry) GetTailTransactionHash() []byte {
+	if x != nil {
+		return x.TailTransactionHash
+	}
+	return nil
+}
+
+func (x *MigratedFundsEntry) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *MigratedFundsEntry) GetDeposit() uint64 {
+	if x != nil {
+		return x.Deposit
+	}
+	return 0
+}
+
+type TreasuryTransaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Input  *TreasuryInput  `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	Output *TreasuryOutput `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+}
+
+func (x *TreasuryTransaction) Reset() {
+	*x = TreasuryTransaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_iotago_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TreasuryTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreasuryTransaction) ProtoMessage() {}
+
+func (x *TreasuryTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_iotago_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreasuryTransaction.ProtoReflect.Descriptor instead.
+func (*TreasuryTransaction) Descriptor() ([]byte, []int) {
+	return file_proto_iotago_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TreasuryTransaction) GetInput() *TreasuryInput {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *TreasuryTransaction) GetOutput() *TreasuryOutput {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+var File_proto_iotago_proto protoreflect.FileDescriptor
+
+var file_proto_iotago_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x22, 0x82, 0x01, 0x0a,
+	0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x29, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x50, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6e,
+	0x6f, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63,
+	0x65, 0x22, 0xb4, 0x02, 0x0a, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x37, 0x0a,
+	0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x09, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74,
+	0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6f, 0x74, 0x61,
+	0x67, 0x6f, 0x2e, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x09,
+	0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x00, 0x52, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x2b, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70,
+	0x74, 0x48, 0x00, 0x52, 0x07, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x4f, 0x0a, 0x13,
+	0x74, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6f, 0x74, 0x61,
+	0x67, 0x6f, 0x2e, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x13, 0x74, 0x72, 0x65, 0x61, 0x73, 0x75,
+	0x72, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x09, 0x0a,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x36, 0x0a, 0x0a, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x7c, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x34, 0x0a, 0x07, 0x65, 0x73, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x73, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x07, 0x65, 0x73,
+	0x73, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x0c, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x6f,
+	0x74, 0x61, 0x67, 0x6f, 0x2e, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x52, 0x0c, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0x93,
+	0x01, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x73,
+	0x73, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x49,
+	0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x07,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f,
+	0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x22, 0x6e, 0x0a, 0x05, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x27, 0x0a,
+	0x04, 0x75, 0x74, 0x78, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6f,
+	0x74, 0x61, 0x67, 0x6f, 0x2e, 0x55, 0x54, 0x58, 0x4f, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x48, 0x00,
+	0x52, 0x04, 0x75, 0x74, 0x78, 0x6f, 0x12, 0x33, 0x0a, 0x08, 0x74, 0x72, 0x65, 0x61, 0x73, 0x75,
+	0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67,
+	0x6f, 0x2e, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x48,
+	0x00, 0x52, 0x08, 0x74, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x22, 0x69, 0x0a, 0x09, 0x55, 0x54, 0x58, 0x4f, 0x49, 0x6e, 0x70, 0x75,
+	0x74, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x16, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22,
+	0x31, 0x0a, 0x0d, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65,
+	0x49, 0x64, 0x22, 0x30, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a,
+	0x07, 0x65, 0x64, 0x32, 0x35, 0x35, 0x31, 0x39, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00,
+	0x52, 0x07, 0x65, 0x64, 0x32, 0x35, 0x35, 0x31, 0x39, 0x42, 0x09, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x22, 0xf3, 0x01, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12,
+	0x49, 0x0a, 0x0f, 0x73, 0x69, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x67,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67,
+	0x6f, 0x2e, 0x53, 0x69, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x67, 0x6c,
+	0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x69, 0x67, 0x4c, 0x6f,
+	0x63, 0x6b, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x12, 0x5e, 0x0a, 0x16, 0x73, 0x69,
+	0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x44, 0x75, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6f, 0x74,
+	0x61, 0x67, 0x6f, 0x2e, 0x53, 0x69, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x44, 0x75, 0x73,
+	0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x61, 0x6e, 0x63, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x48, 0x00, 0x52, 0x16, 0x73, 0x69, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x44, 0x75, 0x73,
+	0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x34, 0x0a, 0x08, 0x74, 0x72,
+	0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x69,
+	0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x08, 0x74, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79,
+	0x42, 0x08, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x5a, 0x0a, 0x15, 0x53, 0x69,
+	0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x12, 0x29, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x61, 0x0a, 0x1c, 0x53, 0x69, 0x67, 0x4c, 0x6f, 0x63,
+	0x6b, 0x65, 0x64, 0x44, 0x75, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x61, 0x6e, 0x63, 0x65,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x29, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f,
+	0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x28, 0x0a, 0x0e, 0x54, 0x72, 0x65,
+	0x61, 0x73, 0x75, 0x72, 0x79, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x98, 0x01, 0x0a, 0x0b, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x3c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x12, 0x3c, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x42,
+	0x0d, 0x0a, 0x0b, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x47,
+	0x0a, 0x14, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63,
+	0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x2f, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6f, 0x74, 0x61,
+	0x67, 0x6f, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x09, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x34, 0x0a, 0x14, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
+	0x1c, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x4e, 0x0a,
+	0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x65, 0x64,
+	0x32, 0x35, 0x35, 0x31, 0x39, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x69, 0x6f,
+	0x74, 0x61, 0x67, 0x6f, 0x2e, 0x45, 0x64, 0x32, 0x35, 0x35, 0x31, 0x39, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x07, 0x65, 0x64, 0x32, 0x35, 0x35, 0x31, 0x39,
+	0x42, 0x0b, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x4e, 0x0a,
+	0x10, 0x45, 0x64, 0x32, 0x35, 0x35, 0x31, 0x39, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xdc, 0x02,
+	0x0a, 0x09, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x14, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x14, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69,
+	0x6f, 0x6e, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x22, 0x0a,
+	0x0c, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x6f, 0x57, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x6f, 0x57, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x3e, 0x0a, 0x1a, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x6f, 0x57, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1a, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x6f, 0x57, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
+	0x73, 0x12, 0x29, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x70, 0x74, 0x52, 0x07, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0xb0, 0x01, 0x0a,
+	0x07, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x69, 0x67, 0x72,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x69,
+	0x67, 0x72, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6e, 0x61,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x12, 0x30,
+	0x0a, 0x05, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x64, 0x46,
+	0x75, 0x6e, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x66, 0x75, 0x6e, 0x64, 0x73,
+	0x12, 0x3d, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x54,
+	0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x8b, 0x01, 0x0a, 0x12, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x64, 0x46, 0x75, 0x6e, 0x64,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x30, 0x0a, 0x13, 0x74, 0x61, 0x69, 0x6c, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x13, 0x74, 0x61, 0x69, 0x6c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x29, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x6f, 0x74, 0x61,
+	0x67, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x22, 0x72, 0x0a,
+	0x13, 0x54, 0x72, 0x65, 0x61, 0x73, 0x75, 0x72, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x54, 0x72, 0x65,
+	0x61, 0x73, 0x75, 0x72, 0x79, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x12, 0x2e, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x2e, 0x54, 0x72, 0x65, 0x61, 0x73,
+	0x75, 0x72, 0x79, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x69, 0x6f, 0x74, 0x61, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2f, 0x69, 0x6f, 0x74, 0x61, 0x2e,
+	0x67, 0x6f, 0x2f, 0x76, 0x32, 0x2f, 0x69, 0x6f, 0x74, 0x61, 0x67, 0x6f, 0x70, 0x62, 0x3b, 0x69,
+	0x6f, 0x74, 0x61, 0x67, 0x6f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_iotago_proto_rawDescOnce sync.Once
+	file_proto_iotago_proto_rawDescData = file_proto_iotago_proto_rawDesc
+)
+
+func file_proto_iotago_proto_rawDescGZIP() []byte {
+	file_proto_iotago_proto_rawDescOnce.Do(func() {
+		file_proto_iotago_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_iotago_proto_rawDescData)
+	})
+	return file_proto_iotago_proto_rawDescData
+}
+
+var file_proto_iotago_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_proto_iotago_proto_goTypes = []interface{}{
+	(*Message)(nil),                      // 0: iotago.Message
+	(*Payload)(nil),                      // 1: iotago.Payload
+	(*Indexation)(nil),                   // 2: iotago.Indexation
+	(*Transaction)(nil),                  // 3: iotago.Transaction
+	(*TransactionEssence)(nil),           // 4: iotago.TransactionEssence
+	(*Input)(nil),                        // 5: iotago.Input
+	(*UTXOInput)(nil),                    // 6: iotago.UTXOInput
+	(*TreasuryInput)(nil),                // 7: iotago.TreasuryInput
+	(*Address)(nil),                      // 8: iotago.Address
+	(*Output)(nil),                       // 9: iotago.Output
+	(*SigLockedSingleOutput)(nil),        // 10: iotago.SigLockedSingleOutput
+	(*SigLockedDustAllowanceOutput)(nil), // 11: iotago.SigLockedDustAllowanceOutput
+	(*TreasuryOutput)(nil),               // 12: iotago.TreasuryOutput
+	(*UnlockBlock)(nil),                  // 13: iotago.UnlockBlock
+	(*SignatureUnlockBlock)(nil),         // 14: iotago.SignatureUnlockBlock
+	(*ReferenceUnlockBlock)(nil),         // 15: iotago.ReferenceUnlockBlock
+	(*Signature)(nil),                    // 16: iotago.Signature
+	(*Ed25519Signature)(nil),             // 17: iotago.Ed25519Signature
+	(*Milestone)(nil),                    // 18: iotago.Milestone
+	(*Receipt)(nil),                      // 19: iotago.Receipt
+	(*MigratedFundsEntry)(nil),           // 20: iotago.MigratedFundsEntry
+	(*TreasuryTransaction)(nil),          // 21: iotago.TreasuryTransaction
+}
+var file_proto_iotago_proto_depIdxs = []int32{
+	1,  // 0: iotago.Message.payload:type_name -> iotago.Payload
+	3,  // 1: iotago.Payload.transaction:type_name -> iotago.Transaction
+	18, // 2: iotago.Payload.milestone:type_name -> iotago.Milestone
+	2,  // 3: iotago.Payload.indexation:type_name -> iotago.Indexation
+	19, // 4: iotago.Payload.receipt:type_name -> iotago.Receipt
+	21, // 5: iotago.Payload.treasuryTransaction:type_name -> iotago.TreasuryTransaction
+	4,  // 6: iotago.Transaction.essence:type_name -> iotago.TransactionEssence
+	13, // 7: iotago.Transaction.unlockBlocks:type_name -> iotago.UnlockBlock
+	5,  // 8: iotago.TransactionEssence.inputs:type_name -> iotago.Input
+	9,  // 9: iotago.TransactionEssence.outputs:type_name -> iotago.Output
+	2,  // 10: iotago.TransactionEssence.payload:type_name -> iotago.Indexation
+	6,  // 11: iotago.Input.utxo:type_name -> iotago.UTXOInput
+	7,  // 12: iotago.Input.treasury:type_name -> iotago.TreasuryInput
+	10, // 13: iotago.Output.sigLockedSingle:type_name -> iotago.SigLockedSingleOutput
+	11, // 14: iotago.Output.sigLockedDustAllowance:type_name -> iotago.SigLockedDustAllowanceOutput
+	12, // 15: iotago.Output.treasury:type_name -> iotago.TreasuryOutput
+	8,  // 16: iotago.SigLockedSingleOutput.address:type_name -> iotago.Address
+	8,  // 17: iotago.SigLockedDustAllowanceOutput.address:type_name -> iotago.Address
+	14, // 18: iotago.UnlockBlock.signature:type_name -> iotago.SignatureUnlockBlock
+	15, // 19: iotago.UnlockBlock.reference:type_name -> iotago.ReferenceUnlockBlock
+	16, // 20: iotago.SignatureUnlockBlock.signature:type_name -> iotago.Signature
+	17, // 21: iotago.Signature.ed25519:type_name -> iotago.Ed25519Signature
+	19, // 22: iotago.Milestone.receipt:type_name -> iotago.Receipt
+	20, // 23: iotago.Receipt.funds:type_name -> iotago.MigratedFundsEntry
+	21, // 24: iotago.Receipt.transaction:type_name -> iotago.TreasuryTransaction
+	8,  // 25: iotago.MigratedFundsEntry.address:type_name -> iotago.Address
+	7,  // 26: iotago.TreasuryTransaction.input:type_name -> iotago.TreasuryInput
+	12, // 27: iotago.TreasuryTransaction.output:type_name -> iotago.TreasuryOutput
+	28, // [28:28] is the sub-list for method output_type
+	28, // [28:28] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_proto_iotago_proto_init() }
+func file_proto_iotago_proto_init() {
+	if File_proto_iotago_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_iotago_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Payload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Indexation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Transaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionEssence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Input); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UTXOInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TreasuryInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Address); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Output); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SigLockedSingleOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SigLockedDustAllowanceOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TreasuryOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnlockBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignatureUnlockBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReferenceUnlockBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Signature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ed25519Signature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Milestone); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Receipt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MigratedFundsEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_iotago_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TreasuryTransaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_proto_iotago_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*Payload_Transaction)(nil),
+		(*Payload_Milestone)(nil),
+		(*Payload_Indexation)(nil),
+		(*Payload_Receipt)(nil),
+		(*Payload_TreasuryTransaction)(nil),
+	}
+	file_proto_iotago_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*Input_Utxo)(nil),
+		(*Input_Treasury)(nil),
+	}
+	file_proto_iotago_proto_msgTypes[8].OneofWrappers = []interface{}{
+		(*Address_Ed25519)(nil),
+	}
+	file_proto_iotago_proto_msgTypes[9].OneofWrappers = []interface{}{
+		(*Output_SigLockedSingle)(nil),
+		(*Output_SigLockedDustAllowance)(nil),
+		(*Output_Treasury)(nil),
+	}
+	file_proto_iotago_proto_msgTypes[13].OneofWrappers = []interface{}{
+		(*UnlockBlock_Signature)(nil),
+		(*UnlockBlock_Reference)(nil),
+	}
+	file_proto_iotago_proto_msgTypes[16].OneofWrappers = []interface{}{
+		(*Signature_Ed25519)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_iotago_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_iotago_proto_goTypes,
+		DependencyIndexes: file_proto_iotago_proto_depIdxs,
+		MessageInfos:      file_proto_iotago_proto_msgTypes,
+	}.Build()
+	File_proto_iotago_proto = out.File
+	file_proto_iotago_proto_rawDesc = nil
+	file_proto_iotago_proto_goTypes = nil
+	file_proto_iotago_proto_depIdxs = nil
+}
@@ -0,0 +1,179 @@
+package iotagopb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"google.golang.org/protobuf/proto"
+
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+var (
+	// ErrMissingField gets returned when a required field of a protobuf message is not set.
+	ErrMissingField = errors.New("missing field")
+
+	// options producing a canonical encoding.
+	canonicalMarshalOptions = proto.MarshalOptions{Deterministic: true}
+)
+
+// Marshal encodes the given protobuf message deterministically,
+// meaning that the same message always results in the same bytes.
+func Marshal(m proto.Message) ([]byte, error) {
+	return canonicalMarshalOptions.Marshal(m)
+}
+
+// Unmarshal decodes the given bytes into the given protobuf message.
+func Unmarshal(data []byte, m proto.Message) error {
+	return proto.Unmarshal(data, m)
+}
+
+// MarshalMessage converts the given iotago.Message to its protobuf form and encodes it canonically.
+func MarshalMessage(msg *iotago.Message) ([]byte, error) {
+	pbMsg, err := MessageToProto(msg)
+	if err != nil {
+		return nil, err
+	}
+	return Marshal(pbMsg)
+}
+
+// UnmarshalMessage decodes the given protobuf encoded bytes into an iotago.Message.
+func UnmarshalMessage(data []byte) (*iotago.Message, error) {
+	pbMsg := &Message{}
+	if err := Unmarshal(data, pbMsg); err != nil {
+		return nil, err
+	}
+	return MessageFromProto(pbMsg)
+}
+
+// MessageToProto converts the given iotago.Message to its protobuf form.
+func MessageToProto(msg *iotago.Message) (*Message, error) {
+	pbMsg := &Message{
+		NetworkId: msg.NetworkID,
+		Parents:   messageIDsToProto(msg.Parents),
+		Nonce:     msg.Nonce,
+	}
+	if msg.Payload != nil {
+		payload, err := PayloadToProto(msg.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert message payload: %w", err)
+		}
+		pbMsg.Payload = payload
+	}
+	return pbMsg, nil
+}
+
+// MessageFromProto converts the given protobuf Message to an iotago.Message.
+func MessageFromProto(pbMsg *Message) (*iotago.Message, error) {
+	parents, err := messageIDsFromProto(pbMsg.GetParents())
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert message parents: %w", err)
+	}
+	msg := &iotago.Message{
+		NetworkID: pbMsg.GetNetworkId(),
+		Parents:   parents,
+		Nonce:     pbMsg.GetNonce(),
+	}
+	if pbMsg.GetPayload() != nil {
+		if msg.Payload, err = PayloadFromProto(pbMsg.GetPayload()); err != nil {
+			return nil, fmt.Errorf("unable to convert message payload: %w", err)
+		}
+	}
+	return msg, nil
+}
+
+// PayloadToProto converts the given payload to its protobuf form.
+func PayloadToProto(payload serializer.Serializable) (*Payload, error) {
+	switch p := payload.(type) {
+	case *iotago.Transaction:
+		tx, err := TransactionToProto(p)
+		if err != nil {
+			return nil, err
+		}
+		return &Payload{Payload: &Payload_Transaction{Transaction: tx}}, nil
+	case *iotago.Milestone:
+		ms, err := MilestoneToProto(p)
+		if err != nil {
+			return nil, err
+		}
+		return &Payload{Payload: &Payload_Milestone{Milestone: ms}}, nil
+	case *iotago.Indexation:
+		return &Payload{Payload: &Payload_Indexation{Indexation: IndexationToProto(p)}}, nil
+	case *iotago.Receipt:
+		receipt, err := ReceiptToProto(p)
+		if err != nil {
+			return nil, err
+		}
+		return &Payload{Payload: &Payload_Receipt{Receipt: receipt}}, nil
+	case *iotago.TreasuryTransaction:
+		treasuryTx, err := TreasuryTransactionToProto(p)
+		if err != nil {
+			return nil, err
+		}
+		return &Payload{Payload: &Payload_TreasuryTransaction{TreasuryTransaction: treasuryTx}}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", iotago.ErrUnknownPayloadType, payload)
+	}
+}
+
+// PayloadFromProto converts the given protobuf Payload to its iotago counterpart.
+func PayloadFromProto(pbPayload *Payload) (serializer.Serializable, error) {
+	switch p := pbPayload.GetPayload().(type) {
+	case *Payload_Transaction:
+		return TransactionFromProto(p.Transaction)
+	case *Payload_Milestone:
+		return MilestoneFromProto(p.Milestone)
+	case *Payload_Indexation:
+		return IndexationFromProto(p.Indexation), nil
+	case *Payload_Receipt:
+		return ReceiptFromProto(p.Receipt)
+	case *Payload_TreasuryTransaction:
+		return TreasuryTransactionFromProto(p.TreasuryTransaction)
+	default:
+		return nil, fmt.Errorf("%w: %T", iotago.ErrUnknownPayloadType, p)
+	}
+}
+
+// IndexationToProto converts the given iotago.Indexation to its protobuf form.
+func IndexationToProto(indexation *iotago.Indexation) *Indexation {
+	return &Indexation{Index: indexation.Index, Data: indexation.Data}
+}
+
+// IndexationFromProto converts the given protobuf Indexation to an iotago.Indexation.
+func IndexationFromProto(pbIndexation *Indexation) *iotago.Indexation {
+	return &iotago.Indexation{Index: pbIndexation.GetIndex(), Data: pbIndexation.GetData()}
+}
+
+func messageIDsToProto(ids iotago.MessageIDs) [][]byte {
+	if ids == nil {
+		return nil
+	}
+	b := make([][]byte, len(ids))
+	for i := range ids {
+		b[i] = append([]byte{}, ids[i][:]...)
+	}
+	return b
+}
+
+func messageIDsFromProto(b [][]byte) (iotago.MessageIDs, error) {
+	if b == nil {
+		return nil, nil
+	}
+	ids := make(iotago.MessageIDs, len(b))
+	for i := range b {
+		if err := copyExact(ids[i][:], b[i]); err != nil {
+			return nil, fmt.Errorf("message ID at pos %d: %w", i, err)
+		}
+	}
+	return ids, nil
+}
+
+// copies src into dst if both have the same length.
+func copyExact(dst []byte, src []byte) error {
+	if err := serializer.CheckExactByteLength(len(dst), len(src)); err != nil {
+		return err
+	}
+	copy(dst, src)
+	return nil
+}
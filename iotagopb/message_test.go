@@ -0,0 +1,144 @@
+package iotagopb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/iotagopb"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+func TestMessage_RoundTrip(t *testing.T) {
+	type test struct {
+		name   string
+		source *iotago.Message
+	}
+
+	tests := []test{
+		func() test {
+			msg, _ := tpkg.RandMessage(1337)
+			return test{"no payload", msg}
+		}(),
+		func() test {
+			msg, _ := tpkg.RandMessage(iotago.TransactionPayloadTypeID)
+			return test{"transaction", msg}
+		}(),
+		func() test {
+			msg, _ := tpkg.RandMessage(iotago.MilestonePayloadTypeID)
+			return test{"milestone", msg}
+		}(),
+		func() test {
+			msg, _ := tpkg.RandMessage(iotago.IndexationPayloadTypeID)
+			return test{"indexation", msg}
+		}(),
+		func() test {
+			receipt, _ := tpkg.RandReceipt()
+			ms, _ := tpkg.RandMilestone(tpkg.SortedRand32BytArray(2))
+			ms.Receipt = receipt
+			return test{"milestone with receipt", &iotago.Message{NetworkID: 1, Parents: tpkg.SortedRand32BytArray(1), Payload: ms}}
+		}(),
+		func() test {
+			tx := tpkg.OneInputOutputTransaction()
+			essence := tx.Essence.(*iotago.TransactionEssence)
+			essence.Payload, _ = tpkg.RandIndexation()
+			dustAddr, _ := tpkg.RandEd25519Address()
+			essence.Outputs = append(essence.Outputs, &iotago.SigLockedDustAllowanceOutput{
+				Address: dustAddr, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit,
+			})
+			tx.UnlockBlocks = append(tx.UnlockBlocks, &iotago.ReferenceUnlockBlock{Reference: 0})
+			return test{"transaction with indexation, dust output and reference", &iotago.Message{NetworkID: 1, Parents: tpkg.SortedRand32BytArray(1), Payload: tx}}
+		}(),
+		func() test {
+			treasuryTx, _ := tpkg.RandTreasuryTransaction()
+			return test{"treasury transaction", &iotago.Message{NetworkID: 1, Parents: tpkg.SortedRand32BytArray(1), Payload: treasuryTx}}
+		}(),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, err := tt.source.Serialize(serializer.DeSeriModeNoValidation)
+			require.NoError(t, err)
+
+			data, err := iotagopb.MarshalMessage(tt.source)
+			require.NoError(t, err)
+
+			// the encoding must be canonical
+			data2, err := iotagopb.MarshalMessage(tt.source)
+			require.NoError(t, err)
+			assert.Equal(t, data, data2)
+
+			msg, err := iotagopb.UnmarshalMessage(data)
+			require.NoError(t, err)
+
+			actual, err := msg.Serialize(serializer.DeSeriModeNoValidation)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+			assert.Equal(t, tt.source.MustID(), msg.MustID())
+		})
+	}
+}
+
+func TestMessageFromProto_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *iotagopb.Message
+		err  error
+	}{
+		{
+			name: "invalid parent length",
+			msg:  &iotagopb.Message{Parents: [][]byte{make([]byte, 31)}},
+			err:  serializer.ErrInvalidBytes,
+		},
+		{
+			name: "empty payload",
+			msg:  &iotagopb.Message{Payload: &iotagopb.Payload{}},
+			err:  iotago.ErrUnknownPayloadType,
+		},
+		{
+			name: "transaction without essence",
+			msg:  &iotagopb.Message{Payload: &iotagopb.Payload{Payload: &iotagopb.Payload_Transaction{Transaction: &iotagopb.Transaction{}}}},
+			err:  iotagopb.ErrMissingField,
+		},
+		{
+			name: "output without address",
+			msg: &iotagopb.Message{Payload: &iotagopb.Payload{Payload: &iotagopb.Payload_Transaction{Transaction: &iotagopb.Transaction{
+				Essence: &iotagopb.TransactionEssence{Outputs: []*iotagopb.Output{{Output: &iotagopb.Output_SigLockedSingle{SigLockedSingle: &iotagopb.SigLockedSingleOutput{Amount: 1}}}}},
+			}}}},
+			err: iotagopb.ErrMissingField,
+		},
+		{
+			name: "unknown unlock block",
+			msg: &iotagopb.Message{Payload: &iotagopb.Payload{Payload: &iotagopb.Payload_Transaction{Transaction: &iotagopb.Transaction{
+				Essence:      &iotagopb.TransactionEssence{},
+				UnlockBlocks: []*iotagopb.UnlockBlock{{}},
+			}}}},
+			err: iotago.ErrUnknownUnlockBlockType,
+		},
+		{
+			name: "reference exceeding uint16",
+			msg: &iotagopb.Message{Payload: &iotagopb.Payload{Payload: &iotagopb.Payload_Transaction{Transaction: &iotagopb.Transaction{
+				Essence:      &iotagopb.TransactionEssence{},
+				UnlockBlocks: []*iotagopb.UnlockBlock{{UnlockBlock: &iotagopb.UnlockBlock_Reference{Reference: &iotagopb.ReferenceUnlockBlock{Reference: 1 << 16}}}},
+			}}}},
+			err: serializer.ErrDeserializationLengthInvalid,
+		},
+		{
+			name: "invalid merkle proof length",
+			msg:  &iotagopb.Message{Payload: &iotagopb.Payload{Payload: &iotagopb.Payload_Milestone{Milestone: &iotagopb.Milestone{}}}},
+			err:  serializer.ErrInvalidBytes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := iotagopb.MessageFromProto(tt.msg)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.err), err)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package iotagopb
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/hive.go/serializer"
+
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+// MilestoneToProto converts the given iotago.Milestone to its protobuf form.
+func MilestoneToProto(ms *iotago.Milestone) (*Milestone, error) {
+	pbMs := &Milestone{
+		Index:                      ms.Index,
+		Timestamp:                  ms.Timestamp,
+		Parents:                    messageIDsToProto(ms.Parents),
+		InclusionMerkleProof:       append([]byte{}, ms.InclusionMerkleProof[:]...),
+		NextPoWScore:               ms.NextPoWScore,
+		NextPoWScoreMilestoneIndex: ms.NextPoWScoreMilestoneIndex,
+		PublicKeys:                 make([][]byte, len(ms.PublicKeys)),
+		Signatures:                 make([][]byte, len(ms.Signatures)),
+	}
+	for i := range ms.PublicKeys {
+		pbMs.PublicKeys[i] = append([]byte{}, ms.PublicKeys[i][:]...)
+	}
+	for i := range ms.Signatures {
+		pbMs.Signatures[i] = append([]byte{}, ms.Signatures[i][:]...)
+	}
+	if ms.Receipt != nil {
+		receipt, ok := ms.Receipt.(*iotago.Receipt)
+		if !ok {
+			return nil, fmt.Errorf("%w: milestone receipt %T", iotago.ErrUnsupportedPayloadType, ms.Receipt)
+		}
+		pbReceipt, err := ReceiptToProto(receipt)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert milestone receipt: %w", err)
+		}
+		pbMs.Receipt = pbReceipt
+	}
+	return pbMs, nil
+}
+
+// MilestoneFromProto converts the given protobuf Milestone to an iotago.Milestone.
+func MilestoneFromProto(pbMs *Milestone) (*iotago.Milestone, error) {
+	parents, err := messageIDsFromProto(pbMs.GetParents())
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert milestone parents: %w", err)
+	}
+	ms := &iotago.Milestone{
+		Index:                      pbMs.GetIndex(),
+		Timestamp:                  pbMs.GetTimestamp(),
+		Parents:                    parents,
+		NextPoWScore:               pbMs.GetNextPoWScore(),
+		NextPoWScoreMilestoneIndex: pbMs.GetNextPoWScoreMilestoneIndex(),
+		PublicKeys:                 make([]iotago.MilestonePublicKey, len(pbMs.GetPublicKeys())),
+		Signatures:                 make([]iotago.MilestoneSignature, len(pbMs.GetSignatures())),
+	}
+	if err := copyExact(ms.InclusionMerkleProof[:], pbMs.GetInclusionMerkleProof()); err != nil {
+		return nil, fmt.Errorf("invalid inclusion merkle proof: %w", err)
+	}
+	for i, pubKey := range pbMs.GetPublicKeys() {
+		if err := copyExact(ms.PublicKeys[i][:], pubKey); err != nil {
+			return nil, fmt.Errorf("invalid public key at pos %d: %w", i, err)
+		}
+	}
+	for i, sig := range pbMs.GetSignatures() {
+		if err := copyExact(ms.Signatures[i][:], sig); err != nil {
+			return nil, fmt.Errorf("invalid signature at pos %d: %w", i, err)
+		}
+	}
+	if pbMs.GetReceipt() != nil {
+		if ms.Receipt, err = ReceiptFromProto(pbMs.GetReceipt()); err != nil {
+			return nil, fmt.Errorf("unable to convert milestone receipt: %w", err)
+		}
+	}
+	return ms, nil
+}
+
+// ReceiptToProto converts the given iotago.Receipt to its protobuf form.
+func ReceiptToProto(receipt *iotago.Receipt) (*Receipt, error) {
+	pbReceipt := &Receipt{
+		MigratedAt: receipt.MigratedAt,
+		Final:      receipt.Final,
+		Funds:      make([]*MigratedFundsEntry, len(receipt.Funds)),
+	}
+	for i, fund := range receipt.Funds {
+		entry, ok := fund.(*iotago.MigratedFundsEntry)
+		if !ok {
+			return nil, fmt.Errorf("%w: receipt funds entry %T", iotago.ErrUnsupportedObjectType, fund)
+		}
+		pbEntry, err := MigratedFundsEntryToProto(entry)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert migrated funds entry at pos %d: %w", i, err)
+		}
+		pbReceipt.Funds[i] = pbEntry
+	}
+	if receipt.Transaction != nil {
+		treasuryTx, ok := receipt.Transaction.(*iotago.TreasuryTransaction)
+		if !ok {
+			return nil, fmt.Errorf("%w: receipt transaction %T", iotago.ErrUnsupportedPayloadType, receipt.Transaction)
+		}
+		pbTreasuryTx, err := TreasuryTransactionToProto(treasuryTx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert receipt transaction: %w", err)
+		}
+		pbReceipt.Transaction = pbTreasuryTx
+	}
+	return pbReceipt, nil
+}
+
+// ReceiptFromProto converts the given protobuf Receipt to an iotago.Receipt.
+func ReceiptFromProto(pbReceipt *Receipt) (*iotago.Receipt, error) {
+	receipt := &iotago.Receipt{
+		MigratedAt: pbReceipt.GetMigratedAt(),
+		Final:      pbReceipt.GetFinal(),
+		Funds:      make(serializer.Serializables, len(pbReceipt.GetFunds())),
+	}
+	for i, pbEntry := range pbReceipt.GetFunds() {
+		entry, err := MigratedFundsEntryFromProto(pbEntry)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert migrated funds entry at pos %d: %w", i, err)
+		}
+		receipt.Funds[i] = entry
+	}
+	if pbReceipt.GetTransaction() != nil {
+		treasuryTx, err := TreasuryTransactionFromProto(pbReceipt.GetTransaction())
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert receipt transaction: %w", err)
+		}
+		receipt.Transaction = treasuryTx
+	}
+	return receipt, nil
+}
+
+// MigratedFundsEntryToProto converts the given iotago.MigratedFundsEntry to its protobuf form.
+func MigratedFundsEntryToProto(entry *iotago.MigratedFundsEntry) (*MigratedFundsEntry, error) {
+	addr, err := AddressToProto(entry.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &MigratedFundsEntry{
+		TailTransactionHash: append([]byte{}, entry.TailTransactionHash[:]...),
+		Address:             addr,
+		Deposit:             entry.Deposit,
+	}, nil
+}
+
+// MigratedFundsEntryFromProto converts the given protobuf MigratedFundsEntry to an iotago.MigratedFundsEntry.
+func MigratedFundsEntryFromProto(pbEntry *MigratedFundsEntry) (*iotago.MigratedFundsEntry, error) {
+	addr, err := addressFromProtoField(pbEntry.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+	entry := &iotago.MigratedFundsEntry{Address: addr, Deposit: pbEntry.GetDeposit()}
+	if err := copyExact(entry.TailTransactionHash[:], pbEntry.GetTailTransactionHash()); err != nil {
+		return nil, fmt.Errorf("invalid tail transaction hash: %w", err)
+	}
+	return entry, nil
+}
+
+// TreasuryTransactionToProto converts the given iotago.TreasuryTransaction to its protobuf form.
+func TreasuryTransactionToProto(treasuryTx *iotago.TreasuryTransaction) (*TreasuryTransaction, error) {
+	input, ok := treasuryTx.Input.(*iotago.TreasuryInput)
+	if !ok {
+		return nil, fmt.Errorf("%w: treasury transaction input %T", iotago.ErrUnknownInputType, treasuryTx.Input)
+	}
+	output, ok := treasuryTx.Output.(*iotago.TreasuryOutput)
+	if !ok {
+		return nil, fmt.Errorf("%w: treasury transaction output %T", iotago.ErrUnknownOutputType, treasuryTx.Output)
+	}
+	return &TreasuryTransaction{Input: TreasuryInputToProto(input), Output: TreasuryOutputToProto(output)}, nil
+}
+
+// TreasuryTransactionFromProto converts the given protobuf TreasuryTransaction to an iotago.TreasuryTransaction.
+func TreasuryTransactionFromProto(pbTreasuryTx *TreasuryTransaction) (*iotago.TreasuryTransaction, error) {
+	if pbTreasuryTx.GetInput() == nil {
+		return nil, fmt.Errorf("%w: treasury transaction input", ErrMissingField)
+	}
+	if pbTreasuryTx.GetOutput() == nil {
+		return nil, fmt.Errorf("%w: treasury transaction output", ErrMissingField)
+	}
+	input, err := TreasuryInputFromProto(pbTreasuryTx.GetInput())
+	if err != nil {
+		return nil, err
+	}
+	return &iotago.TreasuryTransaction{Input: input, Output: TreasuryOutputFromProto(pbTreasuryTx.GetOutput())}, nil
+}
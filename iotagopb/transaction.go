@@ -0,0 +1,299 @@
+package iotagopb
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/iotaledger/hive.go/serializer"
+
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+// TransactionToProto converts the given iotago.Transaction to its protobuf form.
+func TransactionToProto(tx *iotago.Transaction) (*Transaction, error) {
+	essence, ok := tx.Essence.(*iotago.TransactionEssence)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", iotago.ErrUnknownTransactionEssenceType, tx.Essence)
+	}
+	pbEssence, err := TransactionEssenceToProto(essence)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert transaction essence: %w", err)
+	}
+	pbTx := &Transaction{Essence: pbEssence, UnlockBlocks: make([]*UnlockBlock, len(tx.UnlockBlocks))}
+	for i, unlockBlock := range tx.UnlockBlocks {
+		if pbTx.UnlockBlocks[i], err = UnlockBlockToProto(unlockBlock); err != nil {
+			return nil, fmt.Errorf("unable to convert unlock block at pos %d: %w", i, err)
+		}
+	}
+	return pbTx, nil
+}
+
+// TransactionFromProto converts the given protobuf Transaction to an iotago.Transaction.
+func TransactionFromProto(pbTx *Transaction) (*iotago.Transaction, error) {
+	if pbTx.GetEssence() == nil {
+		return nil, fmt.Errorf("%w: transaction essence", ErrMissingField)
+	}
+	essence, err := TransactionEssenceFromProto(pbTx.GetEssence())
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert transaction essence: %w", err)
+	}
+	tx := &iotago.Transaction{Essence: essence, UnlockBlocks: make(serializer.Serializables, len(pbTx.GetUnlockBlocks()))}
+	for i, pbUnlockBlock := range pbTx.GetUnlockBlocks() {
+		if tx.UnlockBlocks[i], err = UnlockBlockFromProto(pbUnlockBlock); err != nil {
+			return nil, fmt.Errorf("unable to convert unlock block at pos %d: %w", i, err)
+		}
+	}
+	return tx, nil
+}
+
+// TransactionEssenceToProto converts the given iotago.TransactionEssence to its protobuf form.
+func TransactionEssenceToProto(essence *iotago.TransactionEssence) (*TransactionEssence, error) {
+	var err error
+	pbEssence := &TransactionEssence{
+		Inputs:  make([]*Input, len(essence.Inputs)),
+		Outputs: make([]*Output, len(essence.Outputs)),
+	}
+	for i, input := range essence.Inputs {
+		if pbEssence.Inputs[i], err = InputToProto(input); err != nil {
+			return nil, fmt.Errorf("unable to convert input at pos %d: %w", i, err)
+		}
+	}
+	for i, output := range essence.Outputs {
+		if pbEssence.Outputs[i], err = OutputToProto(output); err != nil {
+			return nil, fmt.Errorf("unable to convert output at pos %d: %w", i, err)
+		}
+	}
+	if essence.Payload != nil {
+		indexation, ok := essence.Payload.(*iotago.Indexation)
+		if !ok {
+			return nil, fmt.Errorf("%w: transaction essence payload %T", iotago.ErrUnsupportedPayloadType, essence.Payload)
+		}
+		pbEssence.Payload = IndexationToProto(indexation)
+	}
+	return pbEssence, nil
+}
+
+// TransactionEssenceFromProto converts the given protobuf TransactionEssence to an iotago.TransactionEssence.
+func TransactionEssenceFromProto(pbEssence *TransactionEssence) (*iotago.TransactionEssence, error) {
+	var err error
+	essence := &iotago.TransactionEssence{
+		Inputs:  make(serializer.Serializables, len(pbEssence.GetInputs())),
+		Outputs: make(serializer.Serializables, len(pbEssence.GetOutputs())),
+	}
+	for i, pbInput := range pbEssence.GetInputs() {
+		if essence.Inputs[i], err = InputFromProto(pbInput); err != nil {
+			return nil, fmt.Errorf("unable to convert input at pos %d: %w", i, err)
+		}
+	}
+	for i, pbOutput := range pbEssence.GetOutputs() {
+		if essence.Outputs[i], err = OutputFromProto(pbOutput); err != nil {
+			return nil, fmt.Errorf("unable to convert output at pos %d: %w", i, err)
+		}
+	}
+	if pbEssence.GetPayload() != nil {
+		essence.Payload = IndexationFromProto(pbEssence.GetPayload())
+	}
+	return essence, nil
+}
+
+// InputToProto converts the given input to its protobuf form.
+func InputToProto(input serializer.Serializable) (*Input, error) {
+	switch in := input.(type) {
+	case *iotago.UTXOInput:
+		return &Input{Input: &Input_Utxo{Utxo: &UTXOInput{
+			TransactionId:          append([]byte{}, in.TransactionID[:]...),
+			TransactionOutputIndex: uint32(in.TransactionOutputIndex),
+		}}}, nil
+	case *iotago.TreasuryInput:
+		return &Input{Input: &Input_Treasury{Treasury: TreasuryInputToProto(in)}}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", iotago.ErrUnknownInputType, input)
+	}
+}
+
+// InputFromProto converts the given protobuf Input to its iotago counterpart.
+func InputFromProto(pbInput *Input) (serializer.Serializable, error) {
+	switch in := pbInput.GetInput().(type) {
+	case *Input_Utxo:
+		if in.Utxo.GetTransactionOutputIndex() > math.MaxUint16 {
+			return nil, fmt.Errorf("%w: transaction output index %d exceeds max value", serializer.ErrDeserializationLengthInvalid, in.Utxo.GetTransactionOutputIndex())
+		}
+		utxoInput := &iotago.UTXOInput{TransactionOutputIndex: uint16(in.Utxo.GetTransactionOutputIndex())}
+		if err := copyExact(utxoInput.TransactionID[:], in.Utxo.GetTransactionId()); err != nil {
+			return nil, fmt.Errorf("invalid transaction ID: %w", err)
+		}
+		return utxoInput, nil
+	case *Input_Treasury:
+		return TreasuryInputFromProto(in.Treasury)
+	default:
+		return nil, fmt.Errorf("%w: %T", iotago.ErrUnknownInputType, in)
+	}
+}
+
+// TreasuryInputToProto converts the given iotago.TreasuryInput to its protobuf form.
+func TreasuryInputToProto(input *iotago.TreasuryInput) *TreasuryInput {
+	return &TreasuryInput{MilestoneId: append([]byte{}, input[:]...)}
+}
+
+// TreasuryInputFromProto converts the given protobuf TreasuryInput to an iotago.TreasuryInput.
+func TreasuryInputFromProto(pbInput *TreasuryInput) (*iotago.TreasuryInput, error) {
+	input := &iotago.TreasuryInput{}
+	if err := copyExact(input[:], pbInput.GetMilestoneId()); err != nil {
+		return nil, fmt.Errorf("invalid milestone ID: %w", err)
+	}
+	return input, nil
+}
+
+// OutputToProto converts the given output to its protobuf form.
+func OutputToProto(output serializer.Serializable) (*Output, error) {
+	switch out := output.(type) {
+	case *iotago.SigLockedSingleOutput:
+		addr, err := AddressToProto(out.Address)
+		if err != nil {
+			return nil, err
+		}
+		return &Output{Output: &Output_SigLockedSingle{SigLockedSingle: &SigLockedSingleOutput{Address: addr, Amount: out.Amount}}}, nil
+	case *iotago.SigLockedDustAllowanceOutput:
+		addr, err := AddressToProto(out.Address)
+		if err != nil {
+			return nil, err
+		}
+		return &Output{Output: &Output_SigLockedDustAllowance{SigLockedDustAllowance: &SigLockedDustAllowanceOutput{Address: addr, Amount: out.Amount}}}, nil
+	case *iotago.TreasuryOutput:
+		return &Output{Output: &Output_Treasury{Treasury: TreasuryOutputToProto(out)}}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", iotago.ErrUnknownOutputType, output)
+	}
+}
+
+// OutputFromProto converts the given protobuf Output to its iotago counterpart.
+func OutputFromProto(pbOutput *Output) (serializer.Serializable, error) {
+	switch out := pbOutput.GetOutput().(type) {
+	case *Output_SigLockedSingle:
+		addr, err := addressFromProtoField(out.SigLockedSingle.GetAddress())
+		if err != nil {
+			return nil, err
+		}
+		return &iotago.SigLockedSingleOutput{Address: addr, Amount: out.SigLockedSingle.GetAmount()}, nil
+	case *Output_SigLockedDustAllowance:
+		addr, err := addressFromProtoField(out.SigLockedDustAllowance.GetAddress())
+		if err != nil {
+			return nil, err
+		}
+		return &iotago.SigLockedDustAllowanceOutput{Address: addr, Amount: out.SigLockedDustAllowance.GetAmount()}, nil
+	case *Output_Treasury:
+		return TreasuryOutputFromProto(out.Treasury), nil
+	default:
+		return nil, fmt.Errorf("%w: %T", iotago.ErrUnknownOutputType, out)
+	}
+}
+
+// TreasuryOutputToProto converts the given iotago.TreasuryOutput to its protobuf form.
+func TreasuryOutputToProto(output *iotago.TreasuryOutput) *TreasuryOutput {
+	return &TreasuryOutput{Amount: output.Amount}
+}
+
+// TreasuryOutputFromProto converts the given protobuf TreasuryOutput to an iotago.TreasuryOutput.
+func TreasuryOutputFromProto(pbOutput *TreasuryOutput) *iotago.TreasuryOutput {
+	return &iotago.TreasuryOutput{Amount: pbOutput.GetAmount()}
+}
+
+// AddressToProto converts the given address to its protobuf form.
+func AddressToProto(addr serializer.Serializable) (*Address, error) {
+	switch a := addr.(type) {
+	case *iotago.Ed25519Address:
+		return &Address{Address: &Address_Ed25519{Ed25519: append([]byte{}, a[:]...)}}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", iotago.ErrUnknownAddrType, addr)
+	}
+}
+
+// AddressFromProto converts the given protobuf Address to its iotago counterpart.
+func AddressFromProto(pbAddr *Address) (iotago.Address, error) {
+	switch a := pbAddr.GetAddress().(type) {
+	case *Address_Ed25519:
+		addr := &iotago.Ed25519Address{}
+		if err := copyExact(addr[:], a.Ed25519); err != nil {
+			return nil, fmt.Errorf("invalid Ed25519 address: %w", err)
+		}
+		return addr, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", iotago.ErrUnknownAddrType, a)
+	}
+}
+
+// converts an address held by another protobuf message, which must be set.
+func addressFromProtoField(pbAddr *Address) (iotago.Address, error) {
+	if pbAddr == nil {
+		return nil, fmt.Errorf("%w: address", ErrMissingField)
+	}
+	return AddressFromProto(pbAddr)
+}
+
+// UnlockBlockToProto converts the given unlock block to its protobuf form.
+func UnlockBlockToProto(unlockBlock serializer.Serializable) (*UnlockBlock, error) {
+	switch block := unlockBlock.(type) {
+	case *iotago.SignatureUnlockBlock:
+		sig, err := SignatureToProto(block.Signature)
+		if err != nil {
+			return nil, err
+		}
+		return &UnlockBlock{UnlockBlock: &UnlockBlock_Signature{Signature: &SignatureUnlockBlock{Signature: sig}}}, nil
+	case *iotago.ReferenceUnlockBlock:
+		return &UnlockBlock{UnlockBlock: &UnlockBlock_Reference{Reference: &ReferenceUnlockBlock{Reference: uint32(block.Reference)}}}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", iotago.ErrUnknownUnlockBlockType, unlockBlock)
+	}
+}
+
+// UnlockBlockFromProto converts the given protobuf UnlockBlock to its iotago counterpart.
+func UnlockBlockFromProto(pbUnlockBlock *UnlockBlock) (serializer.Serializable, error) {
+	switch block := pbUnlockBlock.GetUnlockBlock().(type) {
+	case *UnlockBlock_Signature:
+		if block.Signature.GetSignature() == nil {
+			return nil, fmt.Errorf("%w: signature", ErrMissingField)
+		}
+		sig, err := SignatureFromProto(block.Signature.GetSignature())
+		if err != nil {
+			return nil, err
+		}
+		return &iotago.SignatureUnlockBlock{Signature: sig}, nil
+	case *UnlockBlock_Reference:
+		if block.Reference.GetReference() > math.MaxUint16 {
+			return nil, fmt.Errorf("%w: reference %d exceeds max value", serializer.ErrDeserializationLengthInvalid, block.Reference.GetReference())
+		}
+		return &iotago.ReferenceUnlockBlock{Reference: uint16(block.Reference.GetReference())}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", iotago.ErrUnknownUnlockBlockType, block)
+	}
+}
+
+// SignatureToProto converts the given signature to its protobuf form.
+func SignatureToProto(sig serializer.Serializable) (*Signature, error) {
+	switch s := sig.(type) {
+	case *iotago.Ed25519Signature:
+		return &Signature{Signature: &Signature_Ed25519{Ed25519: &Ed25519Signature{
+			PublicKey: append([]byte{}, s.PublicKey[:]...),
+			Signature: append([]byte{}, s.Signature[:]...),
+		}}}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", iotago.ErrUnknownSignatureType, sig)
+	}
+}
+
+// SignatureFromProto converts the given protobuf Signature to its iotago counterpart.
+func SignatureFromProto(pbSig *Signature) (serializer.Serializable, error) {
+	switch s := pbSig.GetSignature().(type) {
+	case *Signature_Ed25519:
+		sig := &iotago.Ed25519Signature{}
+		if err := copyExact(sig.PublicKey[:], s.Ed25519.GetPublicKey()); err != nil {
+			return nil, fmt.Errorf("invalid Ed25519 public key: %w", err)
+		}
+		if err := copyExact(sig.Signature[:], s.Ed25519.GetSignature()); err != nil {
+			return nil, fmt.Errorf("invalid Ed25519 signature: %w", err)
+		}
+		return sig, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", iotago.ErrUnknownSignatureType, s)
+	}
+}
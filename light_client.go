@@ -0,0 +1,330 @@
+package iotago
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrLightClientInvalidMilestone gets returned when a milestone fetched from the node is invalid.
+	ErrLightClientInvalidMilestone = errors.New("invalid milestone")
+	// ErrLightClientUnverifiedMilestone gets returned when a milestone index is not part of the verified milestone chain.
+	ErrLightClientUnverifiedMilestone = errors.New("milestone is not verified")
+	// ErrLightClientMessageNotReferenced gets returned when a message is not referenced by the claimed milestone.
+	ErrLightClientMessageNotReferenced = errors.New("message is not referenced by milestone")
+	// ErrLightClientMessageMismatch gets returned when the node delivers a different message than queried.
+	ErrLightClientMessageMismatch = errors.New("node delivered a different message")
+	// ErrLightClientTraversalLimitReached gets returned when walking the past cone of a milestone exceeds the traversal limit.
+	ErrLightClientTraversalLimitReached = errors.New("traversal limit reached")
+)
+
+// VerifiedMilestone is a milestone of the verified milestone chain.
+type VerifiedMilestone struct {
+	// The index of the milestone.
+	Index uint32
+	// The time at which the milestone was issued.
+	Timestamp uint64
+	// The ID of the milestone.
+	MilestoneID MilestoneID
+	// The ID of the message containing the milestone.
+	MessageID MessageID
+}
+
+var defaultLightClientOptions = []LightClientOption{
+	WithLightClientMinPoWScore(0),
+	WithLightClientTraversalLimit(10000),
+}
+
+// LightClientOptions define options for the LightClient.
+type LightClientOptions struct {
+	// The min. PoW score applicable at the trusted milestone.
+	minPoWScore uint32
+	// The max. amount of messages fetched to verify that a message is referenced by a milestone.
+	traversalLimit int
+}
+
+// applies the given LightClientOption.
+func (lo *LightClientOptions) apply(opts ...LightClientOption) {
+	for _, opt := range opts {
+		opt(lo)
+	}
+}
+
+// WithLightClientMinPoWScore sets the min. PoW score which is applicable at the trusted milestone.
+func WithLightClientMinPoWScore(score uint32) LightClientOption {
+	return func(opts *LightClientOptions) {
+		opts.minPoWScore = score
+	}
+}
+
+// WithLightClientTraversalLimit sets the max. amount of messages fetched to verify that a message
+// is part of the past cone of a milestone.
+func WithLightClientTraversalLimit(limit int) LightClientOption {
+	return func(opts *LightClientOptions) {
+		opts.traversalLimit = limit
+	}
+}
+
+// LightClientOption is a function setting a LightClient option.
+type LightClientOption func(opts *LightClientOptions)
+
+// powScoreChange is a change of the min. PoW score at a milestone index.
+type powScoreChange struct {
+	index uint32
+	score uint32
+}
+
+// NewLightClient creates a new LightClient which extends the verified milestone chain from the given trusted
+// message containing a Milestone. The trusted milestone's signatures are verified against the given MilestoneKeyManager.
+func NewLightClient(nodeHTTPAPIClient *NodeHTTPAPIClient, keyManager *MilestoneKeyManager, trustedMsg *Message, opts ...LightClientOption) (*LightClient, error) {
+	options := &LightClientOptions{}
+	options.apply(defaultLightClientOptions...)
+	options.apply(opts...)
+
+	lc := &LightClient{
+		nodeAPI:         nodeHTTPAPIClient,
+		keyManager:      keyManager,
+		opts:            options,
+		milestones:      make(map[uint32]*VerifiedMilestone),
+		milestoneMsgIDs: make(map[MessageID]uint32),
+	}
+
+	ms, ok := trustedMsg.Payload.(*Milestone)
+	if !ok {
+		return nil, fmt.Errorf("%w: trusted message contains %T", ErrLightClientInvalidMilestone, trustedMsg.Payload)
+	}
+	lc.powScoreChanges = []powScoreChange{{index: ms.Index, score: options.minPoWScore}}
+	if err := lc.add(trustedMsg, ms); err != nil {
+		return nil, err
+	}
+	lc.trustedIndex = ms.Index
+	return lc, nil
+}
+
+// LightClient verifies the milestone chain of a node starting from a trusted milestone, without running a node.
+// It can be used to verify claims of the node against the verified milestone chain.
+type LightClient struct {
+	nodeAPI    *NodeHTTPAPIClient
+	keyManager *MilestoneKeyManager
+	opts       *LightClientOptions
+
+	mu              sync.RWMutex
+	trustedIndex    uint32
+	confirmedIndex  uint32
+	milestones      map[uint32]*VerifiedMilestone
+	milestoneMsgIDs map[MessageID]uint32
+	powScoreChanges []powScoreChange
+}
+
+// ConfirmedMilestoneIndex returns the index of the latest verified milestone.
+func (lc *LightClient) ConfirmedMilestoneIndex() uint32 {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	return lc.confirmedIndex
+}
+
+// Milestone returns the verified milestone of the given index.
+func (lc *LightClient) Milestone(index uint32) (*VerifiedMilestone, error) {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	ms, has := lc.milestones[index]
+	if !has {
+		return nil, fmt.Errorf("%w: index %d, verified range %d-%d", ErrLightClientUnverifiedMilestone, index, lc.trustedIndex, lc.confirmedIndex)
+	}
+	return ms, nil
+}
+
+// MinPoWScore returns the min. PoW score applicable at the given milestone index as announced by the verified milestones.
+func (lc *LightClient) MinPoWScore(index uint32) uint32 {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	score := lc.powScoreChanges[0].score
+	for _, change := range lc.powScoreChanges {
+		if change.index > index {
+			break
+		}
+		score = change.score
+	}
+	return score
+}
+
+// Sync fetches and verifies all milestones up to the node's confirmed milestone index
+// and returns the new confirmed milestone index.
+func (lc *LightClient) Sync(ctx context.Context) (uint32, error) {
+	info, err := lc.nodeAPI.Info(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to query node info: %w", err)
+	}
+	return lc.SyncTo(ctx, info.ConfirmedMilestoneIndex)
+}
+
+// SyncTo fetches and verifies all milestones up to the given index and returns the new confirmed milestone index.
+// Every milestone must be signed by the keys applicable to its index, follow the previous milestone's index and
+// must not be issued before the previous milestone.
+func (lc *LightClient) SyncTo(ctx context.Context, target uint32) (uint32, error) {
+	for index := lc.ConfirmedMilestoneIndex() + 1; index <= target; index++ {
+		msg, ms, err := lc.fetchMilestone(ctx, index)
+		if err != nil {
+			return lc.ConfirmedMilestoneIndex(), err
+		}
+		if err := lc.add(msg, ms); err != nil {
+			return lc.ConfirmedMilestoneIndex(), err
+		}
+	}
+	return lc.ConfirmedMilestoneIndex(), nil
+}
+
+// fetches the message of the milestone of the given index and checks whether the node delivered the right message.
+func (lc *LightClient) fetchMilestone(ctx context.Context, index uint32) (*Message, *Milestone, error) {
+	msRes, err := lc.nodeAPI.MilestoneByIndex(ctx, index)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to query milestone %d: %w", index, err)
+	}
+	msgID, err := MessageIDFromHexString(msRes.MessageID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid message ID of milestone %d: %s", ErrLightClientInvalidMilestone, index, err)
+	}
+	msg, err := lc.fetchMessage(ctx, msgID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to query message of milestone %d: %w", index, err)
+	}
+	ms, ok := msg.Payload.(*Milestone)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: message of milestone %d contains %T", ErrLightClientInvalidMilestone, index, msg.Payload)
+	}
+	if ms.Index != index {
+		return nil, nil, fmt.Errorf("%w: queried milestone %d but got %d", ErrLightClientInvalidMilestone, index, ms.Index)
+	}
+	return msg, ms, nil
+}
+
+// fetches the message with the given ID and checks whether the node delivered the right message.
+func (lc *LightClient) fetchMessage(ctx context.Context, msgID MessageID) (*Message, error) {
+	msg, err := lc.nodeAPI.MessageByMessageID(ctx, msgID)
+	if err != nil {
+		return nil, err
+	}
+	actualMsgID, err := msg.ID()
+	if err != nil {
+		return nil, err
+	}
+	if *actualMsgID != msgID {
+		return nil, fmt.Errorf("%w: got %s for %s", ErrLightClientMessageMismatch, hex.EncodeToString(actualMsgID[:]), hex.EncodeToString(msgID[:]))
+	}
+	return msg, nil
+}
+
+// verifies the given milestone and appends it to the verified milestone chain.
+func (lc *LightClient) add(msg *Message, ms *Milestone) error {
+	if err := lc.keyManager.VerifyMilestone(ms); err != nil {
+		return fmt.Errorf("%w: milestone %d: %s", ErrLightClientInvalidMilestone, ms.Index, err)
+	}
+
+	msgID, err := msg.ID()
+	if err != nil {
+		return err
+	}
+	msID, err := ms.ID()
+	if err != nil {
+		return err
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if prev, has := lc.milestones[lc.confirmedIndex]; has {
+		switch {
+		case ms.Index != prev.Index+1:
+			return fmt.Errorf("%w: milestone %d does not follow milestone %d", ErrLightClientInvalidMilestone, ms.Index, prev.Index)
+		case ms.Timestamp < prev.Timestamp:
+			return fmt.Errorf("%w: milestone %d was issued before milestone %d", ErrLightClientInvalidMilestone, ms.Index, prev.Index)
+		}
+	}
+
+	if ms.NextPoWScoreMilestoneIndex != 0 {
+		lc.powScoreChanges = append(lc.powScoreChanges, powScoreChange{index: ms.NextPoWScoreMilestoneIndex, score: ms.NextPoWScore})
+		sort.SliceStable(lc.powScoreChanges, func(i, j int) bool {
+			return lc.powScoreChanges[i].index < lc.powScoreChanges[j].index
+		})
+	}
+
+	lc.milestones[ms.Index] = &VerifiedMilestone{Index: ms.Index, Timestamp: ms.Timestamp, MilestoneID: *msID, MessageID: *msgID}
+	lc.milestoneMsgIDs[*msgID] = ms.Index
+	lc.confirmedIndex = ms.Index
+	return nil
+}
+
+// VerifyConfirmation verifies that the message with the given ID is part of the past cone of the milestone
+// which the node claims to reference it, as described by VerifyInPastCone, and returns the verified milestone index.
+// This proves that the message is confirmed at the latest by that milestone, but not that this milestone was
+// the first one to reference it: a node can claim a later milestone for a message referenced by an earlier one.
+func (lc *LightClient) VerifyConfirmation(ctx context.Context, msgID MessageID) (uint32, error) {
+	metadata, err := lc.nodeAPI.MessageMetadataByMessageID(ctx, msgID)
+	if err != nil {
+		return 0, fmt.Errorf("unable to query message metadata: %w", err)
+	}
+	if metadata.ReferencedByMilestoneIndex == nil {
+		return 0, fmt.Errorf("%w: node claims the message is not referenced", ErrLightClientMessageNotReferenced)
+	}
+	index := *metadata.ReferencedByMilestoneIndex
+	if err := lc.VerifyInPastCone(ctx, msgID, index); err != nil {
+		return 0, err
+	}
+	return index, nil
+}
+
+// VerifyInPastCone verifies that the message with the given ID is part of the past cone of the
+// verified milestone of the given index. The past cone is walked from the milestone without descending
+// into previous milestones, as their past cones were referenced before. Therefore, a message which is
+// only reachable through a previous milestone is reported as not being part of the past cone;
+// such a message has to be verified against the milestone which first referenced it.
+// It is not verified that no previous milestone referenced the message.
+func (lc *LightClient) VerifyInPastCone(ctx context.Context, msgID MessageID, index uint32) error {
+	ms, err := lc.Milestone(index)
+	if err != nil {
+		return err
+	}
+
+	visited := map[MessageID]struct{}{ms.MessageID: {}}
+	queue := MessageIDs{ms.MessageID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == msgID {
+			return nil
+		}
+
+		if len(visited) > lc.opts.traversalLimit {
+			return fmt.Errorf("%w: visited %d messages of the past cone of milestone %d", ErrLightClientTraversalLimitReached, len(visited), index)
+		}
+
+		msg, err := lc.fetchMessage(ctx, current)
+		if err != nil {
+			return fmt.Errorf("unable to query message %s: %w", hex.EncodeToString(current[:]), err)
+		}
+		for _, parent := range msg.Parents {
+			if _, has := visited[parent]; has {
+				continue
+			}
+			visited[parent] = struct{}{}
+			if lc.isMilestoneMessageBefore(parent, index) {
+				continue
+			}
+			queue = append(queue, parent)
+		}
+	}
+
+	return fmt.Errorf("%w: message %s is not part of the past cone of milestone %d", ErrLightClientMessageNotReferenced, hex.EncodeToString(msgID[:]), index)
+}
+
+// tells whether the message with the given ID contains a verified milestone preceding the given index.
+func (lc *LightClient) isMilestoneMessageBefore(msgID MessageID, index uint32) bool {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	msIndex, isMilestone := lc.milestoneMsgIDs[msgID]
+	return isMilestone && msIndex < index
+}
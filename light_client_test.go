@@ -0,0 +1,113 @@
+package iotago_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+func TestLightClient(t *testing.T) {
+	pubKeys, prvKeys := randMilestoneKeys(2)
+	km := iotago.NewMilestoneKeyManager(2)
+	for _, pubKey := range pubKeys {
+		require.NoError(t, km.AddKeyRange(pubKey, 0, 0))
+	}
+	allPrvKeys := []ed25519.PrivateKey{prvKeys[pubKeys[0]], prvKeys[pubKeys[1]]}
+	schedule, signer := km.Signer(allPrvKeys...)
+
+	node, nodeAPI := newFakeNode(t)
+	timestamp := time.Unix(1_600_000_000, 0)
+	milestone := func(index uint32, parents ...iotago.MessageID) (*iotago.Message, iotago.MessageID) {
+		msg, err := iotago.NewMilestoneBuilder(index).
+			Timestamp(timestamp.Add(time.Duration(index)*time.Second)).
+			Parents(parents).
+			NextPoWScore(func() (uint32, uint32) {
+				if index == 2 {
+					return 2000, 10
+				}
+				return 0, 0
+			}()).
+			PublicKeySchedule(schedule).
+			Sign(signer).
+			BuildMessage(context.Background(), 0, 0)
+		require.NoError(t, err)
+		return msg, node.AttachMessage(msg)
+	}
+	data := func(parents ...iotago.MessageID) iotago.MessageID {
+		return node.AttachMessage(&iotago.Message{Parents: serializer.RemoveDupsAndSortByLexicalOrderArrayOf32Bytes(parents), Payload: &iotago.Indexation{Index: []byte("light client")}})
+	}
+
+	trustedMsg, ms1 := milestone(1, tpkg.Rand32ByteArray())
+	a := data(ms1)
+	_, ms2 := milestone(2, ms1, a)
+	b := data(ms2)
+	c := data(ms2, b)
+	_, ms3 := milestone(3, ms2, c)
+	node.ReferenceMessage(a, 2)
+	node.ReferenceMessage(b, 3)
+	node.ReferenceMessage(c, 2)
+
+	lc, err := iotago.NewLightClient(nodeAPI, km, trustedMsg, iotago.WithLightClientMinPoWScore(1000))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, lc.ConfirmedMilestoneIndex())
+
+	confirmedIndex, err := lc.Sync(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, confirmedIndex)
+	verifiedMs3, err := lc.Milestone(3)
+	require.NoError(t, err)
+	assert.Equal(t, ms3, verifiedMs3.MessageID)
+	_, err = lc.Milestone(4)
+	assert.True(t, errors.Is(err, iotago.ErrLightClientUnverifiedMilestone))
+
+	assert.EqualValues(t, 1000, lc.MinPoWScore(9))
+	assert.EqualValues(t, 2000, lc.MinPoWScore(10))
+
+	index, err := lc.VerifyConfirmation(context.Background(), a)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, index)
+	index, err = lc.VerifyConfirmation(context.Background(), b)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, index)
+
+	// c is only referenced by milestone 3
+	_, err = lc.VerifyConfirmation(context.Background(), c)
+	assert.True(t, errors.Is(err, iotago.ErrLightClientMessageNotReferenced))
+	_, err = lc.VerifyConfirmation(context.Background(), data(ms3))
+	assert.True(t, errors.Is(err, iotago.ErrLightClientMessageNotReferenced))
+
+	limited, err := iotago.NewLightClient(nodeAPI, km, trustedMsg, iotago.WithLightClientTraversalLimit(1))
+	require.NoError(t, err)
+	_, err = limited.SyncTo(context.Background(), 3)
+	require.NoError(t, err)
+	_, err = limited.VerifyConfirmation(context.Background(), b)
+	assert.True(t, errors.Is(err, iotago.ErrLightClientTraversalLimitReached))
+
+	// a milestone signed by keys which are not applicable is rejected
+	otherPubKeys, otherPrvKeys := randMilestoneKeys(2)
+	forged, err := iotago.NewMilestoneBuilder(4).
+		Parents(iotago.MessageIDs{ms3}).
+		PublicKeys(otherPubKeys...).
+		Sign(iotago.InMemoryEd25519MilestoneSigner(otherPrvKeys)).
+		BuildMessage(context.Background(), 0, 0)
+	require.NoError(t, err)
+	node.AttachMessage(forged)
+	confirmedIndex, err = lc.Sync(context.Background())
+	assert.True(t, errors.Is(err, iotago.ErrLightClientInvalidMilestone))
+	assert.EqualValues(t, 3, confirmedIndex)
+
+	// a milestone issued before its predecessor is rejected
+	timestamp = timestamp.Add(-time.Hour)
+	milestone(4, ms3)
+	_, err = lc.Sync(context.Background())
+	assert.True(t, errors.Is(err, iotago.ErrLightClientInvalidMilestone))
+}
@@ -88,6 +88,21 @@ func (mb *MessageBuilder) Tips(ctx context.Context, nodeAPI *NodeHTTPAPIClient)
 	return mb
 }
 
+// TipsFromSelector uses the given TipSelector to select parents to use.
+func (mb *MessageBuilder) TipsFromSelector(ctx context.Context, selector TipSelector) *MessageBuilder {
+	if mb.err != nil {
+		return mb
+	}
+
+	parents, err := selector.SelectTips(ctx)
+	if err != nil {
+		mb.err = fmt.Errorf("unable to select tips: %w", err)
+		return mb
+	}
+
+	return mb.ParentsMessageIDs(parents)
+}
+
 // Parents sets the parents of the message.
 func (mb *MessageBuilder) Parents(parents [][]byte) *MessageBuilder {
 	if mb.err != nil {
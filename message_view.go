@@ -0,0 +1,472 @@
+package iotago
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// offset of the parents count within a serialized message.
+	messageViewParentsCountOffset = MessageNetworkIDLength
+	// offset of the first parent within a serialized message.
+	messageViewParentsOffset = messageViewParentsCountOffset + serializer.OneByte
+)
+
+// MessageView is a read-only view onto the serialized form of a Message.
+// It exposes the fields of the message without building up the object graph of the Message and
+// its payload and therefore without allocating any memory. This is useful for applications which have
+// to scan through large amounts of messages but are only interested in a small portion of their content.
+//
+// A MessageView must be created through NewMessageView which checks the structural integrity of the given data,
+// so that none of the accessor functions can run out of bounds. The view does not perform the syntactical validation
+// done by Message.Deserialize (i.e. lexical ordering, deposit amounts etc.), use the latter if such validation is needed.
+// The data passed to NewMessageView must not be modified while the MessageView is in use.
+type MessageView struct {
+	data          []byte
+	payloadOffset int
+	payloadLength int
+	payloadType   uint32
+	index         IndexationView
+	tx            TransactionView
+}
+
+// NewMessageView creates a new MessageView over the given serialized Message.
+// It returns an error if the data does not represent a structurally correct Message.
+func NewMessageView(data []byte) (MessageView, error) {
+	v := MessageView{data: data}
+
+	if len(data) > MessageBinSerializedMaxSize {
+		return MessageView{}, fmt.Errorf("%w: size %d bytes", ErrMessageExceedsMaxSize, len(data))
+	}
+	if err := serializer.CheckMinByteLength(MessageBinSerializedMinSize, len(data)); err != nil {
+		return MessageView{}, fmt.Errorf("invalid message bytes: %w", err)
+	}
+
+	parentsCount := int(data[messageViewParentsCountOffset])
+	if parentsCount < MinParentsInAMessage || parentsCount > MaxParentsInAMessage {
+		return MessageView{}, fmt.Errorf("%w: message parents count %d", serializer.ErrDeserializationLengthInvalid, parentsCount)
+	}
+
+	payloadLengthOffset := messageViewParentsOffset + parentsCount*MessageIDLength
+	payloadLength, err := viewReadUint32(data, payloadLengthOffset)
+	if err != nil {
+		return MessageView{}, fmt.Errorf("unable to read message payload length: %w", err)
+	}
+	v.payloadOffset = payloadLengthOffset + serializer.UInt32ByteSize
+	v.payloadLength = int(payloadLength)
+
+	if v.payloadOffset+v.payloadLength+serializer.UInt64ByteSize != len(data) {
+		return MessageView{}, fmt.Errorf("%w: message payload length %d does not match the message size", serializer.ErrDeserializationLengthInvalid, payloadLength)
+	}
+
+	if v.payloadLength == 0 {
+		return v, nil
+	}
+
+	payload := data[v.payloadOffset : v.payloadOffset+v.payloadLength]
+	if v.payloadType, err = viewReadUint32(payload, 0); err != nil {
+		return MessageView{}, fmt.Errorf("unable to read message payload type: %w", err)
+	}
+
+	switch v.payloadType {
+	case TransactionPayloadTypeID:
+		if v.tx, err = newTransactionView(payload); err != nil {
+			return MessageView{}, err
+		}
+	case IndexationPayloadTypeID:
+		var n int
+		if v.index, n, err = newIndexationView(payload); err != nil {
+			return MessageView{}, err
+		}
+		if n != len(payload) {
+			return MessageView{}, fmt.Errorf("%w: indexation payload has %d bytes left over", serializer.ErrDeserializationNotAllConsumed, len(payload)-n)
+		}
+	case MilestonePayloadTypeID:
+		if err := serializer.CheckMinByteLength(MilestoneBinSerializedMinSize, len(payload)); err != nil {
+			return MessageView{}, fmt.Errorf("invalid milestone bytes: %w", err)
+		}
+	default:
+		return MessageView{}, fmt.Errorf("a message can only contain a transaction, indexation or milestone but got type ID %d: %w", v.payloadType, ErrUnsupportedPayloadType)
+	}
+
+	return v, nil
+}
+
+// Bytes returns the underlying serialized Message.
+func (v *MessageView) Bytes() []byte {
+	return v.data
+}
+
+// ID computes the MessageID of the underlying Message.
+func (v *MessageView) ID() MessageID {
+	return blake2b.Sum256(v.data)
+}
+
+// NetworkID returns the network ID of the Message.
+func (v *MessageView) NetworkID() uint64 {
+	return binary.LittleEndian.Uint64(v.data)
+}
+
+// ParentsCount returns the amount of parents of the Message.
+func (v *MessageView) ParentsCount() int {
+	return int(v.data[messageViewParentsCountOffset])
+}
+
+// Parent returns the parent at the given index. It panics if the index is out of range.
+func (v *MessageView) Parent(i int) MessageID {
+	if i < 0 || i >= v.ParentsCount() {
+		panic(fmt.Sprintf("parent index %d out of range", i))
+	}
+	var parent MessageID
+	copy(parent[:], v.data[messageViewParentsOffset+i*MessageIDLength:])
+	return parent
+}
+
+// Nonce returns the nonce of the Message.
+func (v *MessageView) Nonce() uint64 {
+	return binary.LittleEndian.Uint64(v.data[len(v.data)-serializer.UInt64ByteSize:])
+}
+
+// HasPayload tells whether the Message contains a payload.
+func (v *MessageView) HasPayload() bool {
+	return v.payloadLength != 0
+}
+
+// PayloadType returns the type of the payload within the Message.
+// The second return value is false if the Message does not contain a payload.
+func (v *MessageView) PayloadType() (uint32, bool) {
+	return v.payloadType, v.HasPayload()
+}
+
+// PayloadBytes returns the serialized payload of the Message or nil if it does not contain one.
+func (v *MessageView) PayloadBytes() []byte {
+	if !v.HasPayload() {
+		return nil
+	}
+	return v.data[v.payloadOffset : v.payloadOffset+v.payloadLength]
+}
+
+// Indexation returns a view onto the Indexation payload of the Message.
+// The second return value is false if the Message does not contain an Indexation.
+func (v *MessageView) Indexation() (IndexationView, bool) {
+	if !v.HasPayload() || v.payloadType != IndexationPayloadTypeID {
+		return IndexationView{}, false
+	}
+	return v.index, true
+}
+
+// Transaction returns a view onto the Transaction payload of the Message.
+// The second return value is false if the Message does not contain a Transaction.
+func (v *MessageView) Transaction() (TransactionView, bool) {
+	if !v.HasPayload() || v.payloadType != TransactionPayloadTypeID {
+		return TransactionView{}, false
+	}
+	return v.tx, true
+}
+
+// MilestoneIndex returns the index of the Milestone payload of the Message.
+// The second return value is false if the Message does not contain a Milestone.
+func (v *MessageView) MilestoneIndex() (uint32, bool) {
+	if !v.HasPayload() || v.payloadType != MilestonePayloadTypeID {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint32(v.data[v.payloadOffset+serializer.TypeDenotationByteSize:]), true
+}
+
+// MilestoneID computes the MilestoneID of the Milestone payload of the Message.
+// The second return value is false if the Message does not contain a Milestone.
+func (v *MessageView) MilestoneID() (MilestoneID, bool) {
+	if !v.HasPayload() || v.payloadType != MilestonePayloadTypeID {
+		return MilestoneID{}, false
+	}
+	return blake2b.Sum256(v.PayloadBytes()), true
+}
+
+// IndexationView is a read-only view onto a serialized Indexation.
+type IndexationView struct {
+	index []byte
+	data  []byte
+}
+
+// creates a new IndexationView and returns the amount of bytes the Indexation occupies.
+func newIndexationView(data []byte) (IndexationView, int, error) {
+	if err := serializer.CheckMinByteLength(IndexationBinSerializedMinSize, len(data)); err != nil {
+		return IndexationView{}, 0, fmt.Errorf("invalid indexation bytes: %w", err)
+	}
+	if err := serializer.CheckType(data, IndexationPayloadTypeID); err != nil {
+		return IndexationView{}, 0, fmt.Errorf("unable to read indexation: %w", err)
+	}
+
+	offset := serializer.TypeDenotationByteSize
+	indexLength := int(binary.LittleEndian.Uint16(data[offset:]))
+	switch {
+	case indexLength > IndexationIndexMaxLength:
+		return IndexationView{}, 0, fmt.Errorf("unable to read indexation index: %w", ErrIndexationIndexExceedsMaxSize)
+	case indexLength < IndexationIndexMinLength:
+		return IndexationView{}, 0, fmt.Errorf("unable to read indexation index: %w", ErrIndexationIndexUnderMinSize)
+	}
+	offset += serializer.UInt16ByteSize
+	if offset+indexLength > len(data) {
+		return IndexationView{}, 0, fmt.Errorf("unable to read indexation index: %w", serializer.ErrDeserializationNotEnoughData)
+	}
+	index := data[offset : offset+indexLength]
+	offset += indexLength
+
+	dataLength, err := viewReadUint32(data, offset)
+	if err != nil {
+		return IndexationView{}, 0, fmt.Errorf("unable to read indexation data length: %w", err)
+	}
+	offset += serializer.UInt32ByteSize
+	if int(dataLength) > len(data)-offset {
+		return IndexationView{}, 0, fmt.Errorf("unable to read indexation data: %w", serializer.ErrDeserializationNotEnoughData)
+	}
+
+	return IndexationView{index: index, data: data[offset : offset+int(dataLength)]}, offset + int(dataLength), nil
+}
+
+// Index returns the index of the Indexation.
+func (v IndexationView) Index() []byte {
+	return v.index
+}
+
+// Data returns the data of the Indexation.
+func (v IndexationView) Data() []byte {
+	return v.data
+}
+
+// TransactionView is a read-only view onto a serialized Transaction.
+type TransactionView struct {
+	data          []byte
+	inputsOffset  int
+	inputsCount   int
+	outputsOffset int
+	outputsCount  int
+	essenceEnd    int
+	hasIndexation bool
+	index         IndexationView
+}
+
+// creates a new TransactionView by checking the structure of the given serialized Transaction.
+func newTransactionView(data []byte) (TransactionView, error) {
+	v := TransactionView{data: data}
+
+	if err := serializer.CheckMinByteLength(TransactionBinSerializedMinSize+TransactionEssenceMinByteSize, len(data)); err != nil {
+		return TransactionView{}, fmt.Errorf("invalid transaction bytes: %w", err)
+	}
+
+	offset := serializer.TypeDenotationByteSize
+	if data[offset] != TransactionEssenceNormal {
+		return TransactionView{}, fmt.Errorf("%w: type byte %d", ErrUnknownTransactionEssenceType, data[offset])
+	}
+	offset += serializer.SmallTypeDenotationByteSize
+
+	// inputs
+	v.inputsCount = int(binary.LittleEndian.Uint16(data[offset:]))
+	if v.inputsCount < MinInputsCount || v.inputsCount > MaxInputsCount {
+		return TransactionView{}, fmt.Errorf("%w: transaction inputs count %d", serializer.ErrDeserializationLengthInvalid, v.inputsCount)
+	}
+	offset += serializer.UInt16ByteSize
+	v.inputsOffset = offset
+	if offset+v.inputsCount*UTXOInputSize > len(data) {
+		return TransactionView{}, fmt.Errorf("unable to read transaction inputs: %w", serializer.ErrDeserializationNotEnoughData)
+	}
+	for i := 0; i < v.inputsCount; i++ {
+		if data[offset] != InputUTXO {
+			return TransactionView{}, fmt.Errorf("transaction essence can only contain UTXO input as inputs but got type ID %d: %w", data[offset], ErrUnsupportedObjectType)
+		}
+		offset += UTXOInputSize
+	}
+
+	// outputs
+	outputsCount, err := viewReadUint16(data, offset)
+	if err != nil {
+		return TransactionView{}, fmt.Errorf("unable to read transaction outputs count: %w", err)
+	}
+	v.outputsCount = int(outputsCount)
+	if v.outputsCount < MinOutputsCount || v.outputsCount > MaxOutputsCount {
+		return TransactionView{}, fmt.Errorf("%w: transaction outputs count %d", serializer.ErrDeserializationLengthInvalid, v.outputsCount)
+	}
+	offset += serializer.UInt16ByteSize
+	v.outputsOffset = offset
+	if offset+v.outputsCount*SigLockedSingleOutputEd25519AddrBytesSize > len(data) {
+		return TransactionView{}, fmt.Errorf("unable to read transaction outputs: %w", serializer.ErrDeserializationNotEnoughData)
+	}
+	for i := 0; i < v.outputsCount; i++ {
+		switch data[offset] {
+		case OutputSigLockedSingleOutput, OutputSigLockedDustAllowanceOutput:
+		default:
+			return TransactionView{}, fmt.Errorf("transaction essence can not contain output type ID %d: %w", data[offset], ErrUnsupportedObjectType)
+		}
+		if addrType := data[offset+SigLockedSingleOutputAddressOffset]; addrType != AddressEd25519 {
+			return TransactionView{}, fmt.Errorf("%w: type %d", ErrUnknownAddrType, addrType)
+		}
+		offset += SigLockedSingleOutputEd25519AddrBytesSize
+	}
+
+	// embedded payload
+	essencePayloadLength, err := viewReadUint32(data, offset)
+	if err != nil {
+		return TransactionView{}, fmt.Errorf("unable to read transaction essence payload length: %w", err)
+	}
+	offset += serializer.UInt32ByteSize
+	if essencePayloadLength != 0 {
+		if int(essencePayloadLength) > len(data)-offset {
+			return TransactionView{}, fmt.Errorf("unable to read transaction essence payload: %w", serializer.ErrDeserializationNotEnoughData)
+		}
+		essencePayload := data[offset : offset+int(essencePayloadLength)]
+		var n int
+		if v.index, n, err = newIndexationView(essencePayload); err != nil {
+			return TransactionView{}, fmt.Errorf("transaction essence can only contain an indexation payload: %w", err)
+		}
+		if n != len(essencePayload) {
+			return TransactionView{}, fmt.Errorf("%w: transaction essence payload has %d bytes left over", serializer.ErrDeserializationNotAllConsumed, len(essencePayload)-n)
+		}
+		v.hasIndexation = true
+		offset += int(essencePayloadLength)
+	}
+	v.essenceEnd = offset
+
+	// unlock blocks
+	unlockBlocksCount, err := viewReadUint16(data, offset)
+	if err != nil {
+		return TransactionView{}, fmt.Errorf("unable to read unlock blocks count: %w", err)
+	}
+	if int(unlockBlocksCount) != v.inputsCount {
+		return TransactionView{}, fmt.Errorf("%w: num of inputs %d, num of unlock blocks %d", ErrUnlockBlocksMustMatchInputCount, v.inputsCount, unlockBlocksCount)
+	}
+	offset += serializer.UInt16ByteSize
+	for i := 0; i < int(unlockBlocksCount); i++ {
+		if offset >= len(data) {
+			return TransactionView{}, fmt.Errorf("unable to read unlock block %d: %w", i, serializer.ErrDeserializationNotEnoughData)
+		}
+		var size int
+		switch data[offset] {
+		case UnlockBlockSignature:
+			size = SignatureUnlockBlockMinSize
+			if offset+size <= len(data) && data[offset+serializer.SmallTypeDenotationByteSize] != SignatureEd25519 {
+				return TransactionView{}, fmt.Errorf("%w: type byte %d", ErrUnknownSignatureType, data[offset+serializer.SmallTypeDenotationByteSize])
+			}
+		case UnlockBlockReference:
+			size = ReferenceUnlockBlockSize
+		default:
+			return TransactionView{}, fmt.Errorf("%w: type byte %d", ErrUnknownUnlockBlockType, data[offset])
+		}
+		if offset+size > len(data) {
+			return TransactionView{}, fmt.Errorf("unable to read unlock block %d: %w", i, serializer.ErrDeserializationNotEnoughData)
+		}
+		offset += size
+	}
+
+	if offset != len(data) {
+		return TransactionView{}, fmt.Errorf("%w: transaction has %d bytes left over", serializer.ErrDeserializationNotAllConsumed, len(data)-offset)
+	}
+
+	return v, nil
+}
+
+// Bytes returns the underlying serialized Transaction.
+func (v TransactionView) Bytes() []byte {
+	return v.data
+}
+
+// ID computes the TransactionID of the underlying Transaction.
+func (v TransactionView) ID() TransactionID {
+	return blake2b.Sum256(v.data)
+}
+
+// EssenceBytes returns the serialized TransactionEssence of the Transaction.
+func (v TransactionView) EssenceBytes() []byte {
+	return v.data[serializer.TypeDenotationByteSize:v.essenceEnd]
+}
+
+// InputsCount returns the amount of inputs of the Transaction.
+func (v TransactionView) InputsCount() int {
+	return v.inputsCount
+}
+
+// Input returns the UTXOInputID of the input at the given index. It panics if the index is out of range.
+func (v TransactionView) Input(i int) UTXOInputID {
+	if i < 0 || i >= v.inputsCount {
+		panic(fmt.Sprintf("input index %d out of range", i))
+	}
+	var id UTXOInputID
+	// the serialized form of an UTXOInput without its type byte equals its UTXOInputID
+	copy(id[:], v.data[v.inputsOffset+i*UTXOInputSize+serializer.SmallTypeDenotationByteSize:])
+	return id
+}
+
+// OutputsCount returns the amount of outputs of the Transaction.
+func (v TransactionView) OutputsCount() int {
+	return v.outputsCount
+}
+
+// Output returns a view onto the output at the given index. It panics if the index is out of range.
+func (v TransactionView) Output(i int) OutputView {
+	if i < 0 || i >= v.outputsCount {
+		panic(fmt.Sprintf("output index %d out of range", i))
+	}
+	offset := v.outputsOffset + i*SigLockedSingleOutputEd25519AddrBytesSize
+	return OutputView{data: v.data[offset : offset+SigLockedSingleOutputEd25519AddrBytesSize]}
+}
+
+// OutputID computes the UTXOInputID of the output at the given index. It panics if the index is out of range.
+func (v TransactionView) OutputID(i int) UTXOInputID {
+	if i < 0 || i >= v.outputsCount {
+		panic(fmt.Sprintf("output index %d out of range", i))
+	}
+	var id UTXOInputID
+	txID := v.ID()
+	copy(id[:TransactionIDLength], txID[:])
+	binary.LittleEndian.PutUint16(id[TransactionIDLength:], uint16(i))
+	return id
+}
+
+// Indexation returns a view onto the Indexation payload embedded in the TransactionEssence.
+// The second return value is false if the TransactionEssence does not contain a payload.
+func (v TransactionView) Indexation() (IndexationView, bool) {
+	return v.index, v.hasIndexation
+}
+
+// OutputView is a read-only view onto a serialized SigLockedSingleOutput or SigLockedDustAllowanceOutput.
+type OutputView struct {
+	data []byte
+}
+
+// Type returns the type of the output.
+func (v OutputView) Type() OutputType {
+	return v.data[0]
+}
+
+// AddressType returns the type of the address of the output.
+func (v OutputView) AddressType() AddressType {
+	return v.data[SigLockedSingleOutputAddressOffset]
+}
+
+// Ed25519Address returns the Ed25519Address of the output.
+func (v OutputView) Ed25519Address() Ed25519Address {
+	var addr Ed25519Address
+	copy(addr[:], v.data[SigLockedSingleOutputAddressOffset+serializer.SmallTypeDenotationByteSize:])
+	return addr
+}
+
+// Amount returns the deposit of the output.
+func (v OutputView) Amount() uint64 {
+	return binary.LittleEndian.Uint64(v.data[SigLockedSingleOutputAddressOffset+Ed25519AddressSerializedBytesSize:])
+}
+
+func viewReadUint16(data []byte, offset int) (uint16, error) {
+	if offset+serializer.UInt16ByteSize > len(data) {
+		return 0, serializer.ErrDeserializationNotEnoughData
+	}
+	return binary.LittleEndian.Uint16(data[offset:]), nil
+}
+
+func viewReadUint32(data []byte, offset int) (uint32, error) {
+	if offset+serializer.UInt32ByteSize > len(data) {
+		return 0, serializer.ErrDeserializationNotEnoughData
+	}
+	return binary.LittleEndian.Uint32(data[offset:]), nil
+}
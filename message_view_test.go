@@ -0,0 +1,277 @@
+package iotago_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+// checks that the given MessageView exposes the same data as the given Message.
+func assertMessageViewEqualsMessage(t *testing.T, msg *iotago.Message, view iotago.MessageView) {
+	assert.Equal(t, msg.MustID(), view.ID())
+	assert.Equal(t, msg.NetworkID, view.NetworkID())
+	assert.Equal(t, msg.Nonce, view.Nonce())
+	require.Equal(t, len(msg.Parents), view.ParentsCount())
+	for i, parent := range msg.Parents {
+		assert.Equal(t, parent, view.Parent(i))
+	}
+
+	payloadType, hasPayload := view.PayloadType()
+	switch payload := msg.Payload.(type) {
+	case nil:
+		assert.False(t, hasPayload)
+		assert.Nil(t, view.PayloadBytes())
+	case *iotago.Indexation:
+		assert.True(t, hasPayload)
+		assert.Equal(t, iotago.IndexationPayloadTypeID, payloadType)
+		indexationView, ok := view.Indexation()
+		require.True(t, ok)
+		assert.EqualValues(t, payload.Index, indexationView.Index())
+		assert.EqualValues(t, payload.Data, indexationView.Data())
+	case *iotago.Milestone:
+		assert.True(t, hasPayload)
+		assert.Equal(t, iotago.MilestonePayloadTypeID, payloadType)
+		msIndex, ok := view.MilestoneIndex()
+		require.True(t, ok)
+		assert.Equal(t, payload.Index, msIndex)
+		msID, err := payload.ID()
+		require.NoError(t, err)
+		viewMsID, ok := view.MilestoneID()
+		require.True(t, ok)
+		assert.Equal(t, *msID, viewMsID)
+	case *iotago.Transaction:
+		assert.True(t, hasPayload)
+		assert.Equal(t, iotago.TransactionPayloadTypeID, payloadType)
+		txView, ok := view.Transaction()
+		require.True(t, ok)
+
+		txID, err := payload.ID()
+		require.NoError(t, err)
+		assert.Equal(t, *txID, txView.ID())
+
+		essence := payload.Essence.(*iotago.TransactionEssence)
+		essenceBytes, err := essence.Serialize(serializer.DeSeriModeNoValidation)
+		require.NoError(t, err)
+		assert.Equal(t, essenceBytes, txView.EssenceBytes())
+
+		require.Equal(t, len(essence.Inputs), txView.InputsCount())
+		for i, input := range essence.Inputs {
+			assert.Equal(t, input.(*iotago.UTXOInput).ID(), txView.Input(i))
+		}
+
+		require.Equal(t, len(essence.Outputs), txView.OutputsCount())
+		for i, output := range essence.Outputs {
+			out := output.(iotago.Output)
+			outView := txView.Output(i)
+			assert.Equal(t, out.Type(), outView.Type())
+			deposit, err := out.Deposit()
+			require.NoError(t, err)
+			assert.Equal(t, deposit, outView.Amount())
+			target, err := out.Target()
+			require.NoError(t, err)
+			assert.Equal(t, target.(iotago.Address).Type(), outView.AddressType())
+			assert.Equal(t, *target.(*iotago.Ed25519Address), outView.Ed25519Address())
+			utxoInput := &iotago.UTXOInput{TransactionID: *txID, TransactionOutputIndex: uint16(i)}
+			assert.Equal(t, utxoInput.ID(), txView.OutputID(i))
+		}
+
+		indexationView, hasIndexation := txView.Indexation()
+		if essence.Payload == nil {
+			assert.False(t, hasIndexation)
+			break
+		}
+		require.True(t, hasIndexation)
+		assert.EqualValues(t, essence.Payload.(*iotago.Indexation).Index, indexationView.Index())
+		assert.EqualValues(t, essence.Payload.(*iotago.Indexation).Data, indexationView.Data())
+	}
+}
+
+func TestMessageView(t *testing.T) {
+	type test struct {
+		name   string
+		source *iotago.Message
+		data   []byte
+	}
+
+	tests := []test{
+		func() test {
+			msg, msgData := randMessage(t, 1337)
+			return test{"ok - no payload", msg, msgData}
+		}(),
+		func() test {
+			msg, msgData := randMessage(t, iotago.TransactionPayloadTypeID)
+			return test{"ok - transaction payload", msg, msgData}
+		}(),
+		func() test {
+			msg, msgData := randMessage(t, iotago.MilestonePayloadTypeID)
+			return test{"ok - milestone payload", msg, msgData}
+		}(),
+		func() test {
+			msg, msgData := randMessage(t, iotago.IndexationPayloadTypeID)
+			return test{"ok - indexation payload", msg, msgData}
+		}(),
+		func() test {
+			tx := tpkg.OneInputOutputTransaction()
+			indexation, _ := tpkg.RandIndexation()
+			tx.Essence.(*iotago.TransactionEssence).Payload = indexation
+			msg := &iotago.Message{NetworkID: 1, Parents: tpkg.SortedRand32BytArray(2), Payload: tx}
+			msgData, err := msg.Serialize(serializer.DeSeriModePerformValidation)
+			require.NoError(t, err)
+			return test{"ok - transaction with indexation", msg, msgData}
+		}(),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, err := iotago.NewMessageView(tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.data, view.Bytes())
+			assertMessageViewEqualsMessage(t, tt.source, view)
+		})
+	}
+}
+
+func TestMessageView_Invalid(t *testing.T) {
+	type test struct {
+		name string
+		data []byte
+		err  error
+	}
+
+	tests := []test{
+		{"not enough data", []byte{1, 2, 3}, serializer.ErrDeserializationNotEnoughData},
+		func() test {
+			_, msgData := tpkg.RandMessage(iotago.IndexationPayloadTypeID)
+			return test{"left over data", append(msgData, 0), serializer.ErrDeserializationLengthInvalid}
+		}(),
+		func() test {
+			_, msgData := tpkg.RandMessage(1337)
+			msgData[iotago.MessageNetworkIDLength] = 0
+			return test{"no parents", msgData, serializer.ErrDeserializationLengthInvalid}
+		}(),
+		func() test {
+			treasuryTx, _ := tpkg.RandTreasuryTransaction()
+			msg := &iotago.Message{Parents: tpkg.SortedRand32BytArray(1), Payload: treasuryTx}
+			msgData, err := msg.Serialize(serializer.DeSeriModeNoValidation)
+			require.NoError(t, err)
+			return test{"unsupported payload", msgData, iotago.ErrUnsupportedPayloadType}
+		}(),
+		func() test {
+			msg := &iotago.Message{Parents: tpkg.SortedRand32BytArray(1), Payload: &iotago.Indexation{Index: make([]byte, 65)}}
+			msgData, err := msg.Serialize(serializer.DeSeriModeNoValidation)
+			require.NoError(t, err)
+			return test{"indexation index too big", msgData, iotago.ErrIndexationIndexExceedsMaxSize}
+		}(),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := iotago.NewMessageView(tt.data)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.err), err)
+		})
+	}
+}
+
+// returns a random message with the given payload type. Unlike tpkg.RandMessage, the outputs of a
+// transaction payload always have a deposit greater than zero, so the message passes validation.
+func randMessage(t *testing.T, payloadType uint32) (*iotago.Message, []byte) {
+	if payloadType != iotago.TransactionPayloadTypeID {
+		return tpkg.RandMessage(payloadType)
+	}
+
+	essence := &iotago.TransactionEssence{}
+	unlockBlocks := serializer.Serializables{}
+	for i := rand.Intn(10) + 1; i > 0; i-- {
+		input, _ := tpkg.RandUTXOInput()
+		essence.Inputs = append(essence.Inputs, input)
+		unlockBlock, _ := tpkg.RandEd25519SignatureUnlockBlock()
+		unlockBlocks = append(unlockBlocks, unlockBlock)
+	}
+	for i := rand.Intn(10) + 1; i > 0; i-- {
+		addr, _ := tpkg.RandEd25519Address()
+		essence.Outputs = append(essence.Outputs, &iotago.SigLockedSingleOutput{Address: addr, Amount: uint64(rand.Intn(10000) + 1)})
+	}
+
+	msg := &iotago.Message{
+		NetworkID: 1,
+		Parents:   tpkg.SortedRand32BytArray(1 + rand.Intn(7)),
+		Payload:   &iotago.Transaction{Essence: essence, UnlockBlocks: unlockBlocks},
+		Nonce:     uint64(rand.Intn(1000)),
+	}
+	msgData, err := msg.Serialize(serializer.DeSeriModePerformValidation | serializer.DeSeriModePerformLexicalOrdering)
+	require.NoError(t, err)
+
+	// deserialize again to get the inputs and outputs in their serialized order
+	msg = &iotago.Message{}
+	_, err = msg.Deserialize(msgData, serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	return msg, msgData
+}
+
+// differential test: for any input the view must not panic and must agree with Message.Deserialize.
+func TestMessageView_DifferentialFuzzing(t *testing.T) {
+	payloadTypes := []uint32{1337, iotago.TransactionPayloadTypeID, iotago.MilestonePayloadTypeID, iotago.IndexationPayloadTypeID}
+
+	for i := 0; i < 5000; i++ {
+		_, msgData := randMessage(t, payloadTypes[rand.Intn(len(payloadTypes))])
+
+		// mutate some of the bytes in most of the cases
+		if rand.Intn(5) != 0 {
+			for j := rand.Intn(4) + 1; j > 0; j-- {
+				msgData[rand.Intn(len(msgData))] = byte(rand.Intn(256))
+			}
+			if rand.Intn(10) == 0 {
+				msgData = msgData[:rand.Intn(len(msgData))]
+			}
+		}
+
+		require.NotPanics(t, func() {
+			view, viewErr := iotago.NewMessageView(msgData)
+
+			msg := &iotago.Message{}
+			if _, err := msg.Deserialize(msgData, serializer.DeSeriModePerformValidation); err != nil {
+				return
+			}
+
+			// everything the deserializer accepts must be accepted by the view too
+			require.NoError(t, viewErr)
+			assertMessageViewEqualsMessage(t, msg, view)
+		})
+	}
+}
+
+func TestMessageView_ZeroAllocs(t *testing.T) {
+	tx := tpkg.OneInputOutputTransaction()
+	indexation, _ := tpkg.RandIndexation()
+	tx.Essence.(*iotago.TransactionEssence).Payload = indexation
+	msg := &iotago.Message{NetworkID: 1, Parents: tpkg.SortedRand32BytArray(2), Payload: tx}
+	msgData, err := msg.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		view, err := iotago.NewMessageView(msgData)
+		if err != nil {
+			panic(err)
+		}
+		_ = view.ID()
+		_ = view.Parent(0)
+		txView, _ := view.Transaction()
+		_ = txView.ID()
+		_ = txView.Input(0)
+		_ = txView.Output(0).Ed25519Address()
+		_ = txView.Output(0).Amount()
+		_ = txView.OutputID(0)
+		indexationView, _ := txView.Indexation()
+		_ = indexationView.Data()
+	})
+	assert.Zero(t, allocs)
+}
@@ -3,8 +3,13 @@ package iotago
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+
 	"github.com/iotaledger/hive.go/serializer"
+	legacy "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/encoding/t5b1"
+	"github.com/iotaledger/iota.go/trinary"
 )
 
 const (
@@ -15,6 +20,53 @@ const (
 // LegacyTailTransactionHash represents the bytes of a T5B1 encoded legacy tail transaction hash.
 type LegacyTailTransactionHash = [49]byte
 
+var (
+	// ErrInvalidLegacyTailTransactionHash gets returned when a legacy tail transaction hash is neither
+	// valid trytes of a hash nor a valid T5B1 encoding of such.
+	ErrInvalidLegacyTailTransactionHash = errors.New("invalid legacy tail transaction hash")
+)
+
+// LegacyTailTransactionHashFromTrytes converts the given 81 trytes of a legacy tail transaction hash
+// to its T5B1 encoded form.
+func LegacyTailTransactionHashFromTrytes(trytes trinary.Trytes) (LegacyTailTransactionHash, error) {
+	var hash LegacyTailTransactionHash
+	if len(trytes) != legacy.HashTrytesSize {
+		return hash, fmt.Errorf("%w: trytes must be of length %d but is %d", ErrInvalidLegacyTailTransactionHash, legacy.HashTrytesSize, len(trytes))
+	}
+	if err := trinary.ValidTrytes(trytes); err != nil {
+		return hash, fmt.Errorf("%w: %s", ErrInvalidLegacyTailTransactionHash, err)
+	}
+	t5b1.Encode(hash[:], trinary.MustTrytesToTrits(trytes))
+	return hash, nil
+}
+
+// LegacyTailTransactionHashToTrytes converts the given T5B1 encoded legacy tail transaction hash
+// to its 81 trytes form.
+func LegacyTailTransactionHashToTrytes(hash LegacyTailTransactionHash) (trinary.Trytes, error) {
+	trits := make(trinary.Trits, t5b1.DecodedLen(len(hash)))
+	if _, err := t5b1.Decode(trits, hash[:]); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidLegacyTailTransactionHash, err)
+	}
+	// the 243 trits of a hash are padded to 245 trits by the encoding
+	for _, trit := range trits[legacy.HashTrinarySize:] {
+		if trit != 0 {
+			return "", fmt.Errorf("%w: T5B1 encoding is not zero padded", ErrInvalidLegacyTailTransactionHash)
+		}
+	}
+	return trinary.MustTritsToTrytes(trits[:legacy.HashTrinarySize]), nil
+}
+
+// ValidLegacyTailTransactionHash checks whether the given bytes are a valid T5B1 encoding of a legacy tail transaction hash.
+func ValidLegacyTailTransactionHash(hash LegacyTailTransactionHash) error {
+	_, err := LegacyTailTransactionHashToTrytes(hash)
+	return err
+}
+
+// TailTransactionHashTrytes returns the tail transaction hash of the MigratedFundsEntry in its 81 trytes form.
+func (m *MigratedFundsEntry) TailTransactionHashTrytes() (trinary.Trytes, error) {
+	return LegacyTailTransactionHashToTrytes(m.TailTransactionHash)
+}
+
 // MigratedFundsEntry are funds which were migrated from a legacy network.
 type MigratedFundsEntry struct {
 	// The tail transaction hash of the migration bundle.
@@ -54,6 +106,14 @@ func (m *MigratedFundsEntry) Serialize(deSeriMode serializer.DeSerializationMode
 }
 
 func (m *MigratedFundsEntry) MarshalJSON() ([]byte, error) {
+	jMigratedFundsEntry, err := m.toJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jMigratedFundsEntry)
+}
+
+func (m *MigratedFundsEntry) toJSON() (*jsonMigratedFundsEntry, error) {
 	jMigratedFundsEntry := &jsonMigratedFundsEntry{}
 	jMigratedFundsEntry.TailTransactionHash = hex.EncodeToString(m.TailTransactionHash[:])
 	addrJsonBytes, err := m.Address.MarshalJSON()
@@ -63,7 +123,23 @@ func (m *MigratedFundsEntry) MarshalJSON() ([]byte, error) {
 	jsonRawMsgAddr := json.RawMessage(addrJsonBytes)
 	jMigratedFundsEntry.Address = &jsonRawMsgAddr
 	jMigratedFundsEntry.Deposit = int(m.Deposit)
+	return jMigratedFundsEntry, nil
+}
 
+// MigratedFundsEntryWithTrytes wraps a MigratedFundsEntry whose JSON representation additionally contains
+// the tail transaction hash in its trytes form. The representation can be unmarshaled into a MigratedFundsEntry.
+type MigratedFundsEntryWithTrytes struct {
+	*MigratedFundsEntry
+}
+
+func (m MigratedFundsEntryWithTrytes) MarshalJSON() ([]byte, error) {
+	jMigratedFundsEntry, err := m.toJSON()
+	if err != nil {
+		return nil, err
+	}
+	if jMigratedFundsEntry.TailTransactionHashTrytes, err = m.TailTransactionHashTrytes(); err != nil {
+		return nil, err
+	}
 	return json.Marshal(jMigratedFundsEntry)
 }
 
@@ -81,19 +157,40 @@ func (m *MigratedFundsEntry) UnmarshalJSON(bytes []byte) error {
 }
 
 // jsonMigratedFundsEntry defines the json representation of a MigratedFundsEntry.
+// The tail transaction hash can be given in its hex encoded T5B1 form, its trytes form or both.
 type jsonMigratedFundsEntry struct {
-	TailTransactionHash string           `json:"tailTransactionHash"`
-	Address             *json.RawMessage `json:"address"`
-	Deposit             int              `json:"deposit"`
+	TailTransactionHash       string           `json:"tailTransactionHash"`
+	TailTransactionHashTrytes string           `json:"tailTransactionHashTrytes,omitempty"`
+	Address                   *json.RawMessage `json:"address"`
+	Deposit                   int              `json:"deposit"`
 }
 
 func (j *jsonMigratedFundsEntry) ToSerializable() (serializer.Serializable, error) {
 	payload := &MigratedFundsEntry{}
-	tailTransactionHash, err := hex.DecodeString(j.TailTransactionHash)
-	if err != nil {
-		return nil, fmt.Errorf("can't decode tail transaction hash for migrated funds entry from JSON: %w", err)
+	switch {
+	case j.TailTransactionHash != "":
+		tailTransactionHash, err := hex.DecodeString(j.TailTransactionHash)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode tail transaction hash for migrated funds entry from JSON: %w", err)
+		}
+		copy(payload.TailTransactionHash[:], tailTransactionHash)
+		if j.TailTransactionHashTrytes == "" {
+			break
+		}
+		tailTransactionHashFromTrytes, err := LegacyTailTransactionHashFromTrytes(j.TailTransactionHashTrytes)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode tail transaction hash trytes for migrated funds entry from JSON: %w", err)
+		}
+		if tailTransactionHashFromTrytes != payload.TailTransactionHash {
+			return nil, fmt.Errorf("%w: tail transaction hash and its trytes of migrated funds entry differ", ErrInvalidJSON)
+		}
+	case j.TailTransactionHashTrytes != "":
+		tailTransactionHash, err := LegacyTailTransactionHashFromTrytes(j.TailTransactionHashTrytes)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode tail transaction hash trytes for migrated funds entry from JSON: %w", err)
+		}
+		payload.TailTransactionHash = tailTransactionHash
 	}
-	copy(payload.TailTransactionHash[:], tailTransactionHash)
 	payload.Deposit = uint64(j.Deposit)
 	jsonAddr, err := DeserializeObjectFromJSON(j.Address, jsonAddressSelector)
 	if err != nil {
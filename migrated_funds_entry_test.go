@@ -1,9 +1,14 @@
 package iotago_test
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/encoding/t5b1"
 	"github.com/iotaledger/iota.go/v2/tpkg"
+	"strings"
 	"testing"
 
 	"github.com/iotaledger/iota.go/v2"
@@ -59,3 +64,71 @@ func TestMigratedFundsEntry_Serialize(t *testing.T) {
 		})
 	}
 }
+
+func TestLegacyTailTransactionHashTrytes(t *testing.T) {
+	const trytes = "RRRUGYOEYFDFWFMQZEBMCYCKTEKIOTOIEUXLGQUVBVLFIFUUEXQNMJRRCJUCKORQLFSHIHTIZTZB99999"
+	hash, err := iotago.LegacyTailTransactionHashFromTrytes(trytes)
+	assert.NoError(t, err)
+	assert.NoError(t, iotago.ValidLegacyTailTransactionHash(hash))
+	assert.Equal(t, t5b1.EncodeTrytes(trytes), hash[:])
+
+	hashTrytes, err := iotago.LegacyTailTransactionHashToTrytes(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, trytes, hashTrytes)
+
+	for _, invalid := range []string{trytes[:80], trytes + "9", strings.ToLower(trytes)} {
+		_, err = iotago.LegacyTailTransactionHashFromTrytes(invalid)
+		assert.True(t, errors.Is(err, iotago.ErrInvalidLegacyTailTransactionHash))
+	}
+
+	// not a T5B1 encoded byte
+	invalidHash := hash
+	invalidHash[0] = 122
+	assert.True(t, errors.Is(iotago.ValidLegacyTailTransactionHash(invalidHash), iotago.ErrInvalidLegacyTailTransactionHash))
+
+	// the padding trits of the last byte must be zero
+	invalidHash = hash
+	invalidHash[48] = 81
+	_, err = iotago.LegacyTailTransactionHashToTrytes(invalidHash)
+	assert.True(t, errors.Is(err, iotago.ErrInvalidLegacyTailTransactionHash))
+}
+
+func TestMigratedFundsEntry_JSONTrytes(t *testing.T) {
+	hash, trytes := tpkg.RandLegacyTailTransactionHash()
+	addr, _ := tpkg.RandEd25519Address()
+	entry := &iotago.MigratedFundsEntry{TailTransactionHash: hash, Address: addr, Deposit: 1_000_000}
+
+	// the trytes are only emitted on request
+	data, err := json.Marshal(entry)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "tailTransactionHashTrytes")
+
+	data, err = json.Marshal(iotago.MigratedFundsEntryWithTrytes{MigratedFundsEntry: entry})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), fmt.Sprintf(`"tailTransactionHashTrytes":"%s"`, trytes))
+	assert.Contains(t, string(data), fmt.Sprintf(`"tailTransactionHash":"%s"`, hex.EncodeToString(hash[:])))
+
+	fromJSON := &iotago.MigratedFundsEntry{}
+	assert.NoError(t, json.Unmarshal(data, fromJSON))
+	assert.EqualValues(t, entry, fromJSON)
+
+	addrJSON, err := addr.MarshalJSON()
+	assert.NoError(t, err)
+
+	fromTrytes := &iotago.MigratedFundsEntry{}
+	assert.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(`{"tailTransactionHashTrytes":"%s","address":%s,"deposit":1000000}`, trytes, addrJSON)), fromTrytes))
+	assert.EqualValues(t, entry, fromTrytes)
+
+	otherHash, _ := tpkg.RandLegacyTailTransactionHash()
+	err = json.Unmarshal([]byte(fmt.Sprintf(`{"tailTransactionHash":"%s","tailTransactionHashTrytes":"%s","address":%s,"deposit":1000000}`,
+		hex.EncodeToString(otherHash[:]), trytes, addrJSON)), &iotago.MigratedFundsEntry{})
+	assert.True(t, errors.Is(err, iotago.ErrInvalidJSON))
+
+	err = json.Unmarshal([]byte(fmt.Sprintf(`{"tailTransactionHashTrytes":"%s","address":%s,"deposit":1000000}`, trytes[:80], addrJSON)), &iotago.MigratedFundsEntry{})
+	assert.True(t, errors.Is(err, iotago.ErrInvalidLegacyTailTransactionHash))
+
+	// hashes which are not valid T5B1 have no trytes form
+	entry.TailTransactionHash[0] = 122
+	_, err = json.Marshal(iotago.MigratedFundsEntryWithTrytes{MigratedFundsEntry: entry})
+	assert.True(t, errors.Is(err, iotago.ErrInvalidLegacyTailTransactionHash))
+}
@@ -0,0 +1,221 @@
+package iotago
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/iotaledger/hive.go/serializer"
+)
+
+var (
+	// ErrTreasuryInsufficient gets returned when the treasury does not hold enough funds for the migrated funds.
+	ErrTreasuryInsufficient = errors.New("treasury holds insufficient funds")
+	// ErrInvalidReceiptChain gets returned when a chain of receipts is invalid.
+	ErrInvalidReceiptChain = errors.New("invalid receipt chain")
+)
+
+// BuildMigrationReceipts chunks the given MigratedFundsEntry items into receipts holding at most
+// MaxMigratedFundsEntryCount entries each, of which the last one is marked as final.
+//
+// Every receipt carries a TreasuryTransaction consuming the TreasuryOutput of the previous receipt. Only the first
+// one references the given treasury milestone ID, as the following ones must reference the milestone which includes
+// the previous receipt, which has to be set via Receipt.LinkTreasury once that milestone is known.
+func BuildMigrationReceipts(migratedAt uint32, treasuryMilestoneID MilestoneID, treasury *TreasuryOutput, entries []*MigratedFundsEntry) ([]*Receipt, error) {
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: no migrated funds given", ErrInvalidReceipt)
+	}
+
+	funds := make(serializer.Serializables, len(entries))
+	for i, entry := range entries {
+		funds[i] = entry
+	}
+	sort.Sort(serializer.SortedSerializables(funds))
+
+	receipts := make([]*Receipt, 0, (len(funds)+MaxMigratedFundsEntryCount-1)/MaxMigratedFundsEntryCount)
+	remaining := treasury.Amount
+	for len(funds) > 0 {
+		n := len(funds)
+		if n > MaxMigratedFundsEntryCount {
+			n = MaxMigratedFundsEntryCount
+		}
+
+		receiptBuilder := NewReceiptBuilder(migratedAt)
+		var sum uint64
+		for _, fund := range funds[:n] {
+			entry := fund.(*MigratedFundsEntry)
+			receiptBuilder.AddEntry(entry)
+			sum += entry.Deposit
+		}
+		if sum > remaining {
+			return nil, fmt.Errorf("%w: receipt %d migrates %d but the treasury only holds %d", ErrTreasuryInsufficient, len(receipts), sum, remaining)
+		}
+		remaining -= sum
+
+		treasuryInput := &TreasuryInput{}
+		if len(receipts) == 0 {
+			copy(treasuryInput[:], treasuryMilestoneID[:])
+		}
+		receiptBuilder.AddTreasuryTransaction(&TreasuryTransaction{Input: treasuryInput, Output: &TreasuryOutput{Amount: remaining}})
+
+		receipt, err := receiptBuilder.Build()
+		if err != nil {
+			return nil, fmt.Errorf("unable to build receipt %d: %w", len(receipts), err)
+		}
+		receipts = append(receipts, receipt)
+		funds = funds[n:]
+	}
+	receipts[len(receipts)-1].Final = true
+
+	if err := ValidateReceiptChain(treasury, receipts...); err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// LinkTreasury sets the input of the receipt's TreasuryTransaction to the given ID of the milestone
+// which generated the TreasuryOutput to consume.
+// This function panics if the receipt does not contain a TreasuryTransaction.
+func (r *Receipt) LinkTreasury(milestoneID MilestoneID) {
+	treasuryInput := &TreasuryInput{}
+	copy(treasuryInput[:], milestoneID[:])
+	r.Treasury().Input = treasuryInput
+}
+
+// MigratedAtTotal are the totals of the receipts of one legacy milestone index.
+type MigratedAtTotal struct {
+	// The milestone index at which the funds were migrated in the legacy network.
+	MigratedAt uint32 `json:"migratedAt"`
+	// The amount of receipts.
+	Receipts int `json:"receipts"`
+	// The amount of migrated funds entries.
+	Entries int `json:"entries"`
+	// The sum of the migrated funds.
+	Amount uint64 `json:"amount"`
+	// Whether the final receipt was issued.
+	Final bool `json:"final"`
+}
+
+// ReceiptAudit summarizes a chain of receipts.
+type ReceiptAudit struct {
+	// The amount of the treasury before the first receipt.
+	InitialTreasury uint64 `json:"initialTreasury"`
+	// The amount of the treasury after the last receipt.
+	RemainingTreasury uint64 `json:"remainingTreasury"`
+	// The sum of all migrated funds.
+	Migrated uint64 `json:"migrated"`
+	// The amount of receipts.
+	Receipts int `json:"receipts"`
+	// The amount of migrated funds entries.
+	Entries int `json:"entries"`
+	// The totals per legacy milestone index in the order of the receipts.
+	MigratedAt []*MigratedAtTotal `json:"migratedAt"`
+	// The sum of the migrated funds per hex encoded target address.
+	Addresses map[string]uint64 `json:"addresses"`
+}
+
+// receiptChain tracks the order of the migrated at indices and the migrated legacy tail transaction hashes
+// of a chain of receipts.
+type receiptChain struct {
+	// the format describing the position of a receipt in the chain, such as "receipt %d"
+	positionFormat string
+	// the totals per migrated at index
+	migratedAt []*MigratedAtTotal
+	// the position of the receipt which migrated a tail transaction hash
+	seenTailTxHashes map[LegacyTailTransactionHash]uint32
+}
+
+func newReceiptChain(positionFormat string) *receiptChain {
+	return &receiptChain{positionFormat: positionFormat, seenTailTxHashes: make(map[LegacyTailTransactionHash]uint32)}
+}
+
+// add adds the given receipt at the given position of the chain to the totals and returns the errors of its tail
+// transaction hashes already migrated by previous receipts, and the error of the receipt violating the order of
+// migrated at indices and final receipts. A receipt preceding the current migrated at index is not added to the totals.
+func (c *receiptChain) add(receipt *Receipt, position uint32) ([]error, error) {
+	var orderErr error
+	var current *MigratedAtTotal
+	if len(c.migratedAt) > 0 {
+		current = c.migratedAt[len(c.migratedAt)-1]
+	}
+	switch {
+	case current == nil || receipt.MigratedAt > current.MigratedAt:
+		if current != nil && !current.Final {
+			orderErr = fmt.Errorf("%w: receipt migrated at %d follows non final receipts migrated at %d", ErrInvalidReceiptChain, receipt.MigratedAt, current.MigratedAt)
+		}
+		current = &MigratedAtTotal{MigratedAt: receipt.MigratedAt}
+		c.migratedAt = append(c.migratedAt, current)
+	case receipt.MigratedAt < current.MigratedAt:
+		orderErr = fmt.Errorf("%w: receipt migrated at %d precedes previous receipts migrated at %d", ErrInvalidReceiptChain, receipt.MigratedAt, current.MigratedAt)
+	case current.Final:
+		orderErr = fmt.Errorf("%w: receipt follows the final receipt migrated at %d", ErrInvalidReceiptChain, current.MigratedAt)
+	}
+	if receipt.MigratedAt >= current.MigratedAt {
+		current.Receipts++
+		current.Entries += len(receipt.Funds)
+		current.Amount += receipt.Sum()
+		current.Final = current.Final || receipt.Final
+	}
+
+	var duplicateErrs []error
+	for _, fund := range receipt.Funds {
+		entry := fund.(*MigratedFundsEntry)
+		if prevPosition, seen := c.seenTailTxHashes[entry.TailTransactionHash]; seen && prevPosition != position {
+			duplicateErrs = append(duplicateErrs, fmt.Errorf("%w: tail transaction hash %s was already migrated by "+c.positionFormat,
+				ErrInvalidReceiptChain, hex.EncodeToString(entry.TailTransactionHash[:]), prevPosition))
+			continue
+		}
+		c.seenTailTxHashes[entry.TailTransactionHash] = position
+	}
+	return duplicateErrs, orderErr
+}
+
+// AuditReceipts validates the given chain of receipts as described by ValidateReceiptChain and summarizes it.
+func AuditReceipts(prevTreasuryOutput *TreasuryOutput, receipts ...*Receipt) (*ReceiptAudit, error) {
+	audit := &ReceiptAudit{
+		InitialTreasury:   prevTreasuryOutput.Amount,
+		RemainingTreasury: prevTreasuryOutput.Amount,
+		Addresses:         make(map[string]uint64),
+	}
+
+	chain := newReceiptChain("receipt %d")
+	for i, receipt := range receipts {
+		if err := ValidateReceipt(receipt, &TreasuryOutput{Amount: audit.RemainingTreasury}); err != nil {
+			return nil, fmt.Errorf("receipt %d of chain: %w", i, err)
+		}
+		duplicateErrs, orderErr := chain.add(receipt, uint32(i))
+		if orderErr != nil {
+			return nil, fmt.Errorf("receipt %d of chain: %w", i, orderErr)
+		}
+		if len(duplicateErrs) > 0 {
+			return nil, fmt.Errorf("receipt %d of chain: %w", i, duplicateErrs[0])
+		}
+
+		for _, fund := range receipt.Funds {
+			entry := fund.(*MigratedFundsEntry)
+			audit.Addresses[entry.Address.(fmt.Stringer).String()] += entry.Deposit
+		}
+		audit.Receipts++
+		audit.Entries += len(receipt.Funds)
+		audit.Migrated += receipt.Sum()
+		audit.RemainingTreasury = receipt.Treasury().Output.(*TreasuryOutput).Amount
+	}
+	audit.MigratedAt = chain.migratedAt
+
+	return audit, nil
+}
+
+// ValidateReceiptChain validates whether the given receipts form a chain, given the following:
+//	- Every receipt is valid as described by ValidateReceipt, consuming the TreasuryOutput of the previous receipt.
+//	  The first one consumes the given TreasuryOutput.
+//	- The migrated at indices of the receipts never decrease.
+//	- No receipt follows the final receipt of a migrated at index and only final receipts are followed
+//	  by receipts of a higher migrated at index.
+//	- No legacy tail transaction hash is migrated twice.
+// The inputs of the TreasuryTransactions are not checked as the IDs of the milestones including the receipts are unknown.
+// This function panics if the given treasury output is nil.
+func ValidateReceiptChain(prevTreasuryOutput *TreasuryOutput, receipts ...*Receipt) error {
+	_, err := AuditReceipts(prevTreasuryOutput, receipts...)
+	return err
+}
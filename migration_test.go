@@ -0,0 +1,108 @@
+package iotago_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+// returns n migrated funds entries depositing the given amount to the given address.
+func randMigratedFundsEntries(n int, addr *iotago.Ed25519Address, deposit uint64) []*iotago.MigratedFundsEntry {
+	entries := make([]*iotago.MigratedFundsEntry, n)
+	for i := range entries {
+		entries[i] = &iotago.MigratedFundsEntry{TailTransactionHash: tpkg.Rand49ByteArray(), Address: addr, Deposit: deposit}
+	}
+	return entries
+}
+
+func TestBuildMigrationReceipts(t *testing.T) {
+	addr1, _ := tpkg.RandEd25519Address()
+	addr2, _ := tpkg.RandEd25519Address()
+	entries := append(randMigratedFundsEntries(200, addr1, 1_000_000), randMigratedFundsEntries(100, addr2, 2_000_000)...)
+
+	treasuryMilestoneID := iotago.MilestoneID(tpkg.Rand32ByteArray())
+	treasury := &iotago.TreasuryOutput{Amount: 1_000_000_000}
+	receipts, err := iotago.BuildMigrationReceipts(1000, treasuryMilestoneID, treasury, entries)
+	require.NoError(t, err)
+	require.Len(t, receipts, 3)
+
+	remaining := treasury.Amount
+	for i, receipt := range receipts {
+		assert.EqualValues(t, 1000, receipt.MigratedAt)
+		assert.Equal(t, i == len(receipts)-1, receipt.Final)
+		remaining -= receipt.Sum()
+		assert.Equal(t, remaining, receipt.Treasury().Output.(*iotago.TreasuryOutput).Amount)
+		assert.NoError(t, iotago.ValidateReceipt(receipt, treasury))
+		treasury = receipt.Treasury().Output.(*iotago.TreasuryOutput)
+	}
+	assert.Len(t, receipts[0].Funds, iotago.MaxMigratedFundsEntryCount)
+	assert.Len(t, receipts[2].Funds, 300-2*iotago.MaxMigratedFundsEntryCount)
+	assert.Equal(t, iotago.TreasuryInput(treasuryMilestoneID), *receipts[0].Treasury().Input.(*iotago.TreasuryInput))
+
+	// the following receipts reference the milestone including the previous receipt
+	milestoneID := iotago.MilestoneID(tpkg.Rand32ByteArray())
+	receipts[1].LinkTreasury(milestoneID)
+	assert.Equal(t, iotago.TreasuryInput(milestoneID), *receipts[1].Treasury().Input.(*iotago.TreasuryInput))
+
+	audit, err := iotago.AuditReceipts(&iotago.TreasuryOutput{Amount: 1_000_000_000}, receipts...)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1_000_000_000, audit.InitialTreasury)
+	assert.EqualValues(t, 400_000_000, audit.Migrated)
+	assert.EqualValues(t, 600_000_000, audit.RemainingTreasury)
+	assert.Equal(t, 3, audit.Receipts)
+	assert.Equal(t, 300, audit.Entries)
+	assert.Equal(t, []*iotago.MigratedAtTotal{{MigratedAt: 1000, Receipts: 3, Entries: 300, Amount: 400_000_000, Final: true}}, audit.MigratedAt)
+	assert.Equal(t, map[string]uint64{addr1.String(): 200_000_000, addr2.String(): 200_000_000}, audit.Addresses)
+
+	_, err = iotago.BuildMigrationReceipts(1000, treasuryMilestoneID, &iotago.TreasuryOutput{Amount: 399_000_000}, entries)
+	assert.True(t, errors.Is(err, iotago.ErrTreasuryInsufficient))
+
+	_, err = iotago.BuildMigrationReceipts(1000, treasuryMilestoneID, treasury, []*iotago.MigratedFundsEntry{{TailTransactionHash: tpkg.Rand49ByteArray(), Address: addr1, Deposit: 1}})
+	assert.True(t, errors.Is(err, iotago.ErrInvalidReceipt))
+}
+
+func TestValidateReceiptChain(t *testing.T) {
+	addr, _ := tpkg.RandEd25519Address()
+	treasury := &iotago.TreasuryOutput{Amount: 100_000_000}
+	milestoneID := iotago.MilestoneID(tpkg.Rand32ByteArray())
+
+	build := func(migratedAt uint32, final bool, prevTreasury uint64, entries ...*iotago.MigratedFundsEntry) *iotago.Receipt {
+		receipts, err := iotago.BuildMigrationReceipts(migratedAt, milestoneID, &iotago.TreasuryOutput{Amount: prevTreasury}, entries)
+		require.NoError(t, err)
+		receipts[0].Final = final
+		return receipts[0]
+	}
+
+	entries := randMigratedFundsEntries(3, addr, 1_000_000)
+	nonFinal := build(1000, false, 100_000_000, entries[0])
+	final := build(1000, true, 99_000_000, entries[1])
+	next := build(1001, true, 98_000_000, entries[2])
+
+	tests := []struct {
+		name     string
+		receipts []*iotago.Receipt
+		err      error
+	}{
+		{"ok", []*iotago.Receipt{nonFinal, final, next}, nil},
+		{"err - after final", []*iotago.Receipt{nonFinal, final, build(1000, true, 98_000_000, entries[2])}, iotago.ErrInvalidReceiptChain},
+		{"err - previous not final", []*iotago.Receipt{nonFinal, build(1001, true, 99_000_000, entries[1])}, iotago.ErrInvalidReceiptChain},
+		{"err - decreasing migrated at", []*iotago.Receipt{nonFinal, build(999, true, 99_000_000, entries[1])}, iotago.ErrInvalidReceiptChain},
+		{"err - tail hash migrated twice", []*iotago.Receipt{nonFinal, build(1000, true, 99_000_000, entries[0])}, iotago.ErrInvalidReceiptChain},
+		{"err - treasury not chained", []*iotago.Receipt{nonFinal, build(1000, true, 100_000_000, entries[1])}, iotago.ErrInvalidReceipt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := iotago.ValidateReceiptChain(treasury, tt.receipts...)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -225,6 +225,10 @@ func (m *Milestone) VerifySignatures(minSigThreshold int, applicablePubKeys Mile
 		return fmt.Errorf("unable to compute milestone essence for signature verification: %w", err)
 	}
 
+	// the public keys are checked upfront and the signatures verified as a batch,
+	// invalid signatures are reported in the order of the public keys
+	errs := make([]error, len(m.PublicKeys))
+	batch := ed25519.NewBatchVerifier(len(m.PublicKeys))
 	seenPubKeys := make(map[MilestonePublicKey]int)
 	for msPubKeyIndex, msPubKey := range m.PublicKeys {
 		if prevIndex, ok := seenPubKeys[msPubKey]; ok {
@@ -235,13 +239,24 @@ func (m *Milestone) VerifySignatures(minSigThreshold int, applicablePubKeys Mile
 			return fmt.Errorf("%w: public key %s is not applicable", ErrMilestoneNonApplicablePublicKey, hex.EncodeToString(msPubKey[:]))
 		}
 
-		if ok := ed25519.Verify(msPubKey[:], msEssence[:], m.Signatures[msPubKeyIndex][:]); !ok {
-			return fmt.Errorf("%w: at index %d, checked against public key %s", ErrMilestoneInvalidSignature, msPubKeyIndex, hex.EncodeToString(msPubKey[:]))
-		}
-
+		batch.Add(m.PublicKeys[msPubKeyIndex][:], msEssence, m.Signatures[msPubKeyIndex][:])
 		seenPubKeys[msPubKey] = msPubKeyIndex
 	}
 
+	if allValid, valid := batch.Verify(); !allValid {
+		for msPubKeyIndex := range valid {
+			if !valid[msPubKeyIndex] {
+				msPubKey := m.PublicKeys[msPubKeyIndex]
+				errs[msPubKeyIndex] = fmt.Errorf("%w: at index %d, checked against public key %s", ErrMilestoneInvalidSignature, msPubKeyIndex, hex.EncodeToString(msPubKey[:]))
+			}
+		}
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
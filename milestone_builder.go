@@ -0,0 +1,229 @@
+package iotago
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	// ErrMilestoneBuilderMissingParents gets returned when a Milestone is built without parents.
+	ErrMilestoneBuilderMissingParents = errors.New("milestone has no parents")
+	// ErrMilestoneBuilderMissingSigner gets returned when a Milestone is built without a MilestoneSigningFunc.
+	ErrMilestoneBuilderMissingSigner = errors.New("milestone has no signing function")
+)
+
+const (
+	// the prefixes of the hashed data to distinguish leafs from nodes within the inclusion merkle tree.
+	merkleLeafHashPrefix = 0
+	merkleNodeHashPrefix = 1
+)
+
+// MilestoneInclusionMerkleProofFromMessageIDs computes the inclusion merkle proof over the given IDs of the messages
+// newly confirmed by a milestone, in the order they were applied to the ledger.
+// The merkle tree is constructed as defined by RFC 6962 using BLAKE2b-256, an empty set yields the hash of no data.
+func MilestoneInclusionMerkleProofFromMessageIDs(msgIDs MessageIDs) MilestoneInclusionMerkleProof {
+	return merkleTreeHash(msgIDs)
+}
+
+func merkleTreeHash(msgIDs MessageIDs) [blake2b.Size256]byte {
+	switch len(msgIDs) {
+	case 0:
+		return blake2b.Sum256(nil)
+	case 1:
+		return blake2b.Sum256(append([]byte{merkleLeafHashPrefix}, msgIDs[0][:]...))
+	}
+
+	// split at the largest power of two smaller than the amount of leafs
+	k := 1 << (bits.Len(uint(len(msgIDs)-1)) - 1)
+	left, right := merkleTreeHash(msgIDs[:k]), merkleTreeHash(msgIDs[k:])
+	data := make([]byte, 0, 1+2*blake2b.Size256)
+	data = append(data, merkleNodeHashPrefix)
+	data = append(data, left[:]...)
+	data = append(data, right[:]...)
+	return blake2b.Sum256(data)
+}
+
+// MilestonePublicKeyScheduleFunc is a function which returns the public keys which must sign the Milestone of the given index.
+type MilestonePublicKeyScheduleFunc func(index uint32) []MilestonePublicKey
+
+// NewMilestoneBuilder creates a new MilestoneBuilder for the Milestone of the given index.
+func NewMilestoneBuilder(index uint32) *MilestoneBuilder {
+	return &MilestoneBuilder{
+		ms: &Milestone{Index: index},
+	}
+}
+
+// MilestoneBuilder is used to easily build up a Milestone.
+type MilestoneBuilder struct {
+	ms          *Milestone
+	schedule    MilestonePublicKeyScheduleFunc
+	signingFunc MilestoneSigningFunc
+	err         error
+}
+
+// Timestamp sets the time at which the Milestone is issued. If not set, the time of Build is used.
+func (mb *MilestoneBuilder) Timestamp(timestamp time.Time) *MilestoneBuilder {
+	if mb.err != nil {
+		return mb
+	}
+	mb.ms.Timestamp = uint64(timestamp.Unix())
+	return mb
+}
+
+// Parents sets the parents of the Milestone.
+func (mb *MilestoneBuilder) Parents(parents MessageIDs) *MilestoneBuilder {
+	if mb.err != nil {
+		return mb
+	}
+	mb.ms.Parents = serializer.RemoveDupsAndSortByLexicalOrderArrayOf32Bytes(parents)
+	return mb
+}
+
+// Tips uses the given NodeHTTPAPIClient to query for parents to use.
+func (mb *MilestoneBuilder) Tips(ctx context.Context, nodeAPI *NodeHTTPAPIClient) *MilestoneBuilder {
+	if mb.err != nil {
+		return mb
+	}
+
+	res, err := nodeAPI.Tips(ctx)
+	if err != nil {
+		mb.err = fmt.Errorf("unable to fetch tips from node API: %w", err)
+		return mb
+	}
+
+	parents, err := res.Tips()
+	if err != nil {
+		mb.err = fmt.Errorf("unable to fetch tips: %w", err)
+		return mb
+	}
+
+	return mb.Parents(parents)
+}
+
+// InclusionMerkleProof sets the inclusion merkle proof of the Milestone.
+func (mb *MilestoneBuilder) InclusionMerkleProof(proof MilestoneInclusionMerkleProof) *MilestoneBuilder {
+	if mb.err != nil {
+		return mb
+	}
+	mb.ms.InclusionMerkleProof = proof
+	return mb
+}
+
+// ConfirmedMessageIDs computes the inclusion merkle proof of the Milestone from the given IDs of the newly
+// confirmed messages as described by MilestoneInclusionMerkleProofFromMessageIDs.
+func (mb *MilestoneBuilder) ConfirmedMessageIDs(msgIDs MessageIDs) *MilestoneBuilder {
+	return mb.InclusionMerkleProof(MilestoneInclusionMerkleProofFromMessageIDs(msgIDs))
+}
+
+// NextPoWScore sets the min. PoW score to use from the given milestone index onwards.
+func (mb *MilestoneBuilder) NextPoWScore(score uint32, index uint32) *MilestoneBuilder {
+	if mb.err != nil {
+		return mb
+	}
+	mb.ms.NextPoWScore = score
+	mb.ms.NextPoWScoreMilestoneIndex = index
+	return mb
+}
+
+// PublicKeys sets the public keys which sign the Milestone.
+// This function overrides a previously set MilestonePublicKeyScheduleFunc.
+func (mb *MilestoneBuilder) PublicKeys(pubKeys ...MilestonePublicKey) *MilestoneBuilder {
+	if mb.err != nil {
+		return mb
+	}
+	mb.schedule = func(uint32) []MilestonePublicKey { return pubKeys }
+	return mb
+}
+
+// PublicKeySchedule sets the MilestonePublicKeyScheduleFunc from which the public keys signing the Milestone are
+// derived by its index. This function overrides previously set public keys.
+func (mb *MilestoneBuilder) PublicKeySchedule(schedule MilestonePublicKeyScheduleFunc) *MilestoneBuilder {
+	if mb.err != nil {
+		return mb
+	}
+	mb.schedule = schedule
+	return mb
+}
+
+// Receipt attaches the given Receipt to the Milestone.
+func (mb *MilestoneBuilder) Receipt(receipt *Receipt) *MilestoneBuilder {
+	if mb.err != nil {
+		return mb
+	}
+	mb.ms.Receipt = receipt
+	return mb
+}
+
+// Sign sets the MilestoneSigningFunc which produces the signatures of the Milestone on Build.
+func (mb *MilestoneBuilder) Sign(signingFunc MilestoneSigningFunc) *MilestoneBuilder {
+	if mb.err != nil {
+		return mb
+	}
+	mb.signingFunc = signingFunc
+	return mb
+}
+
+// Build builds and signs the Milestone or returns any error which occurred during the build steps.
+func (mb *MilestoneBuilder) Build() (*Milestone, error) {
+	if mb.err != nil {
+		return nil, mb.err
+	}
+
+	switch {
+	case len(mb.ms.Parents) == 0:
+		return nil, ErrMilestoneBuilderMissingParents
+	case mb.signingFunc == nil:
+		return nil, ErrMilestoneBuilderMissingSigner
+	}
+
+	var pubKeys []MilestonePublicKey
+	if mb.schedule != nil {
+		pubKeys = serializer.RemoveDupsAndSortByLexicalOrderArrayOf32Bytes(mb.schedule(mb.ms.Index))
+	}
+
+	timestamp := mb.ms.Timestamp
+	if timestamp == 0 {
+		timestamp = uint64(time.Now().Unix())
+	}
+
+	ms, err := NewMilestone(mb.ms.Index, timestamp, mb.ms.Parents, mb.ms.InclusionMerkleProof, pubKeys)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build milestone %d: %w", mb.ms.Index, err)
+	}
+	ms.NextPoWScore = mb.ms.NextPoWScore
+	ms.NextPoWScoreMilestoneIndex = mb.ms.NextPoWScoreMilestoneIndex
+	ms.Receipt = mb.ms.Receipt
+
+	if err := ms.Sign(mb.signingFunc); err != nil {
+		return nil, fmt.Errorf("unable to sign milestone %d: %w", mb.ms.Index, err)
+	}
+
+	if _, err := ms.Serialize(serializer.DeSeriModePerformValidation); err != nil {
+		return nil, fmt.Errorf("unable to build milestone %d: %w", mb.ms.Index, err)
+	}
+	return ms, nil
+}
+
+// BuildMessage builds the Milestone and wraps it into a Message for the given network which uses the parents
+// of the Milestone and satisfies the given PoW target score. The proof-of-work is skipped for a target score of 0.
+func (mb *MilestoneBuilder) BuildMessage(ctx context.Context, networkID uint64, targetScore float64, numWorkers ...int) (*Message, error) {
+	ms, err := mb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	msgBuilder := NewMessageBuilder().
+		NetworkID(networkID).
+		ParentsMessageIDs(ms.Parents).
+		Payload(ms)
+	if targetScore > 0 {
+		msgBuilder.ProofOfWork(ctx, targetScore, numWorkers...)
+	}
+	return msgBuilder.Build()
+}
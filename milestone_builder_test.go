@@ -0,0 +1,143 @@
+package iotago_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+// returns n random milestone key pairs.
+func randMilestoneKeys(n int) ([]iotago.MilestonePublicKey, iotago.MilestonePublicKeyMapping) {
+	pubKeys := make([]iotago.MilestonePublicKey, n)
+	mapping := make(iotago.MilestonePublicKeyMapping, n)
+	for i := range pubKeys {
+		prvKey := tpkg.RandEd25519PrivateKey()
+		copy(pubKeys[i][:], prvKey.Public().(ed25519.PublicKey))
+		mapping[pubKeys[i]] = prvKey
+	}
+	return pubKeys, mapping
+}
+
+func TestMilestoneInclusionMerkleProofFromMessageIDs(t *testing.T) {
+	leaf := func(msgID iotago.MessageID) [32]byte {
+		return blake2b.Sum256(append([]byte{0}, msgID[:]...))
+	}
+	node := func(left, right [32]byte) [32]byte {
+		return blake2b.Sum256(append(append([]byte{1}, left[:]...), right[:]...))
+	}
+
+	msgIDs := iotago.MessageIDs{tpkg.Rand32ByteArray(), tpkg.Rand32ByteArray(), tpkg.Rand32ByteArray()}
+	assert.EqualValues(t, blake2b.Sum256(nil), iotago.MilestoneInclusionMerkleProofFromMessageIDs(nil))
+	assert.EqualValues(t, leaf(msgIDs[0]), iotago.MilestoneInclusionMerkleProofFromMessageIDs(msgIDs[:1]))
+	assert.EqualValues(t, node(leaf(msgIDs[0]), leaf(msgIDs[1])), iotago.MilestoneInclusionMerkleProofFromMessageIDs(msgIDs[:2]))
+	assert.EqualValues(t, node(node(leaf(msgIDs[0]), leaf(msgIDs[1])), leaf(msgIDs[2])), iotago.MilestoneInclusionMerkleProofFromMessageIDs(msgIDs))
+}
+
+func TestMilestoneBuilder(t *testing.T) {
+	pubKeys, prvKeys := randMilestoneKeys(3)
+	parents := tpkg.SortedRand32BytArray(3)
+	confirmed := iotago.MessageIDs{tpkg.Rand32ByteArray(), tpkg.Rand32ByteArray()}
+	receipt, _ := tpkg.RandReceipt()
+	timestamp := time.Unix(1_600_000_000, 0)
+
+	// rotates the first key at index 1000
+	schedule := func(index uint32) []iotago.MilestonePublicKey {
+		if index < 1000 {
+			return []iotago.MilestonePublicKey{pubKeys[0], pubKeys[1], pubKeys[1]}
+		}
+		return []iotago.MilestonePublicKey{pubKeys[2], pubKeys[1]}
+	}
+
+	ms, err := iotago.NewMilestoneBuilder(1000).
+		Timestamp(timestamp).
+		Parents(append(iotago.MessageIDs{parents[2], parents[0]}, parents...)).
+		ConfirmedMessageIDs(confirmed).
+		NextPoWScore(2000, 1100).
+		PublicKeySchedule(schedule).
+		Receipt(receipt).
+		Sign(iotago.InMemoryEd25519MilestoneSigner(prvKeys)).
+		Build()
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 1000, ms.Index)
+	assert.EqualValues(t, 1_600_000_000, ms.Timestamp)
+	assert.Equal(t, parents, ms.Parents)
+	assert.EqualValues(t, iotago.MilestoneInclusionMerkleProofFromMessageIDs(confirmed), ms.InclusionMerkleProof)
+	assert.EqualValues(t, 2000, ms.NextPoWScore)
+	assert.EqualValues(t, 1100, ms.NextPoWScoreMilestoneIndex)
+	assert.Equal(t, receipt, ms.Receipt)
+	assert.ElementsMatch(t, []iotago.MilestonePublicKey{pubKeys[1], pubKeys[2]}, ms.PublicKeys)
+	assert.NoError(t, ms.VerifySignatures(2, iotago.MilestonePublicKeySet{pubKeys[1]: {}, pubKeys[2]: {}}))
+
+	// duplicated keys of the schedule are removed
+	ms, err = iotago.NewMilestoneBuilder(999).
+		Parents(parents).
+		PublicKeySchedule(schedule).
+		Sign(iotago.InMemoryEd25519MilestoneSigner(prvKeys)).
+		Build()
+	require.NoError(t, err)
+	assert.Len(t, ms.PublicKeys, 2)
+	assert.NotZero(t, ms.Timestamp)
+	assert.NoError(t, ms.VerifySignatures(2, iotago.MilestonePublicKeySet{pubKeys[0]: {}, pubKeys[1]: {}}))
+}
+
+func TestMilestoneBuilder_Errors(t *testing.T) {
+	pubKeys, prvKeys := randMilestoneKeys(2)
+	parents := tpkg.SortedRand32BytArray(1)
+
+	_, err := iotago.NewMilestoneBuilder(1).PublicKeys(pubKeys...).Sign(iotago.InMemoryEd25519MilestoneSigner(prvKeys)).Build()
+	assert.True(t, errors.Is(err, iotago.ErrMilestoneBuilderMissingParents))
+
+	_, err = iotago.NewMilestoneBuilder(1).Parents(parents).PublicKeys(pubKeys...).Build()
+	assert.True(t, errors.Is(err, iotago.ErrMilestoneBuilderMissingSigner))
+
+	_, err = iotago.NewMilestoneBuilder(1).Parents(parents).Sign(iotago.InMemoryEd25519MilestoneSigner(prvKeys)).Build()
+	assert.True(t, errors.Is(err, iotago.ErrMilestoneTooFewPublicKeys))
+
+	otherPubKeys, _ := randMilestoneKeys(1)
+	_, err = iotago.NewMilestoneBuilder(1).Parents(parents).PublicKeys(otherPubKeys...).Sign(iotago.InMemoryEd25519MilestoneSigner(prvKeys)).Build()
+	assert.True(t, errors.Is(err, iotago.ErrMilestoneInMemorySignerPrivateKeyMissing))
+}
+
+func TestMilestoneBuilder_BuildMessage(t *testing.T) {
+	defer gock.Off()
+
+	const targetPoWScore float64 = 500
+
+	originRes := &iotago.NodeTipsResponse{TipsHex: []string{"a5c5b1b1d0b0e0d0f0a5c5b1b1d0b0e0d0f0a5c5b1b1d0b0e0d0f0a5c5b1b1d0", "17a1aa5c5b1b1d0b0e0d0f0a5c5b1b1d0b0e0d0f0a5c5b1b1d0b0e0d0f0a5c5b"}}
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteTips).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: originRes})
+
+	pubKeys, prvKeys := randMilestoneKeys(1)
+	msg, err := iotago.NewMilestoneBuilder(5).
+		Tips(context.Background(), iotago.NewNodeHTTPAPIClient(nodeAPIUrl)).
+		PublicKeys(pubKeys...).
+		Sign(iotago.InMemoryEd25519MilestoneSigner(prvKeys)).
+		BuildMessage(context.Background(), iotago.NetworkIDFromString("testnet"), targetPoWScore)
+	require.NoError(t, err)
+
+	ms := msg.Payload.(*iotago.Milestone)
+	assert.Len(t, ms.Parents, 2)
+	assert.Equal(t, ms.Parents, msg.Parents)
+	assert.Equal(t, iotago.NetworkIDFromString("testnet"), msg.NetworkID)
+
+	powScore, err := msg.POW()
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, powScore, targetPoWScore)
+
+	_, err = msg.Serialize(serializer.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,172 @@
+package iotago
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+var (
+	// ErrMilestoneKeyRangeInvalid gets returned when a MilestoneKeyRange is invalid.
+	ErrMilestoneKeyRangeInvalid = errors.New("invalid milestone key range")
+	// ErrMilestoneKeyManagerInsufficientKeys gets returned when not enough private keys are held
+	// to sign a Milestone with the applicable public keys.
+	ErrMilestoneKeyManagerInsufficientKeys = errors.New("insufficient applicable private keys")
+)
+
+// MilestoneKeyRange defines a public key which is applicable to the milestones of the given index range.
+type MilestoneKeyRange struct {
+	// The hex encoded public key.
+	PublicKey string `json:"key"`
+	// The first milestone index at which the public key is applicable.
+	StartIndex uint32 `json:"start"`
+	// The last milestone index at which the public key is applicable, 0 if it is applicable indefinitely.
+	EndIndex uint32 `json:"end"`
+}
+
+// MilestoneKeyManagerConfig defines the configuration of a MilestoneKeyManager.
+type MilestoneKeyManagerConfig struct {
+	// The amount of signatures a milestone must hold.
+	MinSignatures int `json:"milestonePublicKeyCount"`
+	// The key ranges of the public keys.
+	KeyRanges []*MilestoneKeyRange `json:"publicKeyRanges"`
+}
+
+// NewMilestoneKeyManagerFromConfig creates a new MilestoneKeyManager from the given config.
+func NewMilestoneKeyManagerFromConfig(config *MilestoneKeyManagerConfig) (*MilestoneKeyManager, error) {
+	km := NewMilestoneKeyManager(config.MinSignatures)
+	for i, keyRange := range config.KeyRanges {
+		pubKeyBytes, err := hex.DecodeString(keyRange.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to decode public key of key range %d: %s", ErrMilestoneKeyRangeInvalid, i, err)
+		}
+		if len(pubKeyBytes) != MilestonePublicKeyLength {
+			return nil, fmt.Errorf("%w: public key of key range %d must be %d bytes long but is %d", ErrMilestoneKeyRangeInvalid, i, MilestonePublicKeyLength, len(pubKeyBytes))
+		}
+		var pubKey MilestonePublicKey
+		copy(pubKey[:], pubKeyBytes)
+		if err := km.AddKeyRange(pubKey, keyRange.StartIndex, keyRange.EndIndex); err != nil {
+			return nil, fmt.Errorf("key range %d: %w", i, err)
+		}
+	}
+	return km, nil
+}
+
+// NewMilestoneKeyManager creates a new MilestoneKeyManager requiring the given amount of signatures per milestone.
+func NewMilestoneKeyManager(minSignatures int) *MilestoneKeyManager {
+	return &MilestoneKeyManager{minSignatures: minSignatures}
+}
+
+// MilestoneKeyManager holds the public keys which are applicable to milestones over time.
+type MilestoneKeyManager struct {
+	minSignatures int
+	keyRanges     []*milestoneKeyRange
+}
+
+type milestoneKeyRange struct {
+	pubKey     MilestonePublicKey
+	startIndex uint32
+	endIndex   uint32
+}
+
+// AddKeyRange adds the given public key as applicable to the milestones from startIndex to endIndex.
+// An endIndex of 0 makes the public key applicable indefinitely.
+func (km *MilestoneKeyManager) AddKeyRange(pubKey MilestonePublicKey, startIndex uint32, endIndex uint32) error {
+	if endIndex != 0 && endIndex < startIndex {
+		return fmt.Errorf("%w: end index %d is smaller than start index %d", ErrMilestoneKeyRangeInvalid, endIndex, startIndex)
+	}
+	km.keyRanges = append(km.keyRanges, &milestoneKeyRange{pubKey: pubKey, startIndex: startIndex, endIndex: endIndex})
+	return nil
+}
+
+// Threshold returns the amount of signatures the milestone of the given index must hold.
+func (km *MilestoneKeyManager) Threshold(index uint32) int {
+	return km.minSignatures
+}
+
+// PublicKeysForIndex returns the lexically ordered public keys applicable to the milestone of the given index.
+func (km *MilestoneKeyManager) PublicKeysForIndex(index uint32) []MilestonePublicKey {
+	set := km.PublicKeySetForIndex(index)
+	pubKeys := make([]MilestonePublicKey, 0, len(set))
+	for pubKey := range set {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i][:], pubKeys[j][:]) < 0
+	})
+	return pubKeys
+}
+
+// PublicKeySetForIndex returns the set of public keys applicable to the milestone of the given index.
+func (km *MilestoneKeyManager) PublicKeySetForIndex(index uint32) MilestonePublicKeySet {
+	set := make(MilestonePublicKeySet)
+	for _, keyRange := range km.keyRanges {
+		if index < keyRange.startIndex || (keyRange.endIndex != 0 && index > keyRange.endIndex) {
+			continue
+		}
+		set[keyRange.pubKey] = struct{}{}
+	}
+	return set
+}
+
+// VerifyMilestone verifies the signatures of the given Milestone against the public keys and threshold
+// applicable to its index as described by Milestone.VerifySignatures.
+func (km *MilestoneKeyManager) VerifyMilestone(ms *Milestone) error {
+	return ms.VerifySignatures(km.Threshold(ms.Index), km.PublicKeySetForIndex(ms.Index))
+}
+
+// SigningKeys returns the mapping of the first Threshold applicable public keys of the milestone of the given index
+// to the private keys out of the given ones.
+func (km *MilestoneKeyManager) SigningKeys(index uint32, prvKeys ...ed25519.PrivateKey) (MilestonePublicKeyMapping, error) {
+	held := make(MilestonePublicKeyMapping, len(prvKeys))
+	for _, prvKey := range prvKeys {
+		var pubKey MilestonePublicKey
+		copy(pubKey[:], prvKey.Public().(ed25519.PublicKey))
+		held[pubKey] = prvKey
+	}
+
+	threshold := km.Threshold(index)
+	mapping := make(MilestonePublicKeyMapping, threshold)
+	for _, pubKey := range km.PublicKeysForIndex(index) {
+		if len(mapping) == threshold {
+			break
+		}
+		if prvKey, has := held[pubKey]; has {
+			mapping[pubKey] = prvKey
+		}
+	}
+
+	if len(mapping) < threshold {
+		return nil, fmt.Errorf("%w: milestone %d needs %d signatures but only %d applicable private keys are held", ErrMilestoneKeyManagerInsufficientKeys, index, threshold, len(mapping))
+	}
+	return mapping, nil
+}
+
+// Signer returns a MilestonePublicKeyScheduleFunc and MilestoneSigningFunc for a MilestoneBuilder, which sign
+// every milestone with the applicable keys out of the given private keys as described by SigningKeys.
+// The schedule returns no public keys for a milestone index for which not enough private keys are held.
+func (km *MilestoneKeyManager) Signer(prvKeys ...ed25519.PrivateKey) (MilestonePublicKeyScheduleFunc, MilestoneSigningFunc) {
+	schedule := func(index uint32) []MilestonePublicKey {
+		mapping, err := km.SigningKeys(index, prvKeys...)
+		if err != nil {
+			return nil
+		}
+		pubKeys := make([]MilestonePublicKey, 0, len(mapping))
+		for pubKey := range mapping {
+			pubKeys = append(pubKeys, pubKey)
+		}
+		return pubKeys
+	}
+
+	all := make(MilestonePublicKeyMapping, len(prvKeys))
+	for _, prvKey := range prvKeys {
+		var pubKey MilestonePublicKey
+		copy(pubKey[:], prvKey.Public().(ed25519.PublicKey))
+		all[pubKey] = prvKey
+	}
+	return schedule, InMemoryEd25519MilestoneSigner(all)
+}
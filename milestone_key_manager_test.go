@@ -0,0 +1,91 @@
+package iotago_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+func TestMilestoneKeyManager(t *testing.T) {
+	pubKeys, prvKeys := randMilestoneKeys(3)
+
+	// key 0 is rotated out by key 2 at index 1000
+	var config iotago.MilestoneKeyManagerConfig
+	require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(`{
+		"milestonePublicKeyCount": 2,
+		"publicKeyRanges": [
+			{"key": "%s", "start": 0, "end": 999},
+			{"key": "%s", "start": 0, "end": 0},
+			{"key": "%s", "start": 1000, "end": 0}
+		]
+	}`, hex.EncodeToString(pubKeys[0][:]), hex.EncodeToString(pubKeys[1][:]), hex.EncodeToString(pubKeys[2][:]))), &config))
+
+	km, err := iotago.NewMilestoneKeyManagerFromConfig(&config)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, km.Threshold(1))
+	assert.Equal(t, iotago.MilestonePublicKeySet{pubKeys[0]: {}, pubKeys[1]: {}}, km.PublicKeySetForIndex(999))
+	assert.Equal(t, iotago.MilestonePublicKeySet{pubKeys[1]: {}, pubKeys[2]: {}}, km.PublicKeySetForIndex(1000))
+	assert.ElementsMatch(t, []iotago.MilestonePublicKey{pubKeys[1], pubKeys[2]}, km.PublicKeysForIndex(5000))
+
+	allPrvKeys := make([]ed25519.PrivateKey, 0, len(prvKeys))
+	for _, prvKey := range prvKeys {
+		allPrvKeys = append(allPrvKeys, prvKey)
+	}
+	schedule, signer := km.Signer(allPrvKeys...)
+
+	parents := tpkg.SortedRand32BytArray(1)
+	for _, index := range []uint32{999, 1000} {
+		ms, err := iotago.NewMilestoneBuilder(index).Parents(parents).PublicKeySchedule(schedule).Sign(signer).Build()
+		require.NoError(t, err)
+		assert.NoError(t, km.VerifyMilestone(ms))
+	}
+
+	// signed with the rotated out key
+	ms, err := iotago.NewMilestoneBuilder(1000).Parents(parents).PublicKeys(pubKeys[0], pubKeys[1]).Sign(signer).Build()
+	require.NoError(t, err)
+	assert.True(t, errors.Is(km.VerifyMilestone(ms), iotago.ErrMilestoneNonApplicablePublicKey))
+
+	// below the threshold
+	ms, err = iotago.NewMilestoneBuilder(1000).Parents(parents).PublicKeys(pubKeys[1]).Sign(signer).Build()
+	require.NoError(t, err)
+	assert.True(t, errors.Is(km.VerifyMilestone(ms), iotago.ErrMilestoneTooFewSignaturesForVerificationThreshold))
+
+	mapping, err := km.SigningKeys(1000, prvKeys[pubKeys[1]], prvKeys[pubKeys[2]], prvKeys[pubKeys[0]])
+	require.NoError(t, err)
+	assert.Equal(t, iotago.MilestonePublicKeyMapping{pubKeys[1]: prvKeys[pubKeys[1]], pubKeys[2]: prvKeys[pubKeys[2]]}, mapping)
+
+	_, err = km.SigningKeys(1000, prvKeys[pubKeys[0]], prvKeys[pubKeys[1]])
+	assert.True(t, errors.Is(err, iotago.ErrMilestoneKeyManagerInsufficientKeys))
+
+	schedule, signer = km.Signer(prvKeys[pubKeys[0]], prvKeys[pubKeys[1]])
+	_, err = iotago.NewMilestoneBuilder(1000).Parents(parents).PublicKeySchedule(schedule).Sign(signer).Build()
+	assert.True(t, errors.Is(err, iotago.ErrMilestoneTooFewPublicKeys))
+}
+
+func TestNewMilestoneKeyManagerFromConfig_Errors(t *testing.T) {
+	pubKeys, _ := randMilestoneKeys(1)
+	tests := []struct {
+		name     string
+		keyRange *iotago.MilestoneKeyRange
+	}{
+		{"err - invalid hex", &iotago.MilestoneKeyRange{PublicKey: "xyz"}},
+		{"err - invalid length", &iotago.MilestoneKeyRange{PublicKey: hex.EncodeToString(pubKeys[0][:31])}},
+		{"err - end before start", &iotago.MilestoneKeyRange{PublicKey: hex.EncodeToString(pubKeys[0][:]), StartIndex: 10, EndIndex: 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := iotago.NewMilestoneKeyManagerFromConfig(&iotago.MilestoneKeyManagerConfig{MinSignatures: 1, KeyRanges: []*iotago.MilestoneKeyRange{tt.keyRange}})
+			assert.True(t, errors.Is(err, iotago.ErrMilestoneKeyRangeInvalid))
+		})
+	}
+}
@@ -195,6 +195,71 @@ func TestMilestoneSigning(t *testing.T) {
 				verificationErr: iotago.ErrMilestoneInvalidSignature,
 			}
 		}(),
+		func() test {
+			prvKey1 := tpkg.RandEd25519PrivateKey()
+			prvKey2 := tpkg.RandEd25519PrivateKey()
+			prvKey3 := tpkg.RandEd25519PrivateKey()
+			pubKey1 := pubKeyFromPrv(prvKey1)
+			pubKey2 := pubKeyFromPrv(prvKey2)
+			pubKey3 := pubKeyFromPrv(prvKey3)
+
+			pubKeys := serializer.LexicalOrdered32ByteArrays{pubKey1, pubKey2, pubKey3}
+			sort.Sort(pubKeys)
+
+			msPayload := &iotago.Milestone{
+				Parents:              tpkg.SortedRand32BytArray(1 + rand.Intn(7)),
+				Index:                1000,
+				Timestamp:            uint64(time.Now().Unix()),
+				PublicKeys:           pubKeys,
+				InclusionMerkleProof: tpkg.Rand32ByteArray(),
+			}
+
+			return test{
+				name: "err - one invalid signature within batch",
+				ms:   msPayload,
+				signer: iotago.InMemoryEd25519MilestoneSigner(iotago.MilestonePublicKeyMapping{
+					pubKey1: prvKey1,
+					// signature will be signed with a non matching private key
+					pubKey2: tpkg.RandEd25519PrivateKey(),
+					pubKey3: prvKey3,
+				}),
+				minSigThreshold: 3,
+				pubKeySet:       map[iotago.MilestonePublicKey]struct{}{pubKey1: {}, pubKey2: {}, pubKey3: {}},
+				signingErr:      nil,
+				verificationErr: iotago.ErrMilestoneInvalidSignature,
+			}
+		}(),
+		func() test {
+			prvKey1 := tpkg.RandEd25519PrivateKey()
+			prvKey2 := tpkg.RandEd25519PrivateKey()
+			pubKey1 := pubKeyFromPrv(prvKey1)
+			pubKey2 := pubKeyFromPrv(prvKey2)
+
+			pubKeys := serializer.LexicalOrdered32ByteArrays{pubKey1, pubKey2}
+			sort.Sort(pubKeys)
+
+			msPayload := &iotago.Milestone{
+				Parents:              tpkg.SortedRand32BytArray(1 + rand.Intn(7)),
+				Index:                1000,
+				Timestamp:            uint64(time.Now().Unix()),
+				PublicKeys:           pubKeys,
+				InclusionMerkleProof: tpkg.Rand32ByteArray(),
+			}
+
+			return test{
+				name: "err - non applicable public key after an invalid signature",
+				ms:   msPayload,
+				signer: iotago.InMemoryEd25519MilestoneSigner(iotago.MilestonePublicKeyMapping{
+					// signature will be signed with a non matching private key
+					pubKeys[0]: tpkg.RandEd25519PrivateKey(),
+					pubKeys[1]: prvKey2,
+				}),
+				minSigThreshold: 1,
+				pubKeySet:       map[iotago.MilestonePublicKey]struct{}{pubKeys[0]: {}},
+				signingErr:      nil,
+				verificationErr: iotago.ErrMilestoneNonApplicablePublicKey,
+			}
+		}(),
 	}
 
 	for _, test := range tests {
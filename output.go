@@ -141,7 +141,9 @@ func OutputsAddrUniqueValidator() OutputsValidatorFunc {
 		}
 
 		if j, has := m[k]; has {
-			return fmt.Errorf("%w: output %d and %d share the same address", ErrOutputAddrNotUnique, j, index)
+			violation := newViolation(RuleOutputAddrUnique, index, nil, nil, fmt.Errorf("%w: output %d and %d share the same address", ErrOutputAddrNotUnique, j, index))
+			violation.Address, _ = target.(Address)
+			return violation
 		}
 		m[k] = index
 		return nil
@@ -162,18 +164,18 @@ func OutputsDepositAmountValidator() OutputsValidatorFunc {
 			return fmt.Errorf("unable to get deposit of output: %w", err)
 		}
 		if deposit == 0 {
-			return fmt.Errorf("%w: output %d", ErrDepositAmountMustBeGreaterThanZero, index)
+			return newViolation(RuleOutputDepositGreaterThanZero, index, nil, deposit, fmt.Errorf("%w: output %d", ErrDepositAmountMustBeGreaterThanZero, index))
 		}
 		if _, isAllowanceOutput := dep.(*SigLockedDustAllowanceOutput); isAllowanceOutput {
 			if deposit < OutputSigLockedDustAllowanceOutputMinDeposit {
-				return fmt.Errorf("%w: output %d", ErrOutputDustAllowanceLessThanMinDeposit, index)
+				return newViolation(RuleOutputDustAllowanceMinDeposit, index, OutputSigLockedDustAllowanceOutputMinDeposit, deposit, fmt.Errorf("%w: output %d", ErrOutputDustAllowanceLessThanMinDeposit, index))
 			}
 		}
 		if deposit > TokenSupply {
-			return fmt.Errorf("%w: output %d", ErrOutputDepositsMoreThanTotalSupply, index)
+			return newViolation(RuleOutputDepositWithinSupply, index, TokenSupply, deposit, fmt.Errorf("%w: output %d", ErrOutputDepositsMoreThanTotalSupply, index))
 		}
 		if sum+deposit > TokenSupply {
-			return fmt.Errorf("%w: output %d", ErrOutputsSumExceedsTotalSupply, index)
+			return newViolation(RuleOutputsSumWithinSupply, index, TokenSupply, sum+deposit, fmt.Errorf("%w: output %d", ErrOutputsSumExceedsTotalSupply, index))
 		}
 		if index != -1 {
 			sum += deposit
@@ -187,12 +189,20 @@ var outputAmountValidator = OutputsDepositAmountValidator()
 
 // ValidateOutputs validates the outputs by running them against the given OutputsValidatorFunc.
 func ValidateOutputs(outputs serializer.Serializables, funcs ...OutputsValidatorFunc) error {
+	return validateOutputs(outputs, nil, funcs...)
+}
+
+// validates the outputs and adds the violations to the given collector.
+func validateOutputs(outputs serializer.Serializables, c *violationCollector, funcs ...OutputsValidatorFunc) error {
 	for i, output := range outputs {
 		if _, isOutput := output.(Output); !isOutput {
-			return fmt.Errorf("%w: can only validate outputs but got %T instead", ErrUnknownOutputType, output)
+			if err := c.add(newViolation(RuleOutputType, i, nil, fmt.Sprintf("%T", output), fmt.Errorf("%w: can only validate outputs but got %T instead", ErrUnknownOutputType, output))); err != nil {
+				return err
+			}
+			continue
 		}
 		for _, f := range funcs {
-			if err := f(i, output.(Output)); err != nil {
+			if err := c.add(f(i, output.(Output))); err != nil {
 				return err
 			}
 		}
@@ -0,0 +1,107 @@
+package pretty
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DifferenceKind defines the kind of a Difference.
+type DifferenceKind byte
+
+const (
+	// DifferenceChanged denotes a node which exists in both trees but with a different value.
+	DifferenceChanged DifferenceKind = iota
+	// DifferenceAdded denotes a node which only exists in the second tree.
+	DifferenceAdded
+	// DifferenceRemoved denotes a node which only exists in the first tree.
+	DifferenceRemoved
+)
+
+// Difference is a structural difference between two trees.
+type Difference struct {
+	// The kind of the difference.
+	Kind DifferenceKind
+	// The dot separated keys leading to the differing node, e.g. "message.payload.essence.outputs.[0].amount".
+	Path string
+	// The value of the node within the first tree.
+	Old string
+	// The value of the node within the second tree.
+	New string
+}
+
+func (d Difference) String() string {
+	switch d.Kind {
+	case DifferenceAdded:
+		return fmt.Sprintf("+ %s: %s", d.Path, d.New)
+	case DifferenceRemoved:
+		return fmt.Sprintf("- %s: %s", d.Path, d.Old)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", d.Path, d.Old, d.New)
+	}
+}
+
+// Differences are Difference(s).
+type Differences []Difference
+
+func (d Differences) String() string {
+	var b strings.Builder
+	for _, diff := range d {
+		b.WriteString(diff.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Diff returns the structural differences between the tree forms of a and b.
+// Added or removed subtrees are reported once at their root.
+func (p *Printer) Diff(a interface{}, b interface{}) (Differences, error) {
+	treeA, err := p.Tree(a)
+	if err != nil {
+		return nil, err
+	}
+	treeB, err := p.Tree(b)
+	if err != nil {
+		return nil, err
+	}
+	return DiffTrees(treeA, treeB), nil
+}
+
+// DiffTrees returns the structural differences between the two given trees.
+func DiffTrees(a *Node, b *Node) Differences {
+	var diffs Differences
+	diffNodes(&diffs, "", a, b)
+	return diffs
+}
+
+func diffNodes(diffs *Differences, parentPath string, a *Node, b *Node) {
+	path := joinPath(parentPath, a.Key)
+	if a.Key != b.Key {
+		*diffs = append(*diffs, Difference{Kind: DifferenceRemoved, Path: path, Old: a.Value})
+		*diffs = append(*diffs, Difference{Kind: DifferenceAdded, Path: joinPath(parentPath, b.Key), New: b.Value})
+		return
+	}
+	if a.Value != b.Value {
+		*diffs = append(*diffs, Difference{Kind: DifferenceChanged, Path: path, Old: a.Value, New: b.Value})
+	}
+
+	for _, childA := range a.Children {
+		childB := b.Child(childA.Key)
+		if childB == nil {
+			*diffs = append(*diffs, Difference{Kind: DifferenceRemoved, Path: joinPath(path, childA.Key), Old: childA.Value})
+			continue
+		}
+		diffNodes(diffs, path, childA, childB)
+	}
+	for _, childB := range b.Children {
+		if a.Child(childB.Key) == nil {
+			*diffs = append(*diffs, Difference{Kind: DifferenceAdded, Path: joinPath(path, childB.Key), New: childB.Value})
+		}
+	}
+}
+
+func joinPath(parentPath string, key string) string {
+	if parentPath == "" {
+		return key
+	}
+	return parentPath + "." + key
+}
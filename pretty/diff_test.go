@@ -0,0 +1,52 @@
+package pretty_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/pretty"
+)
+
+func TestPrinter_Diff(t *testing.T) {
+	printer := pretty.NewPrinter()
+
+	a := &iotago.Milestone{Index: 1, Timestamp: 0}
+	b := &iotago.Milestone{Index: 2, Timestamp: 0}
+
+	diffs, err := printer.Diff(a, a)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	diffs, err = printer.Diff(a, b)
+	require.NoError(t, err)
+	// the milestone ID changes as well
+	require.Len(t, diffs, 2)
+	assert.Equal(t, pretty.DifferenceChanged, diffs[0].Kind)
+	assert.Equal(t, "milestone", diffs[0].Path)
+	assert.Equal(t, pretty.Difference{Kind: pretty.DifferenceChanged, Path: "milestone.index", Old: "1", New: "2"}, diffs[1])
+	assert.Equal(t, "~ milestone.index: 1 -> 2", diffs[1].String())
+}
+
+func TestDiffTrees(t *testing.T) {
+	a := &pretty.Node{Key: "root", Children: []*pretty.Node{
+		{Key: "same", Value: "1"},
+		{Key: "changed", Value: "1"},
+		{Key: "removed", Value: "1", Children: []*pretty.Node{{Key: "nested", Value: "x"}}},
+	}}
+	b := &pretty.Node{Key: "root", Children: []*pretty.Node{
+		{Key: "same", Value: "1"},
+		{Key: "changed", Value: "2"},
+		{Key: "added", Value: "3"},
+	}}
+
+	diffs := pretty.DiffTrees(a, b)
+	assert.Equal(t, pretty.Differences{
+		{Kind: pretty.DifferenceChanged, Path: "root.changed", Old: "1", New: "2"},
+		{Kind: pretty.DifferenceRemoved, Path: "root.removed", Old: "1"},
+		{Kind: pretty.DifferenceAdded, Path: "root.added", New: "3"},
+	}, diffs)
+	assert.Equal(t, "~ root.changed: 1 -> 2\n- root.removed: 1\n+ root.added: 3\n", diffs.String())
+}
@@ -0,0 +1,295 @@
+package pretty
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/iotaledger/hive.go/serializer"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/units"
+)
+
+const (
+	// annotation of a valid signature.
+	sigValid = "valid"
+	// annotation prefix of an invalid signature.
+	sigInvalid = "INVALID"
+)
+
+// FormatAmount renders the given amount of iotas with an annotation in its best fitting unit, e.g. "1500000 i (1.5 Mi)".
+func FormatAmount(amount uint64) string {
+	if unit := units.Amount(amount).BestFitUnit(); unit != units.I {
+		return fmt.Sprintf("%d i (%s)", amount, units.Amount(amount).Format(unit))
+	}
+	return fmt.Sprintf("%d i", amount)
+}
+
+// renders arbitrary bytes as hex and additionally as a quoted string if they are printable UTF-8.
+func formatBytes(b []byte) string {
+	s := hex.EncodeToString(b)
+	if len(b) > 0 && utf8.Valid(b) && strconv.CanBackquote(string(b)) {
+		s += fmt.Sprintf(" (%q)", b)
+	}
+	return s
+}
+
+func (p *Printer) message(msg *iotago.Message) *Node {
+	node := &Node{Key: "message"}
+	if msgID, err := msg.ID(); err == nil {
+		node.Value = hex.EncodeToString(msgID[:])
+	}
+	node.add("networkID", strconv.FormatUint(msg.NetworkID, 10))
+	p.messageIDs(node.add("parents", ""), msg.Parents)
+	if msg.Payload != nil {
+		node.addNode("payload", p.payload(msg.Payload))
+	}
+	node.add("nonce", strconv.FormatUint(msg.Nonce, 10))
+	return node
+}
+
+func (p *Printer) messageIDs(node *Node, ids iotago.MessageIDs) {
+	for i := range ids {
+		node.add(index(i), hex.EncodeToString(ids[i][:]))
+	}
+}
+
+// builds the node of a nested payload, its value is prefixed with the kind of payload
+// as the node itself is keyed by the field holding it.
+func (p *Printer) payload(payload serializer.Serializable) *Node {
+	node, err := p.Tree(payload)
+	if err != nil {
+		return &Node{Value: fmt.Sprintf("unknown payload %T", payload)}
+	}
+	node.Value = strings.TrimSpace(node.Key + " " + node.Value)
+	return node
+}
+
+func (p *Printer) transaction(tx *iotago.Transaction) *Node {
+	node := &Node{Key: "transaction"}
+	txID, err := tx.ID()
+	if err == nil {
+		node.Value = hex.EncodeToString(txID[:])
+	}
+
+	essence, ok := tx.Essence.(*iotago.TransactionEssence)
+	if !ok {
+		node.add("essence", fmt.Sprintf("unknown essence %T", tx.Essence))
+		return node
+	}
+	node.addNode("essence", p.essence(essence, txID))
+
+	signingMsg, signingMsgErr := essence.SigningMessage()
+	unlockBlocks := node.add("unlockBlocks", "")
+	for i, unlockBlock := range tx.UnlockBlocks {
+		switch block := unlockBlock.(type) {
+		case *iotago.SignatureUnlockBlock:
+			blockNode := unlockBlocks.add(index(i), "signature")
+			sig, ok := block.Signature.(*iotago.Ed25519Signature)
+			if !ok {
+				blockNode.add("signature", fmt.Sprintf("unknown signature %T", block.Signature))
+				continue
+			}
+			p.ed25519Signature(blockNode, sig)
+			if signingMsgErr != nil {
+				blockNode.add("validity", fmt.Sprintf("unknown: %s", signingMsgErr))
+				continue
+			}
+			blockNode.add("validity", p.signatureValidity(sig, signingMsg, essence, i))
+		case *iotago.ReferenceUnlockBlock:
+			unlockBlocks.add(index(i), fmt.Sprintf("reference to unlock block %d", block.Reference))
+		default:
+			unlockBlocks.add(index(i), fmt.Sprintf("unknown unlock block %T", unlockBlock))
+		}
+	}
+	return node
+}
+
+// checks the given signature of the unlock block at the given position against the signing message
+// and if available, against the address of the output referenced by the corresponding input.
+func (p *Printer) signatureValidity(sig *iotago.Ed25519Signature, signingMsg []byte, essence *iotago.TransactionEssence, pos int) string {
+	if p.opts.utxos != nil && pos < len(essence.Inputs) {
+		if utxoInput, ok := essence.Inputs[pos].(*iotago.UTXOInput); ok {
+			if output, has := p.opts.utxos[utxoInput.ID()]; has {
+				target, err := output.Target()
+				if addr, ok := target.(*iotago.Ed25519Address); err == nil && ok {
+					if err := sig.Valid(signingMsg, addr); err != nil {
+						return fmt.Sprintf("%s: %s", sigInvalid, err)
+					}
+					return sigValid
+				}
+			}
+		}
+	}
+	if !ed25519.Verify(sig.PublicKey[:], signingMsg, sig.Signature[:]) {
+		return sigInvalid
+	}
+	return sigValid
+}
+
+func (p *Printer) ed25519Signature(node *Node, sig *iotago.Ed25519Signature) {
+	addr := iotago.AddressFromEd25519PubKey(sig.PublicKey[:])
+	node.add("publicKey", hex.EncodeToString(sig.PublicKey[:]))
+	node.add("address", p.address(&addr))
+	node.add("signature", hex.EncodeToString(sig.Signature[:]))
+}
+
+// builds the node of the given essence, output IDs are only computed if the transaction ID is given.
+func (p *Printer) essence(essence *iotago.TransactionEssence, txID *iotago.TransactionID) *Node {
+	node := &Node{Key: "essence"}
+	inputs := node.add("inputs", "")
+	for i, input := range essence.Inputs {
+		inputs.addNode(index(i), p.input(input))
+	}
+	outputs := node.add("outputs", "")
+	for i, output := range essence.Outputs {
+		outputNode := p.output(output)
+		if txID != nil {
+			outputID := (&iotago.UTXOInput{TransactionID: *txID, TransactionOutputIndex: uint16(i)}).ID()
+			outputNode.Children = append([]*Node{{Key: "id", Value: outputID.ToHex()}}, outputNode.Children...)
+		}
+		outputs.addNode(index(i), outputNode)
+	}
+	if essence.Payload != nil {
+		node.addNode("payload", p.payload(essence.Payload))
+	}
+	return node
+}
+
+func (p *Printer) input(input serializer.Serializable) *Node {
+	switch in := input.(type) {
+	case *iotago.UTXOInput:
+		node := &Node{Value: fmt.Sprintf("utxo %s:%d", hex.EncodeToString(in.TransactionID[:]), in.TransactionOutputIndex)}
+		if output, has := p.opts.utxos[in.ID()]; has {
+			node.addNode("consumes", p.output(output))
+		}
+		return node
+	case *iotago.TreasuryInput:
+		return &Node{Value: fmt.Sprintf("treasury %s", hex.EncodeToString(in[:]))}
+	default:
+		return &Node{Value: fmt.Sprintf("unknown input %T", input)}
+	}
+}
+
+func (p *Printer) output(output serializer.Serializable) *Node {
+	switch out := output.(type) {
+	case *iotago.SigLockedSingleOutput:
+		node := &Node{Value: "sig locked single"}
+		node.add("address", p.address(out.Address))
+		node.add("amount", FormatAmount(out.Amount))
+		return node
+	case *iotago.SigLockedDustAllowanceOutput:
+		node := &Node{Value: "sig locked dust allowance"}
+		node.add("address", p.address(out.Address))
+		node.add("amount", FormatAmount(out.Amount))
+		return node
+	case *iotago.TreasuryOutput:
+		node := &Node{Value: "treasury"}
+		node.add("amount", FormatAmount(out.Amount))
+		return node
+	default:
+		return &Node{Value: fmt.Sprintf("unknown output %T", output)}
+	}
+}
+
+func (p *Printer) address(addr serializer.Serializable) string {
+	if a, ok := addr.(iotago.Address); ok {
+		return a.Bech32(p.opts.prefix)
+	}
+	return fmt.Sprintf("unknown address %T", addr)
+}
+
+func (p *Printer) indexation(indexation *iotago.Indexation) *Node {
+	node := &Node{Key: "indexation"}
+	node.add("index", formatBytes(indexation.Index))
+	node.add("data", fmt.Sprintf("%d bytes", len(indexation.Data))).add("hex", hex.EncodeToString(indexation.Data))
+	return node
+}
+
+func (p *Printer) milestone(ms *iotago.Milestone) *Node {
+	node := &Node{Key: "milestone"}
+	if msID, err := ms.ID(); err == nil {
+		node.Value = hex.EncodeToString(msID[:])
+	}
+	node.add("index", strconv.FormatUint(uint64(ms.Index), 10))
+	node.add("timestamp", fmt.Sprintf("%d (%s)", ms.Timestamp, time.Unix(int64(ms.Timestamp), 0).UTC().Format(time.RFC3339)))
+	p.messageIDs(node.add("parents", ""), ms.Parents)
+	node.add("inclusionMerkleProof", hex.EncodeToString(ms.InclusionMerkleProof[:]))
+	node.add("nextPoWScore", strconv.FormatUint(uint64(ms.NextPoWScore), 10))
+	node.add("nextPoWScoreMilestoneIndex", strconv.FormatUint(uint64(ms.NextPoWScoreMilestoneIndex), 10))
+	if ms.Receipt != nil {
+		node.addNode("receipt", p.payload(ms.Receipt))
+	}
+
+	msEssence, essenceErr := ms.Essence()
+	signatures := node.add("signatures", "")
+	for i, sig := range ms.Signatures {
+		sigNode := signatures.add(index(i), "")
+		sigNode.add("signature", hex.EncodeToString(sig[:]))
+		if i >= len(ms.PublicKeys) {
+			sigNode.add("validity", fmt.Sprintf("%s: no public key", sigInvalid))
+			continue
+		}
+		pubKey := ms.PublicKeys[i]
+		sigNode.add("publicKey", hex.EncodeToString(pubKey[:]))
+		switch {
+		case essenceErr != nil:
+			sigNode.add("validity", fmt.Sprintf("unknown: %s", essenceErr))
+		case ed25519.Verify(pubKey[:], msEssence, sig[:]):
+			sigNode.add("validity", sigValid)
+		default:
+			sigNode.add("validity", sigInvalid)
+		}
+	}
+	for i := len(ms.Signatures); i < len(ms.PublicKeys); i++ {
+		signatures.add(index(i), fmt.Sprintf("missing signature for public key %s", hex.EncodeToString(ms.PublicKeys[i][:])))
+	}
+	return node
+}
+
+func (p *Printer) receipt(receipt *iotago.Receipt) *Node {
+	node := &Node{Key: "receipt"}
+	node.add("migratedAt", strconv.FormatUint(uint64(receipt.MigratedAt), 10))
+	node.add("final", strconv.FormatBool(receipt.Final))
+	funds := node.add("funds", "")
+	var total uint64
+	for i, fund := range receipt.Funds {
+		entry, ok := fund.(*iotago.MigratedFundsEntry)
+		if !ok {
+			funds.add(index(i), fmt.Sprintf("unknown funds entry %T", fund))
+			continue
+		}
+		total += entry.Deposit
+		funds.addNode(index(i), p.migratedFundsEntry(entry))
+	}
+	funds.Value = fmt.Sprintf("total %s", FormatAmount(total))
+	if receipt.Transaction != nil {
+		node.addNode("transaction", p.payload(receipt.Transaction))
+	}
+	return node
+}
+
+func (p *Printer) migratedFundsEntry(entry *iotago.MigratedFundsEntry) *Node {
+	node := &Node{Key: "migratedFundsEntry"}
+	node.add("tailTransactionHash", hex.EncodeToString(entry.TailTransactionHash[:]))
+	node.add("address", p.address(entry.Address))
+	node.add("deposit", FormatAmount(entry.Deposit))
+	return node
+}
+
+func (p *Printer) treasuryTransaction(treasuryTx *iotago.TreasuryTransaction) *Node {
+	node := &Node{Key: "treasuryTransaction"}
+	node.addNode("input", p.input(treasuryTx.Input))
+	node.addNode("output", p.output(treasuryTx.Output))
+	return node
+}
+
+// returns the key of the element at the given index within a list.
+func index(i int) string {
+	return fmt.Sprintf("[%d]", i)
+}
@@ -0,0 +1,197 @@
+// Package pretty provides a human-readable and diff-friendly representation of messages, payloads and their objects.
+//
+// Unlike the JSON representation, addresses are rendered in their Bech32 form, amounts are annotated with their
+// best fitting unit, IDs of messages, transactions and outputs are computed and signatures are annotated with their validity:
+//
+//	printer := pretty.NewPrinter(pretty.WithNetworkPrefix(iotago.PrefixMainnet))
+//	fmt.Println(printer.Sprint(msg))
+//
+// Use Diff to get the structural differences between two objects.
+package pretty
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/iotaledger/hive.go/serializer"
+
+	"github.com/iotaledger/iota.go/v2"
+)
+
+var (
+	// ErrUnsupportedObject gets returned when an object is given which can not be pretty printed.
+	ErrUnsupportedObject = errors.New("unsupported object")
+)
+
+// the default options applied to the Printer.
+var defaultPrinterOptions = []PrinterOption{
+	WithNetworkPrefix(iotago.PrefixMainnet),
+	WithIndent("  "),
+	WithUTXOs(nil),
+}
+
+// PrinterOptions define options for the Printer.
+type PrinterOptions struct {
+	// The network prefix used to render addresses.
+	prefix iotago.NetworkPrefix
+	// The string used to indent nested nodes.
+	indent string
+	// The outputs referenced by the inputs of transactions.
+	utxos iotago.InputToOutputMapping
+}
+
+// applies the given PrinterOption.
+func (po *PrinterOptions) apply(opts ...PrinterOption) {
+	for _, opt := range opts {
+		opt(po)
+	}
+}
+
+// WithNetworkPrefix sets the network prefix used to render addresses in their Bech32 form.
+func WithNetworkPrefix(prefix iotago.NetworkPrefix) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.prefix = prefix
+	}
+}
+
+// WithIndent sets the string used to indent nested nodes.
+func WithIndent(indent string) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.indent = indent
+	}
+}
+
+// WithUTXOs sets the outputs referenced by the inputs of printed transactions.
+// If set, inputs are annotated with the output they consume and signatures are additionally
+// checked against the address of the referenced output.
+func WithUTXOs(utxos iotago.InputToOutputMapping) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.utxos = utxos
+	}
+}
+
+// PrinterOption is a function setting a Printer option.
+type PrinterOption func(opts *PrinterOptions)
+
+// NewPrinter creates a new Printer with the given options.
+func NewPrinter(opts ...PrinterOption) *Printer {
+	options := &PrinterOptions{}
+	options.apply(defaultPrinterOptions...)
+	options.apply(opts...)
+	return &Printer{opts: options}
+}
+
+// Printer renders objects into a human-readable tree form.
+type Printer struct {
+	opts *PrinterOptions
+}
+
+// Node is an entry within the tree form of an object.
+type Node struct {
+	// The name of the node, unique among its siblings.
+	Key string
+	// The rendered value of the node, might be empty.
+	Value string
+	// The nested nodes.
+	Children []*Node
+}
+
+// adds a new child with the given key and value and returns it.
+func (n *Node) add(key string, value string) *Node {
+	child := &Node{Key: key, Value: value}
+	n.Children = append(n.Children, child)
+	return child
+}
+
+// adds the given child under the given key.
+func (n *Node) addNode(key string, child *Node) {
+	child.Key = key
+	n.Children = append(n.Children, child)
+}
+
+// Child returns the direct child with the given key or nil.
+func (n *Node) Child(key string) *Node {
+	for _, child := range n.Children {
+		if child.Key == key {
+			return child
+		}
+	}
+	return nil
+}
+
+// Tree builds the tree form of the given object.
+func (p *Printer) Tree(obj interface{}) (*Node, error) {
+	switch o := obj.(type) {
+	case *iotago.Message:
+		return p.message(o), nil
+	case *iotago.Transaction:
+		return p.transaction(o), nil
+	case *iotago.TransactionEssence:
+		return p.essence(o, nil), nil
+	case *iotago.Milestone:
+		return p.milestone(o), nil
+	case *iotago.Indexation:
+		return p.indexation(o), nil
+	case *iotago.Receipt:
+		return p.receipt(o), nil
+	case *iotago.TreasuryTransaction:
+		return p.treasuryTransaction(o), nil
+	case *iotago.MigratedFundsEntry:
+		return p.migratedFundsEntry(o), nil
+	case *iotago.UTXOInput, *iotago.TreasuryInput:
+		node := p.input(o.(serializer.Serializable))
+		node.Key = "input"
+		return node, nil
+	case *iotago.SigLockedSingleOutput, *iotago.SigLockedDustAllowanceOutput, *iotago.TreasuryOutput:
+		node := p.output(o.(serializer.Serializable))
+		node.Key = "output"
+		return node, nil
+	case *iotago.Ed25519Address:
+		return &Node{Key: "address", Value: p.address(o)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedObject, obj)
+	}
+}
+
+// Fprint writes the tree form of the given object to w.
+func (p *Printer) Fprint(w io.Writer, obj interface{}) error {
+	node, err := p.Tree(obj)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, p.Render(node))
+	return err
+}
+
+// Sprint returns the tree form of the given object as a string.
+// Unsupported objects are rendered with their Go representation.
+func (p *Printer) Sprint(obj interface{}) string {
+	node, err := p.Tree(obj)
+	if err != nil {
+		return fmt.Sprintf("%+v", obj)
+	}
+	return p.Render(node)
+}
+
+// Render renders the given tree as text, one node per line.
+func (p *Printer) Render(node *Node) string {
+	var b strings.Builder
+	p.render(&b, node, 0)
+	return b.String()
+}
+
+func (p *Printer) render(b *strings.Builder, node *Node, depth int) {
+	b.WriteString(strings.Repeat(p.opts.indent, depth))
+	b.WriteString(node.Key)
+	b.WriteString(":")
+	if node.Value != "" {
+		b.WriteString(" ")
+		b.WriteString(node.Value)
+	}
+	b.WriteString("\n")
+	for _, child := range node.Children {
+		p.render(b, child, depth+1)
+	}
+}
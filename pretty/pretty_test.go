@@ -0,0 +1,159 @@
+package pretty_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/pretty"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+// returns a signed transaction spending a single input of the returned input address.
+func signedTransaction(t *testing.T) (*iotago.Transaction, *iotago.UTXOInput, *iotago.Ed25519Address, *iotago.Ed25519Address) {
+	privKey := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(privKey.Public().(ed25519.PublicKey))
+	outputAddr, _ := tpkg.RandEd25519Address()
+	input := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 3}
+
+	tx, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: input}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 1_500_000}).
+		AddIndexationPayload(&iotago.Indexation{Index: []byte("pretty"), Data: []byte{1, 2, 3}}).
+		Build(iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: privKey}))
+	require.NoError(t, err)
+	return tx, input, &inputAddr, outputAddr
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount   uint64
+		expected string
+	}{
+		{0, "0 i"},
+		{999, "999 i"},
+		{1000, "1000 i (1 Ki)"},
+		{1_500_000, "1500000 i (1.5 Mi)"},
+		{1_000_001, "1000001 i (1.000001 Mi)"},
+		{iotago.TokenSupply, "2779530283277761 i (2.779530283277761 Pi)"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, pretty.FormatAmount(tt.amount))
+	}
+}
+
+func TestPrinter_Transaction(t *testing.T) {
+	tx, input, inputAddr, outputAddr := signedTransaction(t)
+	msg := &iotago.Message{NetworkID: 1, Parents: tpkg.SortedRand32BytArray(1), Payload: tx}
+
+	printer := pretty.NewPrinter(pretty.WithNetworkPrefix(iotago.PrefixTestnet))
+	tree, err := printer.Tree(msg)
+	require.NoError(t, err)
+
+	msgID := msg.MustID()
+	assert.Equal(t, hex.EncodeToString(msgID[:]), tree.Value)
+
+	payload := tree.Child("payload")
+	require.NotNil(t, payload)
+	txID, err := tx.ID()
+	require.NoError(t, err)
+	assert.Equal(t, "transaction "+hex.EncodeToString(txID[:]), payload.Value)
+
+	output := payload.Child("essence").Child("outputs").Child("[0]")
+	require.NotNil(t, output)
+	outputID := (&iotago.UTXOInput{TransactionID: *txID, TransactionOutputIndex: 0}).ID()
+	assert.Equal(t, outputID.ToHex(), output.Child("id").Value)
+	assert.Equal(t, outputAddr.Bech32(iotago.PrefixTestnet), output.Child("address").Value)
+	assert.Equal(t, "1500000 i (1.5 Mi)", output.Child("amount").Value)
+
+	indexation := payload.Child("essence").Child("payload")
+	require.NotNil(t, indexation)
+	assert.Equal(t, `707265747479 ("pretty")`, indexation.Child("index").Value)
+
+	unlockBlock := payload.Child("unlockBlocks").Child("[0]")
+	require.NotNil(t, unlockBlock)
+	assert.Equal(t, inputAddr.Bech32(iotago.PrefixTestnet), unlockBlock.Child("address").Value)
+	assert.Equal(t, "valid", unlockBlock.Child("validity").Value)
+
+	// the consumed output is shown and signatures are checked against its address
+	utxos := iotago.InputToOutputMapping{input.ID(): &iotago.SigLockedSingleOutput{Address: inputAddr, Amount: 1_500_000}}
+	tree, err = pretty.NewPrinter(pretty.WithUTXOs(utxos)).Tree(tx)
+	require.NoError(t, err)
+	assert.Equal(t, inputAddr.Bech32(iotago.PrefixMainnet), tree.Child("essence").Child("inputs").Child("[0]").Child("consumes").Child("address").Value)
+	assert.Equal(t, "valid", tree.Child("unlockBlocks").Child("[0]").Child("validity").Value)
+
+	otherAddr, _ := tpkg.RandEd25519Address()
+	utxos[input.ID()] = &iotago.SigLockedSingleOutput{Address: otherAddr, Amount: 1_500_000}
+	tree, err = pretty.NewPrinter(pretty.WithUTXOs(utxos)).Tree(tx)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(tree.Child("unlockBlocks").Child("[0]").Child("validity").Value, "INVALID"))
+
+	// tampering with the signature
+	tx.UnlockBlocks[0].(*iotago.SignatureUnlockBlock).Signature.(*iotago.Ed25519Signature).Signature[0] ^= 0xff
+	tree, err = printer.Tree(tx)
+	require.NoError(t, err)
+	assert.Equal(t, "INVALID", tree.Child("unlockBlocks").Child("[0]").Child("validity").Value)
+}
+
+func TestPrinter_Milestone(t *testing.T) {
+	privKey := tpkg.RandEd25519PrivateKey()
+	pubKey := privKey.Public().(ed25519.PublicKey)
+	var msPubKey iotago.MilestonePublicKey
+	copy(msPubKey[:], pubKey)
+
+	ms, err := iotago.NewMilestone(1337, 1609459200, tpkg.SortedRand32BytArray(2), [iotago.MilestoneInclusionMerkleProofLength]byte{}, []iotago.MilestonePublicKey{msPubKey})
+	require.NoError(t, err)
+	require.NoError(t, ms.Sign(iotago.InMemoryEd25519MilestoneSigner(iotago.MilestonePublicKeyMapping{msPubKey: privKey})))
+
+	tree, err := pretty.NewPrinter().Tree(ms)
+	require.NoError(t, err)
+	assert.Equal(t, "1337", tree.Child("index").Value)
+	assert.Equal(t, "1609459200 (2021-01-01T00:00:00Z)", tree.Child("timestamp").Value)
+	assert.Equal(t, "valid", tree.Child("signatures").Child("[0]").Child("validity").Value)
+
+	ms.Signatures[0][0] ^= 0xff
+	tree, err = pretty.NewPrinter().Tree(ms)
+	require.NoError(t, err)
+	assert.Equal(t, "INVALID", tree.Child("signatures").Child("[0]").Child("validity").Value)
+}
+
+func TestPrinter_Payloads(t *testing.T) {
+	receipt, _ := tpkg.RandReceipt()
+	treasuryTx, _ := tpkg.RandTreasuryTransaction()
+	indexation, _ := tpkg.RandIndexation()
+
+	printer := pretty.NewPrinter()
+	for _, obj := range []interface{}{receipt, treasuryTx, indexation} {
+		var b bytes.Buffer
+		require.NoError(t, printer.Fprint(&b, obj))
+		assert.NotEmpty(t, b.String())
+	}
+
+	receiptTree, err := printer.Tree(receipt)
+	require.NoError(t, err)
+	var total uint64
+	for _, fund := range receipt.Funds {
+		total += fund.(*iotago.MigratedFundsEntry).Deposit
+	}
+	assert.Equal(t, "total "+pretty.FormatAmount(total), receiptTree.Child("funds").Value)
+
+	_, err = printer.Tree(struct{}{})
+	assert.True(t, errors.Is(err, pretty.ErrUnsupportedObject))
+}
+
+func TestPrinter_Render(t *testing.T) {
+	addr := iotago.Ed25519Address{}
+	output := &iotago.SigLockedSingleOutput{Address: &addr, Amount: 1000}
+
+	expected := "output: sig locked single\n" +
+		"  address: " + addr.Bech32(iotago.PrefixMainnet) + "\n" +
+		"  amount: 1000 i (1 Ki)\n"
+	assert.Equal(t, expected, pretty.NewPrinter().Sprint(output))
+}
@@ -0,0 +1,33 @@
+package sealed
+
+import (
+	"crypto/sha512"
+	"fmt"
+
+	"filippo.io/edwards25519"
+
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+// X25519PrivateKey returns the X25519 private key corresponding to the given Ed25519 private key,
+// which is the hashed and clamped seed as specified by RFC 8032.
+func X25519PrivateKey(prvKey ed25519.PrivateKey) []byte {
+	digest := sha512.Sum512(prvKey.Seed())
+	digest[0] &= 248
+	digest[31] &= 127
+	digest[31] |= 64
+	return digest[:32]
+}
+
+// X25519PublicKey returns the X25519 public key corresponding to the given Ed25519 public key,
+// which is the Montgomery form of its point.
+func X25519PublicKey(pubKey ed25519.PublicKey) ([]byte, error) {
+	if len(pubKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: public key has %d bytes", ErrInvalidKey, len(pubKey))
+	}
+	point, err := new(edwards25519.Point).SetBytes(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKey, err)
+	}
+	return point.BytesMontgomery(), nil
+}
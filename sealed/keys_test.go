@@ -0,0 +1,36 @@
+package sealed_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/sealed"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+func TestX25519Keys(t *testing.T) {
+	alice, bob := tpkg.RandEd25519PrivateKey(), tpkg.RandEd25519PrivateKey()
+
+	alicePubKey, err := sealed.X25519PublicKey(alice.Public().(ed25519.PublicKey))
+	require.NoError(t, err)
+	bobPubKey, err := sealed.X25519PublicKey(bob.Public().(ed25519.PublicKey))
+	require.NoError(t, err)
+
+	// the converted public key must match the one derived from the converted private key
+	derived, err := curve25519.X25519(sealed.X25519PrivateKey(alice), curve25519.Basepoint)
+	require.NoError(t, err)
+	assert.Equal(t, alicePubKey, derived)
+
+	aliceShared, err := curve25519.X25519(sealed.X25519PrivateKey(alice), bobPubKey)
+	require.NoError(t, err)
+	bobShared, err := curve25519.X25519(sealed.X25519PrivateKey(bob), alicePubKey)
+	require.NoError(t, err)
+	assert.Equal(t, aliceShared, bobShared)
+
+	_, err = sealed.X25519PublicKey(ed25519.PublicKey{1, 2, 3})
+	assert.ErrorIs(t, err, sealed.ErrInvalidKey)
+}
@@ -0,0 +1,352 @@
+// Package sealed provides authenticated and optionally encrypted Indexation payloads.
+//
+// A sealed Indexation carries its data together with the Ed25519 public key of its author and a signature over
+// its index and data. The data can be encrypted to one or more recipients, whose X25519 keys are derived from
+// their Ed25519 keys, so that only they can read it while everyone can verify its author:
+//
+//	indexation, err := sealed.Seal([]byte("sensor/42"), reading, authorPrvKey, recipientPubKey)
+//	msg, err := iotago.NewMessageBuilder().Payload(indexation).Tips(ctx, nodeHTTPAPIClient).Build()
+//
+//	for msg := range nodeEventAPIClient.MessagesWithIndex("sensor/42") {
+//		opened, err := sealed.OpenMessage(msg, sealed.WithRecipientKey(recipientPrvKey), sealed.WithTrustedAuthors(authorPubKey))
+//	}
+package sealed
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/iotaledger/hive.go/serializer"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+const (
+	// Version is the version of the sealed data format.
+	Version byte = 1
+	// MaxRecipients is the max. amount of recipients of encrypted data.
+	MaxRecipients = 255
+
+	// flag denoting encrypted data
+	flagEncrypted byte = 1
+
+	// the size of a content key wrapped for a recipient, including its Poly1305 tag
+	wrappedKeySize = chacha20poly1305.KeySize + 16
+)
+
+var (
+	// ErrInvalidSealedData gets returned when the data of an Indexation is not sealed data.
+	ErrInvalidSealedData = errors.New("invalid sealed data")
+	// ErrInvalidSignature gets returned when the signature of sealed data is invalid.
+	ErrInvalidSignature = errors.New("invalid signature of sealed data")
+	// ErrUntrustedAuthor gets returned when sealed data is not signed by a trusted author.
+	ErrUntrustedAuthor = errors.New("sealed data not signed by a trusted author")
+	// ErrNotARecipient gets returned when encrypted data can not be decrypted with the given key.
+	ErrNotARecipient = errors.New("not a recipient of the sealed data")
+	// ErrInvalidKey gets returned for invalid keys.
+	ErrInvalidKey = errors.New("invalid key")
+)
+
+// returns the message the author signs for the given index and sealed data without its signature.
+func signingMessage(index []byte, body []byte) []byte {
+	msg := make([]byte, 0, 1+len(index)+len(body))
+	msg = append(msg, byte(len(index)))
+	msg = append(msg, index...)
+	return append(msg, body...)
+}
+
+// derives the key wrapping the content key for a recipient from their shared secret.
+func wrappingKey(sharedSecret []byte, ephemeralPubKey []byte, recipientPubKey []byte) ([]byte, error) {
+	salt := append(append([]byte{}, ephemeralPubKey...), recipientPubKey...)
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, sharedSecret, salt, []byte("iota sealed indexation")), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Seal returns an Indexation with the given index holding the given data signed by the given author.
+// If recipients are given, the data is encrypted so that only they can decrypt it.
+func Seal(index []byte, data []byte, author ed25519.PrivateKey, recipients ...ed25519.PublicKey) (*iotago.Indexation, error) {
+	if len(recipients) > MaxRecipients {
+		return nil, fmt.Errorf("%d recipients exceed the max. of %d", len(recipients), MaxRecipients)
+	}
+	switch {
+	case len(index) < iotago.IndexationIndexMinLength:
+		return nil, fmt.Errorf("%w: index has %d bytes", iotago.ErrIndexationIndexUnderMinSize, len(index))
+	case len(index) > iotago.IndexationIndexMaxLength:
+		return nil, fmt.Errorf("%w: index has %d bytes", iotago.ErrIndexationIndexExceedsMaxSize, len(index))
+	}
+
+	var body bytes.Buffer
+	body.WriteByte(Version)
+	flags := byte(0)
+	if len(recipients) > 0 {
+		flags |= flagEncrypted
+	}
+	body.WriteByte(flags)
+	body.Write(author.Public().(ed25519.PublicKey))
+
+	if len(recipients) > 0 {
+		header, ciphertext, err := encrypt(index, data, recipients)
+		if err != nil {
+			return nil, err
+		}
+		body.Write(header)
+		data = ciphertext
+	}
+	if err := binary.Write(&body, binary.LittleEndian, uint32(len(data))); err != nil {
+		return nil, err
+	}
+	body.Write(data)
+
+	signature := ed25519.Sign(author, signingMessage(index, body.Bytes()))
+	body.Write(signature)
+	return &iotago.Indexation{Index: index, Data: body.Bytes()}, nil
+}
+
+// encrypts the data with a random content key which is wrapped for every recipient
+// and returns the ephemeral public key and wrapped keys followed by the ciphertext.
+func encrypt(index []byte, data []byte, recipients []ed25519.PublicKey) ([]byte, []byte, error) {
+	contentKey := make([]byte, chacha20poly1305.KeySize)
+	ephemeralPrvKey := make([]byte, curve25519.ScalarSize)
+	for _, b := range [][]byte{contentKey, ephemeralPrvKey} {
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("unable to generate key: %w", err)
+		}
+	}
+	ephemeralPubKey, err := curve25519.X25519(ephemeralPrvKey, curve25519.Basepoint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var header bytes.Buffer
+	header.Write(ephemeralPubKey)
+	header.WriteByte(byte(len(recipients)))
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	for i, recipient := range recipients {
+		recipientPubKey, err := X25519PublicKey(recipient)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid recipient %d: %w", i, err)
+		}
+		sharedSecret, err := curve25519.X25519(ephemeralPrvKey, recipientPubKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: recipient %d: %s", ErrInvalidKey, i, err)
+		}
+		key, err := wrappingKey(sharedSecret, ephemeralPubKey, recipientPubKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		aead, err := chacha20poly1305.New(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		// every wrapping key is used once, the nonce can therefore be constant
+		header.Write(aead.Seal(nil, nonce, contentKey, nil))
+	}
+
+	aead, err := chacha20poly1305.New(contentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the index and header are authenticated so that the ciphertext can not be moved
+	ciphertext := aead.Seal(nil, nonce, data, append(append([]byte{}, index...), header.Bytes()...))
+	return header.Bytes(), ciphertext, nil
+}
+
+// Opened is the content of a sealed Indexation.
+type Opened struct {
+	// The index of the Indexation.
+	Index []byte
+	// The data, nil if it is encrypted and no recipient key was given.
+	Data []byte
+	// The public key of the author who signed the data.
+	Author ed25519.PublicKey
+	// Whether the data was encrypted.
+	Encrypted bool
+}
+
+// OpenOptions define options for opening sealed data.
+type OpenOptions struct {
+	// The Ed25519 private key of the recipient, nil to only verify encrypted data.
+	recipientKey ed25519.PrivateKey
+	// The public keys of the trusted authors, nil if any author is accepted.
+	trustedAuthors map[string]struct{}
+}
+
+// applies the given OpenOption.
+func (oo *OpenOptions) apply(opts ...OpenOption) {
+	for _, opt := range opts {
+		opt(oo)
+	}
+}
+
+// WithRecipientKey sets the Ed25519 private key of the recipient to decrypt encrypted data with.
+func WithRecipientKey(prvKey ed25519.PrivateKey) OpenOption {
+	return func(opts *OpenOptions) {
+		opts.recipientKey = prvKey
+	}
+}
+
+// WithTrustedAuthors defines that only data signed by one of the given authors is accepted.
+func WithTrustedAuthors(pubKeys ...ed25519.PublicKey) OpenOption {
+	return func(opts *OpenOptions) {
+		opts.trustedAuthors = make(map[string]struct{}, len(pubKeys))
+		for _, pubKey := range pubKeys {
+			opts.trustedAuthors[string(pubKey)] = struct{}{}
+		}
+	}
+}
+
+// OpenOption is a function setting an option for opening sealed data.
+type OpenOption func(opts *OpenOptions)
+
+// OpenMessage verifies and opens the sealed Indexation held by the given message,
+// which might also be embedded in a transaction.
+func OpenMessage(msg *iotago.Message, opts ...OpenOption) (*Opened, error) {
+	var indexation *iotago.Indexation
+	switch payload := msg.Payload.(type) {
+	case *iotago.Indexation:
+		indexation = payload
+	case *iotago.Transaction:
+		if essence, ok := payload.Essence.(*iotago.TransactionEssence); ok {
+			indexation, _ = essence.Payload.(*iotago.Indexation)
+		}
+	}
+	if indexation == nil {
+		return nil, fmt.Errorf("%w: message holds no indexation", ErrInvalidSealedData)
+	}
+	return Open(indexation, opts...)
+}
+
+// Open verifies the signature of the given sealed Indexation and decrypts its data if it is encrypted and
+// a recipient key is given. Encrypted data is only verified if no recipient key is given.
+func Open(indexation *iotago.Indexation, opts ...OpenOption) (*Opened, error) {
+	options := &OpenOptions{}
+	options.apply(opts...)
+
+	data := indexation.Data
+	if len(data) < ed25519.SignatureSize {
+		return nil, fmt.Errorf("%w: data too short", ErrInvalidSealedData)
+	}
+	body, signature := data[:len(data)-ed25519.SignatureSize], data[len(data)-ed25519.SignatureSize:]
+
+	r := bytes.NewReader(body)
+	var version, flags byte
+	author := make(ed25519.PublicKey, ed25519.PublicKeySize)
+	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+		return nil, fmt.Errorf("%w: unable to read version", ErrInvalidSealedData)
+	}
+	if version != Version {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSealedData, version)
+	}
+	if err := binary.Read(r, binary.LittleEndian, &flags); err != nil {
+		return nil, fmt.Errorf("%w: unable to read flags", ErrInvalidSealedData)
+	}
+	if _, err := io.ReadFull(r, author); err != nil {
+		return nil, fmt.Errorf("%w: unable to read author", ErrInvalidSealedData)
+	}
+
+	if !ed25519.Verify(author, signingMessage(indexation.Index, body), signature) {
+		return nil, ErrInvalidSignature
+	}
+	if options.trustedAuthors != nil {
+		if _, trusted := options.trustedAuthors[string(author)]; !trusted {
+			return nil, ErrUntrustedAuthor
+		}
+	}
+
+	opened := &Opened{Index: indexation.Index, Author: author, Encrypted: flags&flagEncrypted != 0}
+	var header []byte
+	if opened.Encrypted {
+		var recipientCount byte
+		headerStart := len(body) - r.Len()
+		if _, err := r.Seek(curve25519.PointSize, io.SeekCurrent); err != nil {
+			return nil, err
+		}
+		if err := binary.Read(r, binary.LittleEndian, &recipientCount); err != nil {
+			return nil, fmt.Errorf("%w: unable to read recipient count", ErrInvalidSealedData)
+		}
+		headerEnd := len(body) - r.Len() + int(recipientCount)*wrappedKeySize
+		if headerEnd > len(body) {
+			return nil, fmt.Errorf("%w: unable to read wrapped keys", ErrInvalidSealedData)
+		}
+		header = body[headerStart:headerEnd]
+		if _, err := r.Seek(int64(headerEnd), io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	var dataLength uint32
+	if err := binary.Read(r, binary.LittleEndian, &dataLength); err != nil {
+		return nil, fmt.Errorf("%w: unable to read data length", ErrInvalidSealedData)
+	}
+	if int(dataLength) != r.Len() {
+		return nil, fmt.Errorf("%w: data length %d does not match the remaining %d bytes", ErrInvalidSealedData, dataLength, r.Len())
+	}
+	payload := body[len(body)-r.Len():]
+
+	switch {
+	case !opened.Encrypted:
+		opened.Data = payload
+	case options.recipientKey != nil:
+		plaintext, err := decrypt(indexation.Index, header, payload, options.recipientKey)
+		if err != nil {
+			return nil, err
+		}
+		opened.Data = plaintext
+	}
+	return opened, nil
+}
+
+// unwraps the content key with the given recipient key and decrypts the ciphertext.
+func decrypt(index []byte, header []byte, ciphertext []byte, recipientKey ed25519.PrivateKey) ([]byte, error) {
+	ephemeralPubKey := header[:curve25519.PointSize]
+	wrappedKeys := header[curve25519.PointSize+serializer.OneByte:]
+
+	recipientPrvKey := X25519PrivateKey(recipientKey)
+	recipientPubKey, err := X25519PublicKey(recipientKey.Public().(ed25519.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+	sharedSecret, err := curve25519.X25519(recipientPrvKey, ephemeralPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid ephemeral key: %s", ErrInvalidSealedData, err)
+	}
+	key, err := wrappingKey(sharedSecret, ephemeralPubKey, recipientPubKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	for i := 0; i+wrappedKeySize <= len(wrappedKeys); i += wrappedKeySize {
+		contentKey, err := aead.Open(nil, nonce, wrappedKeys[i:i+wrappedKeySize], nil)
+		if err != nil {
+			continue
+		}
+		contentAEAD, err := chacha20poly1305.New(contentKey)
+		if err != nil {
+			return nil, err
+		}
+		plaintext, err := contentAEAD.Open(nil, nonce, ciphertext, append(append([]byte{}, index...), header...))
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to decrypt data: %s", ErrInvalidSealedData, err)
+		}
+		return plaintext, nil
+	}
+	return nil, ErrNotARecipient
+}
@@ -0,0 +1,136 @@
+package sealed_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/serializer"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/sealed"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+func TestSealOpen(t *testing.T) {
+	author, recipient, other := tpkg.RandEd25519PrivateKey(), tpkg.RandEd25519PrivateKey(), tpkg.RandEd25519PrivateKey()
+	authorPubKey := author.Public().(ed25519.PublicKey)
+	recipientPubKey := recipient.Public().(ed25519.PublicKey)
+	index, data := []byte("sensor/42"), []byte(`{"temperature":21.5}`)
+
+	type test struct {
+		name       string
+		recipients []ed25519.PublicKey
+		tamper     func(indexation *iotago.Indexation)
+		opts       []sealed.OpenOption
+		data       []byte
+		encrypted  bool
+		wantErr    error
+	}
+	tests := []test{
+		{name: "ok - signed", opts: []sealed.OpenOption{sealed.WithTrustedAuthors(authorPubKey)}, data: data},
+		{
+			name:       "ok - encrypted",
+			recipients: []ed25519.PublicKey{other.Public().(ed25519.PublicKey), recipientPubKey},
+			opts:       []sealed.OpenOption{sealed.WithRecipientKey(recipient)},
+			data:       data,
+			encrypted:  true,
+		},
+		{name: "ok - encrypted without recipient key", recipients: []ed25519.PublicKey{recipientPubKey}, encrypted: true},
+		{
+			name:       "fail - not a recipient",
+			recipients: []ed25519.PublicKey{recipientPubKey},
+			opts:       []sealed.OpenOption{sealed.WithRecipientKey(other)},
+			wantErr:    sealed.ErrNotARecipient,
+		},
+		{
+			name:    "fail - untrusted author",
+			opts:    []sealed.OpenOption{sealed.WithTrustedAuthors(recipientPubKey)},
+			wantErr: sealed.ErrUntrustedAuthor,
+		},
+		{
+			name: "fail - tampered data",
+			tamper: func(indexation *iotago.Indexation) {
+				indexation.Data[len(indexation.Data)-ed25519.SignatureSize-1] ^= 1
+			},
+			wantErr: sealed.ErrInvalidSignature,
+		},
+		{
+			name:       "fail - moved to other index",
+			recipients: []ed25519.PublicKey{recipientPubKey},
+			tamper:     func(indexation *iotago.Indexation) { indexation.Index = []byte("sensor/43") },
+			opts:       []sealed.OpenOption{sealed.WithRecipientKey(recipient)},
+			wantErr:    sealed.ErrInvalidSignature,
+		},
+		{
+			name:    "fail - not sealed",
+			tamper:  func(indexation *iotago.Indexation) { indexation.Data = data },
+			wantErr: sealed.ErrInvalidSealedData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexation, err := sealed.Seal(index, data, author, tt.recipients...)
+			require.NoError(t, err)
+			if tt.tamper != nil {
+				tt.tamper(indexation)
+			}
+
+			opened, err := sealed.Open(indexation, tt.opts...)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, index, opened.Index)
+			assert.Equal(t, tt.data, opened.Data)
+			assert.Equal(t, authorPubKey, opened.Author)
+			assert.Equal(t, tt.encrypted, opened.Encrypted)
+			if tt.encrypted {
+				assert.NotContains(t, string(indexation.Data), string(data))
+			}
+		})
+	}
+}
+
+func TestOpenMessage(t *testing.T) {
+	author, recipient := tpkg.RandEd25519PrivateKey(), tpkg.RandEd25519PrivateKey()
+	data := []byte("reading")
+
+	indexation, err := sealed.Seal([]byte("sensor/42"), data, author, recipient.Public().(ed25519.PublicKey))
+	require.NoError(t, err)
+	msg, err := iotago.NewMessageBuilder().ParentsMessageIDs(iotago.MessageIDs{tpkg.Rand32ByteArray()}).Payload(indexation).Build()
+	require.NoError(t, err)
+
+	// the message survives serialization as it would when received from a node
+	msgBytes, err := msg.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+	received := &iotago.Message{}
+	_, err = received.Deserialize(msgBytes, serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	opened, err := sealed.OpenMessage(received, sealed.WithRecipientKey(recipient))
+	require.NoError(t, err)
+	assert.Equal(t, data, opened.Data)
+
+	// the indexation might also be embedded in a transaction
+	txMsg := &iotago.Message{Payload: &iotago.Transaction{Essence: &iotago.TransactionEssence{Payload: indexation}}}
+	opened, err = sealed.OpenMessage(txMsg, sealed.WithRecipientKey(recipient))
+	require.NoError(t, err)
+	assert.Equal(t, data, opened.Data)
+
+	_, err = sealed.OpenMessage(&iotago.Message{})
+	assert.ErrorIs(t, err, sealed.ErrInvalidSealedData)
+	_, err = sealed.OpenMessage(&iotago.Message{Payload: &iotago.Transaction{Essence: &iotago.TransactionEssence{}}})
+	assert.ErrorIs(t, err, sealed.ErrInvalidSealedData)
+}
+
+func TestSeal_Index(t *testing.T) {
+	author := tpkg.RandEd25519PrivateKey()
+	_, err := sealed.Seal(nil, []byte("reading"), author)
+	assert.ErrorIs(t, err, iotago.ErrIndexationIndexUnderMinSize)
+	_, err = sealed.Seal(tpkg.RandBytes(iotago.IndexationIndexMaxLength+1), []byte("reading"), author)
+	assert.ErrorIs(t, err, iotago.ErrIndexationIndexExceedsMaxSize)
+}
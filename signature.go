@@ -52,17 +52,30 @@ type Ed25519Signature struct {
 
 // Valid verifies whether given the message and Ed25519 address, the signature is valid.
 func (e *Ed25519Signature) Valid(msg []byte, addr *Ed25519Address) error {
+	if err := e.matchesAddress(addr); err != nil {
+		return err
+	}
+	if valid := ed25519.Verify(e.PublicKey[:], msg, e.Signature[:]); !valid {
+		return e.invalidErr(addr)
+	}
+	return nil
+}
+
+// checks whether the public key of the signature corresponds to the given address.
+func (e *Ed25519Signature) matchesAddress(addr *Ed25519Address) error {
 	// an address is the Blake2b 256 hash of the public key
 	addrFromPubKey := AddressFromEd25519PubKey(e.PublicKey[:])
 	if !bytes.Equal(addr[:], addrFromPubKey[:]) {
 		return fmt.Errorf("%w: address %s, public key %s", ErrEd25519PubKeyAndAddrMismatch, addr[:], addrFromPubKey)
 	}
-	if valid := ed25519.Verify(e.PublicKey[:], msg, e.Signature[:]); !valid {
-		return fmt.Errorf("%w: address %s, public key %s, signature %s ", ErrEd25519SignatureInvalid, addr[:], e.PublicKey, e.Signature)
-	}
 	return nil
 }
 
+// returns the error describing that the signature is invalid.
+func (e *Ed25519Signature) invalidErr(addr *Ed25519Address) error {
+	return fmt.Errorf("%w: address %s, public key %s, signature %s ", ErrEd25519SignatureInvalid, addr[:], e.PublicKey, e.Signature)
+}
+
 func (e *Ed25519Signature) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
 		if err := serializer.CheckMinByteLength(Ed25519SignatureSerializedBytesSize, len(data)); err != nil {
@@ -0,0 +1,374 @@
+package snapshot
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/iotaledger/hive.go/serializer"
+
+	"github.com/iotaledger/iota.go/v2"
+)
+
+// the sections of a snapshot in the order they are stored.
+type section int
+
+const (
+	sectionSolidEntryPoints section = iota
+	sectionOutputs
+	sectionMilestoneDiffs
+	sectionEnd
+)
+
+// the default options applied to the Reader.
+var defaultReaderOptions = []ReaderOption{
+	WithReaderVerifySupply(false),
+}
+
+// ReaderOptions define options for the Reader.
+type ReaderOptions struct {
+	// Whether the unspent outputs and the treasury of full snapshots must sum up to the token supply.
+	verifySupply bool
+}
+
+// applies the given ReaderOption.
+func (ro *ReaderOptions) apply(opts ...ReaderOption) {
+	for _, opt := range opts {
+		opt(ro)
+	}
+}
+
+// WithReaderVerifySupply defines whether the unspent outputs and the treasury of a full snapshot
+// must sum up to iotago.TokenSupply. Networks with a different supply must not enable it.
+func WithReaderVerifySupply(verify bool) ReaderOption {
+	return func(opts *ReaderOptions) {
+		opts.verifySupply = verify
+	}
+}
+
+// ReaderOption is a function setting a Reader option.
+type ReaderOption func(opts *ReaderOptions)
+
+// NewReader creates a new Reader reading the snapshot from the given io.Reader.
+// The header is read and validated immediately.
+func NewReader(r io.Reader, opts ...ReaderOption) (*Reader, error) {
+	options := &ReaderOptions{}
+	options.apply(defaultReaderOptions...)
+	options.apply(opts...)
+
+	reader := &Reader{r: bufio.NewReader(r), opts: options}
+	header, err := reader.readHeader()
+	if err != nil {
+		return nil, err
+	}
+	reader.header = header
+	return reader, nil
+}
+
+// Reader streams the sections of a snapshot. The sections must be read in order, sections which are not
+// consumed are skipped when a later section is read.
+type Reader struct {
+	r       *bufio.Reader
+	opts    *ReaderOptions
+	header  *FileHeader
+	section section
+}
+
+// Header returns the header of the snapshot.
+func (r *Reader) Header() *FileHeader {
+	return r.header
+}
+
+// wraps io errors of truncated snapshots with ErrCorrupted.
+func corrupted(err error, what string) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: unexpected end of data reading %s", ErrCorrupted, what)
+	}
+	return fmt.Errorf("unable to read %s: %w", what, err)
+}
+
+func (r *Reader) readNum(data interface{}, what string) error {
+	if err := binary.Read(r.r, binary.LittleEndian, data); err != nil {
+		return corrupted(err, what)
+	}
+	return nil
+}
+
+func (r *Reader) readBytes(data []byte, what string) error {
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return corrupted(err, what)
+	}
+	return nil
+}
+
+func (r *Reader) readHeader() (*FileHeader, error) {
+	header := &FileHeader{}
+	if err := r.readNum(&header.Version, "version"); err != nil {
+		return nil, err
+	}
+	if header.Version != SupportedFormatVersion {
+		return nil, fmt.Errorf("%w: version %d, supported is %d", ErrUnsupportedFormatVersion, header.Version, SupportedFormatVersion)
+	}
+	if err := r.readNum(&header.Type, "type"); err != nil {
+		return nil, err
+	}
+	if header.Type != Full && header.Type != Delta {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownType, header.Type)
+	}
+
+	for _, field := range []struct {
+		data interface{}
+		what string
+	}{
+		{&header.Timestamp, "timestamp"},
+		{&header.NetworkID, "network ID"},
+		{&header.SEPMilestoneIndex, "solid entry point milestone index"},
+		{&header.LedgerMilestoneIndex, "ledger milestone index"},
+		{&header.SEPCount, "solid entry point count"},
+	} {
+		if err := r.readNum(field.data, field.what); err != nil {
+			return nil, err
+		}
+	}
+	if header.Type == Full {
+		if err := r.readNum(&header.OutputCount, "output count"); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.readNum(&header.MilestoneDiffCount, "milestone diff count"); err != nil {
+		return nil, err
+	}
+	if header.Type == Full {
+		treasuryOutput, err := r.readTreasuryOutput()
+		if err != nil {
+			return nil, err
+		}
+		header.TreasuryOutput = treasuryOutput
+	}
+
+	return header, validateHeader(header)
+}
+
+// checks that the milestone indices of the header match its amount of milestone diffs.
+func validateHeader(header *FileHeader) error {
+	var span uint32
+	switch header.Type {
+	case Full:
+		if header.LedgerMilestoneIndex < header.SEPMilestoneIndex {
+			return fmt.Errorf("%w: ledger milestone index %d is below solid entry point milestone index %d", ErrCorrupted, header.LedgerMilestoneIndex, header.SEPMilestoneIndex)
+		}
+		span = header.LedgerMilestoneIndex - header.SEPMilestoneIndex
+	default:
+		if header.SEPMilestoneIndex < header.LedgerMilestoneIndex {
+			return fmt.Errorf("%w: solid entry point milestone index %d is below ledger milestone index %d", ErrCorrupted, header.SEPMilestoneIndex, header.LedgerMilestoneIndex)
+		}
+		span = header.SEPMilestoneIndex - header.LedgerMilestoneIndex
+	}
+	if uint64(span) != header.MilestoneDiffCount {
+		return fmt.Errorf("%w: %d milestone diffs between milestone index %d and %d", ErrCorrupted, header.MilestoneDiffCount, header.LedgerMilestoneIndex, header.SEPMilestoneIndex)
+	}
+	return nil
+}
+
+func (r *Reader) readTreasuryOutput() (*TreasuryOutput, error) {
+	treasuryOutput := &TreasuryOutput{}
+	if err := r.readBytes(treasuryOutput.MilestoneID[:], "treasury output milestone ID"); err != nil {
+		return nil, err
+	}
+	if err := r.readNum(&treasuryOutput.Amount, "treasury output amount"); err != nil {
+		return nil, err
+	}
+	return treasuryOutput, nil
+}
+
+func (r *Reader) readOutput() (*Output, error) {
+	output := &Output{UTXOInput: &iotago.UTXOInput{}}
+	if err := r.readBytes(output.MessageID[:], "output message ID"); err != nil {
+		return nil, err
+	}
+	if err := r.readBytes(output.UTXOInput.TransactionID[:], "output transaction ID"); err != nil {
+		return nil, err
+	}
+	if err := r.readNum(&output.UTXOInput.TransactionOutputIndex, "output index"); err != nil {
+		return nil, err
+	}
+
+	// output type, address and amount
+	var types [2]byte
+	if err := r.readBytes(types[:], "output and address type"); err != nil {
+		return nil, err
+	}
+	if types[1] != iotago.AddressEd25519 {
+		return nil, fmt.Errorf("%w: unsupported address type %d in output", ErrCorrupted, types[1])
+	}
+	outputData := make([]byte, len(types)+iotago.Ed25519AddressBytesLength+serializer.UInt64ByteSize)
+	copy(outputData, types[:])
+	if err := r.readBytes(outputData[len(types):], "output"); err != nil {
+		return nil, err
+	}
+
+	seri, err := iotago.OutputSelector(uint32(types[0]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCorrupted, err)
+	}
+	if _, err := seri.Deserialize(outputData, serializer.DeSeriModePerformValidation); err != nil {
+		return nil, fmt.Errorf("%w: invalid output %s: %s", ErrCorrupted, output.UTXOInput.ID().ToHex(), err)
+	}
+	var isOutput bool
+	if output.Output, isOutput = seri.(iotago.Output); !isOutput {
+		return nil, fmt.Errorf("%w: %s", ErrCorrupted, iotago.ErrUnknownOutputType)
+	}
+	return output, nil
+}
+
+func (r *Reader) readMilestoneDiff() (*MilestoneDiff, error) {
+	var msLength uint32
+	if err := r.readNum(&msLength, "milestone length"); err != nil {
+		return nil, err
+	}
+	if msLength > iotago.MessageBinSerializedMaxSize {
+		return nil, fmt.Errorf("%w: milestone length %d exceeds the max. message size", ErrCorrupted, msLength)
+	}
+	msData := make([]byte, msLength)
+	if err := r.readBytes(msData, "milestone"); err != nil {
+		return nil, err
+	}
+	diff := &MilestoneDiff{Milestone: &iotago.Milestone{}}
+	if _, err := diff.Milestone.Deserialize(msData, serializer.DeSeriModePerformValidation); err != nil {
+		return nil, fmt.Errorf("%w: invalid milestone: %s", ErrCorrupted, err)
+	}
+
+	if diff.Milestone.Receipt != nil {
+		treasuryOutput, err := r.readTreasuryOutput()
+		if err != nil {
+			return nil, err
+		}
+		diff.SpentTreasuryOutput = treasuryOutput
+	}
+
+	var createdCount uint64
+	if err := r.readNum(&createdCount, "created output count"); err != nil {
+		return nil, err
+	}
+	for i := uint64(0); i < createdCount; i++ {
+		output, err := r.readOutput()
+		if err != nil {
+			return nil, err
+		}
+		diff.Created = append(diff.Created, output)
+	}
+
+	var consumedCount uint64
+	if err := r.readNum(&consumedCount, "consumed output count"); err != nil {
+		return nil, err
+	}
+	for i := uint64(0); i < consumedCount; i++ {
+		output, err := r.readOutput()
+		if err != nil {
+			return nil, err
+		}
+		spent := &Spent{Output: output}
+		if err := r.readBytes(spent.TargetTransactionID[:], "target transaction ID"); err != nil {
+			return nil, err
+		}
+		diff.Consumed = append(diff.Consumed, spent)
+	}
+	return diff, nil
+}
+
+// skips the sections preceding the given one and checks that it was not read already.
+func (r *Reader) seek(s section) error {
+	if r.section > s {
+		return fmt.Errorf("%w: section %d was already read", ErrWrongSectionOrder, s)
+	}
+	for r.section < s {
+		var err error
+		switch r.section {
+		case sectionSolidEntryPoints:
+			err = r.ForEachSolidEntryPoint(func(iotago.MessageID) error { return nil })
+		case sectionOutputs:
+			err = r.ForEachOutput(func(*Output) error { return nil })
+		case sectionMilestoneDiffs:
+			err = r.ForEachMilestoneDiff(func(*MilestoneDiff) error { return nil })
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEachSolidEntryPoint reads the solid entry points and passes them to the given function.
+func (r *Reader) ForEachSolidEntryPoint(f func(sep iotago.MessageID) error) error {
+	if err := r.seek(sectionSolidEntryPoints); err != nil {
+		return err
+	}
+	for i := uint64(0); i < r.header.SEPCount; i++ {
+		var sep iotago.MessageID
+		if err := r.readBytes(sep[:], "solid entry point"); err != nil {
+			return err
+		}
+		if err := f(sep); err != nil {
+			return err
+		}
+	}
+	r.section = sectionOutputs
+	return nil
+}
+
+// ForEachOutput reads the unspent outputs and passes them to the given function.
+func (r *Reader) ForEachOutput(f func(output *Output) error) error {
+	if err := r.seek(sectionOutputs); err != nil {
+		return err
+	}
+	var supply uint64
+	for i := uint64(0); i < r.header.OutputCount; i++ {
+		output, err := r.readOutput()
+		if err != nil {
+			return err
+		}
+		deposit, err := output.Output.Deposit()
+		if err != nil {
+			return err
+		}
+		supply += deposit
+		if err := f(output); err != nil {
+			return err
+		}
+	}
+	if r.opts.verifySupply && r.header.Type == Full {
+		supply += r.header.TreasuryOutput.Amount
+		if supply != iotago.TokenSupply {
+			return fmt.Errorf("%w: outputs and treasury sum up to %d instead of the token supply %d", ErrCorrupted, supply, iotago.TokenSupply)
+		}
+	}
+	r.section = sectionMilestoneDiffs
+	return nil
+}
+
+// ForEachMilestoneDiff reads the milestone diffs and passes them to the given function.
+// It checks that the milestone indices of the diffs follow the header and that no data follows the last diff.
+func (r *Reader) ForEachMilestoneDiff(f func(diff *MilestoneDiff) error) error {
+	if err := r.seek(sectionMilestoneDiffs); err != nil {
+		return err
+	}
+	for i := uint64(0); i < r.header.MilestoneDiffCount; i++ {
+		diff, err := r.readMilestoneDiff()
+		if err != nil {
+			return err
+		}
+		if expected := expectedMilestoneDiffIndex(r.header, i); diff.Milestone.Index != expected {
+			return fmt.Errorf("%w: milestone diff %d has index %d instead of %d", ErrCorrupted, i, diff.Milestone.Index, expected)
+		}
+		if err := f(diff); err != nil {
+			return err
+		}
+	}
+	if _, err := r.r.ReadByte(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: data after the last milestone diff", ErrCorrupted)
+	}
+	r.section = sectionEnd
+	return nil
+}
@@ -0,0 +1,132 @@
+// Package snapshot provides reading and writing of the node snapshot file format, which holds the ledger state
+// at a milestone.
+//
+// A snapshot file consists of a header, the solid entry points, the unspent outputs (full snapshots only) and
+// milestone diffs. Full snapshots hold the ledger at the ledger milestone index and the diffs to roll it back to the
+// solid entry point milestone index, delta snapshots hold the diffs to roll a full snapshot forward.
+//
+// The sections are streamed so that large snapshots need not be held in memory:
+//
+//	r, err := snapshot.NewReader(file)
+//	err = r.ForEachOutput(func(output *snapshot.Output) error {
+//		// output.UTXOInput, output.Output
+//		return nil
+//	})
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// SupportedFormatVersion is the snapshot file format version supported by this package.
+	SupportedFormatVersion byte = 1
+)
+
+var (
+	// ErrUnsupportedFormatVersion gets returned when a snapshot has an unsupported format version.
+	ErrUnsupportedFormatVersion = errors.New("unsupported snapshot format version")
+	// ErrUnknownType gets returned for unknown snapshot types.
+	ErrUnknownType = errors.New("unknown snapshot type")
+	// ErrCorrupted gets returned when the data of a snapshot does not match its header or is inconsistent.
+	ErrCorrupted = errors.New("corrupted snapshot")
+	// ErrWrongSectionOrder gets returned when the sections of a snapshot are written out of order.
+	ErrWrongSectionOrder = errors.New("snapshot sections written out of order")
+)
+
+// Type defines the type of a snapshot.
+type Type byte
+
+const (
+	// Full is a snapshot holding the complete ledger state.
+	Full Type = iota
+	// Delta is a snapshot holding the milestone diffs following a full snapshot.
+	Delta
+)
+
+// String returns the name of the Type.
+func (t Type) String() string {
+	switch t {
+	case Full:
+		return "full"
+	case Delta:
+		return "delta"
+	default:
+		return fmt.Sprintf("unknown snapshot type %d", byte(t))
+	}
+}
+
+// FileHeader is the header of a snapshot file.
+type FileHeader struct {
+	// The format version of the snapshot.
+	Version byte
+	// The type of the snapshot.
+	Type Type
+	// The unix timestamp of when the snapshot was created.
+	Timestamp uint64
+	// The ID of the network the snapshot belongs to.
+	NetworkID uint64
+	// The milestone index of the solid entry points.
+	SEPMilestoneIndex uint32
+	// The milestone index of the ledger state, for delta snapshots the ledger milestone index of the full snapshot.
+	LedgerMilestoneIndex uint32
+	// The amount of solid entry points.
+	SEPCount uint64
+	// The amount of unspent outputs, always zero for delta snapshots.
+	OutputCount uint64
+	// The amount of milestone diffs.
+	MilestoneDiffCount uint64
+	// The treasury output of the ledger state, only present in full snapshots.
+	TreasuryOutput *TreasuryOutput
+}
+
+// TreasuryOutput is the treasury output of the ledger state.
+type TreasuryOutput struct {
+	// The ID of the milestone which generated the treasury output.
+	MilestoneID iotago.MilestoneID
+	// The amount of funds held by the treasury.
+	Amount uint64
+}
+
+// Output is an unspent output of the ledger state.
+type Output struct {
+	// The ID of the message which contained the transaction creating the output.
+	MessageID iotago.MessageID
+	// The transaction ID and index of the output.
+	UTXOInput *iotago.UTXOInput
+	// The output itself.
+	Output iotago.Output
+}
+
+// Spent is an output consumed by a transaction.
+type Spent struct {
+	// The consumed output.
+	Output *Output
+	// The ID of the transaction which consumed the output.
+	TargetTransactionID iotago.TransactionID
+}
+
+// MilestoneDiff holds the outputs a milestone created and consumed.
+type MilestoneDiff struct {
+	// The milestone.
+	Milestone *iotago.Milestone
+	// The treasury output the receipt of the milestone spent, only present if the milestone contains a receipt.
+	SpentTreasuryOutput *TreasuryOutput
+	// The outputs created by the milestone.
+	Created []*Output
+	// The outputs consumed by the milestone.
+	Consumed []*Spent
+}
+
+// the index of the milestone the i-th diff of a snapshot with the given header must have.
+// Full snapshots hold the diffs from the ledger milestone index backwards,
+// delta snapshots the diffs following the ledger milestone index.
+func expectedMilestoneDiffIndex(header *FileHeader, i uint64) uint32 {
+	if header.Type == Full {
+		return header.LedgerMilestoneIndex - uint32(i)
+	}
+	return header.LedgerMilestoneIndex + 1 + uint32(i)
+}
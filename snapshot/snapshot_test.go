@@ -0,0 +1,232 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/snapshot"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+func randOutput() *snapshot.Output {
+	output, _ := tpkg.RandSigLockedSingleOutput(iotago.AddressEd25519)
+	// outputs are serialized with validation which rejects zero deposits
+	output.Amount = uint64(rand.Intn(10000)) + 1
+	utxoInput, _ := tpkg.RandUTXOInput()
+	return &snapshot.Output{MessageID: tpkg.Rand32ByteArray(), UTXOInput: utxoInput, Output: output}
+}
+
+func randMilestoneDiff(index uint32, withReceipt bool) *snapshot.MilestoneDiff {
+	ms, _ := tpkg.RandMilestone(nil)
+	ms.Index = index
+	diff := &snapshot.MilestoneDiff{
+		Milestone: ms,
+		Created:   []*snapshot.Output{randOutput(), randOutput()},
+		Consumed:  []*snapshot.Spent{{Output: randOutput(), TargetTransactionID: tpkg.Rand32ByteArray()}},
+	}
+	if withReceipt {
+		ms.Receipt, _ = tpkg.RandReceipt()
+		diff.SpentTreasuryOutput = &snapshot.TreasuryOutput{MilestoneID: tpkg.Rand32ByteArray(), Amount: 1337}
+	}
+	return diff
+}
+
+// the contents of a snapshot.
+type testSnapshot struct {
+	header *snapshot.FileHeader
+	seps   iotago.MessageIDs
+	outs   []*snapshot.Output
+	diffs  []*snapshot.MilestoneDiff
+}
+
+func (ts *testSnapshot) write(t *testing.T, ws io.WriteSeeker) {
+	w, err := snapshot.NewWriter(ws, ts.header)
+	require.NoError(t, err)
+	for _, sep := range ts.seps {
+		require.NoError(t, w.WriteSolidEntryPoint(sep))
+	}
+	for _, output := range ts.outs {
+		require.NoError(t, w.WriteOutput(output))
+	}
+	for _, diff := range ts.diffs {
+		require.NoError(t, w.WriteMilestoneDiff(diff))
+	}
+	require.NoError(t, w.Close())
+}
+
+func (ts *testSnapshot) writeFile(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "snapshot.bin")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	ts.write(t, file)
+	return path
+}
+
+func readAll(r *snapshot.Reader) (*testSnapshot, error) {
+	ts := &testSnapshot{header: r.Header()}
+	if err := r.ForEachSolidEntryPoint(func(sep iotago.MessageID) error {
+		ts.seps = append(ts.seps, sep)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := r.ForEachOutput(func(output *snapshot.Output) error {
+		ts.outs = append(ts.outs, output)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := r.ForEachMilestoneDiff(func(diff *snapshot.MilestoneDiff) error {
+		ts.diffs = append(ts.diffs, diff)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+func fullSnapshot() *testSnapshot {
+	return &testSnapshot{
+		header: &snapshot.FileHeader{
+			Type:                 snapshot.Full,
+			Timestamp:            1_600_000_000,
+			NetworkID:            1337,
+			SEPMilestoneIndex:    8,
+			LedgerMilestoneIndex: 10,
+			TreasuryOutput:       &snapshot.TreasuryOutput{MilestoneID: tpkg.Rand32ByteArray(), Amount: 1_000_000},
+		},
+		seps:  iotago.MessageIDs{tpkg.Rand32ByteArray(), tpkg.Rand32ByteArray()},
+		outs:  []*snapshot.Output{randOutput(), randOutput(), randOutput()},
+		diffs: []*snapshot.MilestoneDiff{randMilestoneDiff(10, true), randMilestoneDiff(9, false)},
+	}
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot *testSnapshot
+	}{
+		{name: "full", snapshot: fullSnapshot()},
+		{
+			name: "delta",
+			snapshot: &testSnapshot{
+				header: &snapshot.FileHeader{Type: snapshot.Delta, NetworkID: 1337, SEPMilestoneIndex: 13, LedgerMilestoneIndex: 10},
+				seps:   iotago.MessageIDs{tpkg.Rand32ByteArray()},
+				diffs:  []*snapshot.MilestoneDiff{randMilestoneDiff(11, false), randMilestoneDiff(12, false), randMilestoneDiff(13, true)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := os.Open(tt.snapshot.writeFile(t))
+			require.NoError(t, err)
+			defer file.Close()
+
+			r, err := snapshot.NewReader(file)
+			require.NoError(t, err)
+			read, err := readAll(r)
+			require.NoError(t, err)
+
+			expectedHeader := *tt.snapshot.header
+			expectedHeader.Version = snapshot.SupportedFormatVersion
+			expectedHeader.SEPCount = uint64(len(tt.snapshot.seps))
+			expectedHeader.OutputCount = uint64(len(tt.snapshot.outs))
+			expectedHeader.MilestoneDiffCount = uint64(len(tt.snapshot.diffs))
+			assert.Equal(t, &expectedHeader, read.header)
+			assert.Equal(t, tt.snapshot.seps, read.seps)
+			assert.Equal(t, tt.snapshot.outs, read.outs)
+			assert.Equal(t, tt.snapshot.diffs, read.diffs)
+		})
+	}
+}
+
+func TestReader_SkipSections(t *testing.T) {
+	ts := fullSnapshot()
+	file, err := os.Open(ts.writeFile(t))
+	require.NoError(t, err)
+	defer file.Close()
+
+	r, err := snapshot.NewReader(file)
+	require.NoError(t, err)
+	var indices []uint32
+	require.NoError(t, r.ForEachMilestoneDiff(func(diff *snapshot.MilestoneDiff) error {
+		indices = append(indices, diff.Milestone.Index)
+		return nil
+	}))
+	assert.Equal(t, []uint32{10, 9}, indices)
+
+	err = r.ForEachOutput(func(*snapshot.Output) error { return nil })
+	assert.True(t, errors.Is(err, snapshot.ErrWrongSectionOrder))
+}
+
+func TestReader_Integrity(t *testing.T) {
+	ts := fullSnapshot()
+	data, err := os.ReadFile(ts.writeFile(t))
+	require.NoError(t, err)
+
+	read := func(data []byte, opts ...snapshot.ReaderOption) error {
+		r, err := snapshot.NewReader(bytes.NewReader(data), opts...)
+		if err != nil {
+			return err
+		}
+		_, err = readAll(r)
+		return err
+	}
+	require.NoError(t, read(data))
+
+	corrupt := func(offset int, value byte) []byte {
+		corrupted := append([]byte{}, data...)
+		corrupted[offset] = value
+		return corrupted
+	}
+
+	tests := []struct {
+		name   string
+		data   []byte
+		opts   []snapshot.ReaderOption
+		target error
+	}{
+		{name: "version", data: corrupt(0, 2), target: snapshot.ErrUnsupportedFormatVersion},
+		{name: "type", data: corrupt(1, 5), target: snapshot.ErrUnknownType},
+		// the ledger milestone index at offset 22 no longer matches the diff count
+		{name: "milestone indices", data: corrupt(22, 11), target: snapshot.ErrCorrupted},
+		{name: "truncated", data: data[:len(data)-1], target: snapshot.ErrCorrupted},
+		{name: "trailing data", data: append(append([]byte{}, data...), 0), target: snapshot.ErrCorrupted},
+		{name: "supply", data: data, opts: []snapshot.ReaderOption{snapshot.WithReaderVerifySupply(true)}, target: snapshot.ErrCorrupted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := read(tt.data, tt.opts...)
+			assert.True(t, errors.Is(err, tt.target), "expected %v, got %v", tt.target, err)
+		})
+	}
+}
+
+func TestWriter_Order(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.bin")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	_, err = snapshot.NewWriter(file, &snapshot.FileHeader{Type: snapshot.Full})
+	assert.True(t, errors.Is(err, snapshot.ErrCorrupted))
+
+	w, err := snapshot.NewWriter(file, &snapshot.FileHeader{Type: snapshot.Delta, SEPMilestoneIndex: 2, LedgerMilestoneIndex: 0})
+	require.NoError(t, err)
+	assert.True(t, errors.Is(w.WriteOutput(randOutput()), snapshot.ErrWrongSectionOrder))
+	assert.True(t, errors.Is(w.WriteMilestoneDiff(randMilestoneDiff(2, false)), snapshot.ErrWrongSectionOrder))
+	require.NoError(t, w.WriteMilestoneDiff(randMilestoneDiff(1, false)))
+	assert.True(t, errors.Is(w.WriteSolidEntryPoint(tpkg.Rand32ByteArray()), snapshot.ErrWrongSectionOrder))
+	// the diff of milestone 2 is missing
+	assert.True(t, errors.Is(w.Close(), snapshot.ErrCorrupted))
+}
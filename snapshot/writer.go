@@ -0,0 +1,212 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/iotaledger/hive.go/serializer"
+
+	"github.com/iotaledger/iota.go/v2"
+)
+
+// NewWriter creates a new Writer writing a snapshot with the given header to the given io.WriteSeeker.
+// The counts of the header are ignored, they are computed from the written sections and stored on Close.
+func NewWriter(ws io.WriteSeeker, header *FileHeader) (*Writer, error) {
+	if header.Type != Full && header.Type != Delta {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownType, header.Type)
+	}
+	if header.Type == Full && header.TreasuryOutput == nil {
+		return nil, fmt.Errorf("%w: full snapshots require a treasury output", ErrCorrupted)
+	}
+
+	start, err := ws.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine snapshot start: %w", err)
+	}
+
+	headerCopy := *header
+	headerCopy.Version = SupportedFormatVersion
+	headerCopy.SEPCount, headerCopy.OutputCount, headerCopy.MilestoneDiffCount = 0, 0, 0
+	w := &Writer{ws: ws, w: bufio.NewWriter(ws), start: start, header: &headerCopy}
+	if err := w.writeHeader(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Writer writes a snapshot. The sections must be written in order: solid entry points, outputs and milestone diffs.
+type Writer struct {
+	ws      io.WriteSeeker
+	w       *bufio.Writer
+	start   int64
+	header  *FileHeader
+	section section
+}
+
+func (w *Writer) writeNum(data interface{}) error {
+	return binary.Write(w.w, binary.LittleEndian, data)
+}
+
+func (w *Writer) writeHeader() error {
+	var buf bytes.Buffer
+	fields := []interface{}{
+		w.header.Version, w.header.Type, w.header.Timestamp, w.header.NetworkID,
+		w.header.SEPMilestoneIndex, w.header.LedgerMilestoneIndex, w.header.SEPCount,
+	}
+	if w.header.Type == Full {
+		fields = append(fields, w.header.OutputCount)
+	}
+	fields = append(fields, w.header.MilestoneDiffCount)
+	if w.header.Type == Full {
+		fields = append(fields, w.header.TreasuryOutput.MilestoneID, w.header.TreasuryOutput.Amount)
+	}
+	for _, field := range fields {
+		if err := binary.Write(&buf, binary.LittleEndian, field); err != nil {
+			return fmt.Errorf("unable to serialize snapshot header: %w", err)
+		}
+	}
+	if _, err := w.w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("unable to write snapshot header: %w", err)
+	}
+	return nil
+}
+
+// checks that the given section is not written after a later one.
+func (w *Writer) enter(s section) error {
+	if w.section > s {
+		return fmt.Errorf("%w: section %d after section %d", ErrWrongSectionOrder, s, w.section)
+	}
+	w.section = s
+	return nil
+}
+
+// WriteSolidEntryPoint writes the given solid entry point.
+func (w *Writer) WriteSolidEntryPoint(sep iotago.MessageID) error {
+	if err := w.enter(sectionSolidEntryPoints); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(sep[:]); err != nil {
+		return fmt.Errorf("unable to write solid entry point: %w", err)
+	}
+	w.header.SEPCount++
+	return nil
+}
+
+// WriteOutput writes the given unspent output. Delta snapshots hold no unspent outputs.
+func (w *Writer) WriteOutput(output *Output) error {
+	if w.header.Type != Full {
+		return fmt.Errorf("%w: %s snapshots hold no unspent outputs", ErrWrongSectionOrder, w.header.Type)
+	}
+	if err := w.enter(sectionOutputs); err != nil {
+		return err
+	}
+	if err := w.writeOutput(output); err != nil {
+		return err
+	}
+	w.header.OutputCount++
+	return nil
+}
+
+func (w *Writer) writeOutput(output *Output) error {
+	outputData, err := output.Output.Serialize(serializer.DeSeriModePerformValidation)
+	if err != nil {
+		return fmt.Errorf("unable to serialize output %s: %w", output.UTXOInput.ID().ToHex(), err)
+	}
+	if _, err := w.w.Write(output.MessageID[:]); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(output.UTXOInput.TransactionID[:]); err != nil {
+		return err
+	}
+	if err := w.writeNum(output.UTXOInput.TransactionOutputIndex); err != nil {
+		return err
+	}
+	_, err = w.w.Write(outputData)
+	return err
+}
+
+// WriteMilestoneDiff writes the given milestone diff. The diffs of full snapshots must be written from the
+// ledger milestone index backwards, the diffs of delta snapshots in ascending order following it.
+func (w *Writer) WriteMilestoneDiff(diff *MilestoneDiff) error {
+	if err := w.enter(sectionMilestoneDiffs); err != nil {
+		return err
+	}
+	if expected := expectedMilestoneDiffIndex(w.header, w.header.MilestoneDiffCount); diff.Milestone.Index != expected {
+		return fmt.Errorf("%w: milestone diff has index %d instead of %d", ErrWrongSectionOrder, diff.Milestone.Index, expected)
+	}
+	if (diff.Milestone.Receipt != nil) != (diff.SpentTreasuryOutput != nil) {
+		return fmt.Errorf("%w: milestone diffs require a spent treasury output if and only if the milestone contains a receipt", ErrCorrupted)
+	}
+
+	msData, err := diff.Milestone.Serialize(serializer.DeSeriModePerformValidation)
+	if err != nil {
+		return fmt.Errorf("unable to serialize milestone %d: %w", diff.Milestone.Index, err)
+	}
+	if err := w.writeNum(uint32(len(msData))); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(msData); err != nil {
+		return err
+	}
+	if diff.SpentTreasuryOutput != nil {
+		if _, err := w.w.Write(diff.SpentTreasuryOutput.MilestoneID[:]); err != nil {
+			return err
+		}
+		if err := w.writeNum(diff.SpentTreasuryOutput.Amount); err != nil {
+			return err
+		}
+	}
+
+	if err := w.writeNum(uint64(len(diff.Created))); err != nil {
+		return err
+	}
+	for _, output := range diff.Created {
+		if err := w.writeOutput(output); err != nil {
+			return err
+		}
+	}
+	if err := w.writeNum(uint64(len(diff.Consumed))); err != nil {
+		return err
+	}
+	for _, spent := range diff.Consumed {
+		if err := w.writeOutput(spent.Output); err != nil {
+			return err
+		}
+		if _, err := w.w.Write(spent.TargetTransactionID[:]); err != nil {
+			return err
+		}
+	}
+	w.header.MilestoneDiffCount++
+	return nil
+}
+
+// Close flushes the written data and stores the final counts in the header.
+// It does not close the underlying io.WriteSeeker.
+func (w *Writer) Close() error {
+	if err := validateHeader(w.header); err != nil {
+		return err
+	}
+	w.section = sectionEnd
+	if err := w.w.Flush(); err != nil {
+		return fmt.Errorf("unable to flush snapshot: %w", err)
+	}
+
+	end, err := w.ws.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := w.ws.Seek(w.start, io.SeekStart); err != nil {
+		return fmt.Errorf("unable to seek to snapshot header: %w", err)
+	}
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	if err := w.w.Flush(); err != nil {
+		return fmt.Errorf("unable to flush snapshot header: %w", err)
+	}
+	_, err = w.ws.Seek(end, io.SeekStart)
+	return err
+}
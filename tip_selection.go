@@ -0,0 +1,386 @@
+package iotago
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/serializer"
+)
+
+var (
+	// ErrNoTipsAvailable gets returned when a TipSelector has no tips to select.
+	ErrNoTipsAvailable = errors.New("no tips available")
+)
+
+// TipSelector selects the tips to use as parents of a new message.
+type TipSelector interface {
+	// SelectTips returns the tips to use as parents of a new message.
+	SelectTips(ctx context.Context) (MessageIDs, error)
+}
+
+// TipSelectorFunc is a function implementing TipSelector.
+type TipSelectorFunc func(ctx context.Context) (MessageIDs, error)
+
+// SelectTips calls the TipSelectorFunc.
+func (f TipSelectorFunc) SelectTips(ctx context.Context) (MessageIDs, error) {
+	return f(ctx)
+}
+
+// NodeTipSelector returns a TipSelector which queries the tips of the given node.
+func NodeTipSelector(nodeHTTPAPIClient *NodeHTTPAPIClient) TipSelector {
+	return TipSelectorFunc(func(ctx context.Context) (MessageIDs, error) {
+		res, err := nodeHTTPAPIClient.Tips(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch tips from node API: %w", err)
+		}
+		return res.Tips()
+	})
+}
+
+// TipScore defines how a tip is scored by a TipPool.
+type TipScore int
+
+const (
+	// TipScoreNonLazy is the score of tips which can be used as parents.
+	TipScoreNonLazy TipScore = iota
+	// TipScoreSemiLazy is the score of tips which should be promoted instead of being used as parents.
+	TipScoreSemiLazy
+	// TipScoreLazy is the score of tips which are below max depth and must not be used as parents.
+	TipScoreLazy
+)
+
+// String returns the name of the TipScore.
+func (s TipScore) String() string {
+	switch s {
+	case TipScoreNonLazy:
+		return "non-lazy"
+	case TipScoreSemiLazy:
+		return "semi-lazy"
+	case TipScoreLazy:
+		return "lazy"
+	default:
+		return fmt.Sprintf("unknown tip score %d", int(s))
+	}
+}
+
+// the default options applied to the TipPool.
+var defaultTipPoolOptions = []TipPoolOption{
+	WithTipPoolMaxTips(100),
+	WithTipPoolMaxChildren(2),
+	WithTipPoolRetention(10 * time.Second),
+	WithTipPoolParentsCount(MaxParentsInAMessage),
+	WithTipPoolBelowMaxDepth(15),
+	WithTipPoolSeenMessages(10_000),
+}
+
+// TipPoolOptions define options for the TipPool.
+type TipPoolOptions struct {
+	// The max. amount of non-lazy tips held.
+	maxTips int
+	// The amount of children after which a tip is removed.
+	maxChildren int
+	// The duration after which a tip having at least one child is removed.
+	retention time.Duration
+	// The amount of tips selected as parents.
+	parentsCount int
+	// The amount of milestones after which a referenced tip becomes lazy.
+	belowMaxDepth uint32
+	// The amount of recently added message IDs remembered to ignore messages added again.
+	seenMessages int
+}
+
+// applies the given TipPoolOption.
+func (tpo *TipPoolOptions) apply(opts ...TipPoolOption) {
+	for _, opt := range opts {
+		opt(tpo)
+	}
+}
+
+// WithTipPoolMaxTips sets the max. amount of non-lazy tips held, the oldest tips are evicted first.
+func WithTipPoolMaxTips(maxTips int) TipPoolOption {
+	return func(opts *TipPoolOptions) {
+		opts.maxTips = maxTips
+	}
+}
+
+// WithTipPoolMaxChildren sets the amount of children after which a tip is removed from the pool.
+func WithTipPoolMaxChildren(maxChildren int) TipPoolOption {
+	return func(opts *TipPoolOptions) {
+		opts.maxChildren = maxChildren
+	}
+}
+
+// WithTipPoolRetention sets the duration after which a tip which has at least one child is removed from the pool.
+func WithTipPoolRetention(retention time.Duration) TipPoolOption {
+	return func(opts *TipPoolOptions) {
+		opts.retention = retention
+	}
+}
+
+// WithTipPoolParentsCount sets the max. amount of tips selected as parents, capped at MaxParentsInAMessage.
+func WithTipPoolParentsCount(count int) TipPoolOption {
+	return func(opts *TipPoolOptions) {
+		if count > MaxParentsInAMessage {
+			count = MaxParentsInAMessage
+		}
+		opts.parentsCount = count
+	}
+}
+
+// WithTipPoolBelowMaxDepth sets the amount of confirmed milestones after which a tip referenced by a milestone is lazy.
+func WithTipPoolBelowMaxDepth(belowMaxDepth uint32) TipPoolOption {
+	return func(opts *TipPoolOptions) {
+		opts.belowMaxDepth = belowMaxDepth
+	}
+}
+
+// WithTipPoolSeenMessages sets the amount of recently added message IDs the TipPool remembers in order to ignore
+// messages which are added again, for example when the event API redelivers them after a reconnect.
+func WithTipPoolSeenMessages(count int) TipPoolOption {
+	return func(opts *TipPoolOptions) {
+		opts.seenMessages = count
+	}
+}
+
+// TipPoolOption is a function setting a TipPool option.
+type TipPoolOption func(opts *TipPoolOptions)
+
+// NewTipPool creates a new empty TipPool.
+func NewTipPool(opts ...TipPoolOption) *TipPool {
+	options := &TipPoolOptions{}
+	options.apply(defaultTipPoolOptions...)
+	options.apply(opts...)
+	return &TipPool{
+		opts: options,
+		tips: make(map[MessageID]*tip),
+		seen: make(map[MessageID]struct{}),
+	}
+}
+
+// tip is a message without enough children.
+type tip struct {
+	score        TipScore
+	added        time.Time
+	children     int
+	referencedBy uint32
+}
+
+// TipPool is a TipSelector which maintains its tips from a stream of new messages and their metadata
+// and selects them uniformly at random (URTS). Tips are removed once they have enough children, once they have
+// children and exceed the retention time or once they become lazy.
+//
+// A TipPool can be fed from the event API of a node:
+//
+//	go tipPool.Run(ctx, nodeEventAPIClient.Messages(), nodeEventAPIClient.ReferencedMessagesMetadata())
+type TipPool struct {
+	opts *TipPoolOptions
+
+	mu             sync.Mutex
+	tips           map[MessageID]*tip
+	order          MessageIDs
+	confirmedIndex uint32
+	// the recently added message IDs, seenRing holds them in a ring buffer in the order they were added
+	seen     map[MessageID]struct{}
+	seenRing MessageIDs
+	seenNext int
+}
+
+// Run feeds the TipPool with the given messages and metadata until the given context is done or both channels are closed.
+func (tp *TipPool) Run(ctx context.Context, msgs <-chan *Message, metadata <-chan *MessageMetadataResponse) error {
+	for msgs != nil || metadata != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-msgs:
+			if !ok {
+				msgs = nil
+				continue
+			}
+			if err := tp.AddMessage(msg); err != nil {
+				return err
+			}
+		case meta, ok := <-metadata:
+			if !ok {
+				metadata = nil
+				continue
+			}
+			if err := tp.UpdateMetadata(meta); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AddMessage adds the given message as a tip and counts it as a child of its parents.
+// Messages which were added recently are ignored, even if they were removed from the pool meanwhile.
+func (tp *TipPool) AddMessage(msg *Message) error {
+	msgID, err := msg.ID()
+	if err != nil {
+		return fmt.Errorf("unable to compute message ID: %w", err)
+	}
+
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	if _, has := tp.tips[*msgID]; has {
+		return nil
+	}
+	if _, seen := tp.seen[*msgID]; seen {
+		return nil
+	}
+	tp.markSeenLocked(*msgID)
+
+	for _, parent := range msg.Parents {
+		parentTip, has := tp.tips[parent]
+		if !has {
+			continue
+		}
+		parentTip.children++
+		if parentTip.children >= tp.opts.maxChildren {
+			delete(tp.tips, parent)
+		}
+	}
+
+	tp.tips[*msgID] = &tip{score: TipScoreNonLazy, added: time.Now()}
+	tp.order = append(tp.order, *msgID)
+	tp.evictLocked()
+	return nil
+}
+
+// remembers the given message ID as added, forgetting the oldest one if the max. amount of seen messages is reached.
+func (tp *TipPool) markSeenLocked(msgID MessageID) {
+	if tp.opts.seenMessages <= 0 {
+		return
+	}
+	if len(tp.seenRing) < tp.opts.seenMessages {
+		tp.seenRing = append(tp.seenRing, msgID)
+	} else {
+		delete(tp.seen, tp.seenRing[tp.seenNext])
+		tp.seenRing[tp.seenNext] = msgID
+		tp.seenNext = (tp.seenNext + 1) % len(tp.seenRing)
+	}
+	tp.seen[msgID] = struct{}{}
+}
+
+// UpdateMetadata scores the tip the given metadata belongs to. Tips the node marks to be reattached are lazy,
+// tips the node marks to be promoted are semi-lazy. Metadata of milestones advances the confirmed milestone index,
+// which makes referenced tips lazy once they are below max depth.
+func (tp *TipPool) UpdateMetadata(metadata *MessageMetadataResponse) error {
+	msgID, err := MessageIDFromHexString(metadata.MessageID)
+	if err != nil {
+		return fmt.Errorf("invalid message ID in metadata: %w", err)
+	}
+
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	if metadata.MilestoneIndex != nil && *metadata.MilestoneIndex > tp.confirmedIndex {
+		tp.confirmedIndex = *metadata.MilestoneIndex
+	}
+
+	if t, has := tp.tips[msgID]; has {
+		switch {
+		case metadata.ShouldReattach != nil && *metadata.ShouldReattach:
+			t.score = TipScoreLazy
+		case metadata.ShouldPromote != nil && *metadata.ShouldPromote:
+			t.score = TipScoreSemiLazy
+		}
+		if metadata.ReferencedByMilestoneIndex != nil {
+			t.referencedBy = *metadata.ReferencedByMilestoneIndex
+		}
+	}
+
+	tp.evictLocked()
+	return nil
+}
+
+// Score returns the TipScore of the tip with the given ID and whether it is a tip of the pool.
+func (tp *TipPool) Score(msgID MessageID) (TipScore, bool) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	t, has := tp.tips[msgID]
+	if !has {
+		return 0, false
+	}
+	return t.score, true
+}
+
+// Len returns the amount of non-lazy and semi-lazy tips in the pool.
+func (tp *TipPool) Len() int {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.evictLocked()
+	return len(tp.tips)
+}
+
+// SelectTips selects up to the configured parents count of non-lazy tips uniformly at random.
+func (tp *TipPool) SelectTips(_ context.Context) (MessageIDs, error) {
+	return tp.selectTips(TipScoreNonLazy)
+}
+
+// SelectSemiLazyTips selects up to the configured parents count of semi-lazy tips uniformly at random,
+// which can be used to promote them.
+func (tp *TipPool) SelectSemiLazyTips(_ context.Context) (MessageIDs, error) {
+	return tp.selectTips(TipScoreSemiLazy)
+}
+
+func (tp *TipPool) selectTips(score TipScore) (MessageIDs, error) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.evictLocked()
+
+	candidates := make(MessageIDs, 0, len(tp.tips))
+	for _, msgID := range tp.order {
+		if t, has := tp.tips[msgID]; has && t.score == score {
+			candidates = append(candidates, msgID)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: no %s tips in pool", ErrNoTipsAvailable, score)
+	}
+
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if len(candidates) > tp.opts.parentsCount {
+		candidates = candidates[:tp.opts.parentsCount]
+	}
+	return serializer.RemoveDupsAndSortByLexicalOrderArrayOf32Bytes(candidates), nil
+}
+
+// removes lazy tips, tips exceeding the retention and the oldest non-lazy tips exceeding the max. amount of tips.
+func (tp *TipPool) evictLocked() {
+	now := time.Now()
+	nonLazy := 0
+	for msgID, t := range tp.tips {
+		switch {
+		case t.score == TipScoreLazy,
+			t.referencedBy != 0 && t.referencedBy+tp.opts.belowMaxDepth < tp.confirmedIndex,
+			t.children > 0 && now.Sub(t.added) > tp.opts.retention:
+			delete(tp.tips, msgID)
+		case t.score == TipScoreNonLazy:
+			nonLazy++
+		}
+	}
+
+	// the order is compacted to the held tips, oldest first
+	order := tp.order[:0]
+	for _, msgID := range tp.order {
+		t, has := tp.tips[msgID]
+		if !has {
+			continue
+		}
+		if nonLazy > tp.opts.maxTips && t.score == TipScoreNonLazy {
+			delete(tp.tips, msgID)
+			nonLazy--
+			continue
+		}
+		order = append(order, msgID)
+	}
+	tp.order = order
+}
@@ -0,0 +1,175 @@
+package iotago_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+// returns a new message with the given parents and its ID.
+func tipPoolMessage(t *testing.T, parents ...iotago.MessageID) (*iotago.Message, iotago.MessageID) {
+	if len(parents) == 0 {
+		parents = tpkg.SortedRand32BytArray(1)
+	}
+	msg := &iotago.Message{Parents: serializer.RemoveDupsAndSortByLexicalOrderArrayOf32Bytes(parents), Payload: &iotago.Indexation{Index: []byte("tips")}}
+	msgID, err := msg.ID()
+	require.NoError(t, err)
+	return msg, *msgID
+}
+
+func TestTipPool(t *testing.T) {
+	tipPool := iotago.NewTipPool(iotago.WithTipPoolParentsCount(2), iotago.WithTipPoolMaxChildren(2))
+
+	_, err := tipPool.SelectTips(context.Background())
+	assert.True(t, errors.Is(err, iotago.ErrNoTipsAvailable))
+
+	msgA, a := tipPoolMessage(t)
+	msgB, b := tipPoolMessage(t)
+	require.NoError(t, tipPool.AddMessage(msgA))
+	require.NoError(t, tipPool.AddMessage(msgB))
+	require.NoError(t, tipPool.AddMessage(msgB))
+	assert.Equal(t, 2, tipPool.Len())
+
+	tips, err := tipPool.SelectTips(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, iotago.MessageIDs{a, b}, tips)
+
+	// a is removed after its second child
+	msgC, c := tipPoolMessage(t, a)
+	msgD, d := tipPoolMessage(t, a, b)
+	require.NoError(t, tipPool.AddMessage(msgC))
+	require.NoError(t, tipPool.AddMessage(msgD))
+	_, isTip := tipPool.Score(a)
+	assert.False(t, isTip)
+	assert.Equal(t, 3, tipPool.Len())
+
+	// at most the parents count is selected
+	for i := 0; i < 10; i++ {
+		tips, err := tipPool.SelectTips(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, tips, 2)
+		assert.Subset(t, iotago.MessageIDs{b, c, d}, tips)
+	}
+
+	// semi-lazy and lazy tips are not selected as parents
+	shouldPromote, shouldReattach := true, true
+	require.NoError(t, tipPool.UpdateMetadata(&iotago.MessageMetadataResponse{MessageID: iotago.MessageIDToHexString(c), ShouldPromote: &shouldPromote}))
+	require.NoError(t, tipPool.UpdateMetadata(&iotago.MessageMetadataResponse{MessageID: iotago.MessageIDToHexString(d), ShouldReattach: &shouldReattach}))
+	score, isTip := tipPool.Score(c)
+	assert.True(t, isTip)
+	assert.Equal(t, iotago.TipScoreSemiLazy, score)
+	_, isTip = tipPool.Score(d)
+	assert.False(t, isTip)
+
+	tips, err = tipPool.SelectTips(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, iotago.MessageIDs{b}, tips)
+	tips, err = tipPool.SelectSemiLazyTips(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, iotago.MessageIDs{c}, tips)
+
+	// b becomes lazy once it is below max depth
+	referencedBy, msIndex := uint32(10), uint32(26)
+	require.NoError(t, tipPool.UpdateMetadata(&iotago.MessageMetadataResponse{MessageID: iotago.MessageIDToHexString(b), ReferencedByMilestoneIndex: &referencedBy}))
+	_, isTip = tipPool.Score(b)
+	assert.True(t, isTip)
+	_, ms := tipPoolMessage(t)
+	require.NoError(t, tipPool.UpdateMetadata(&iotago.MessageMetadataResponse{MessageID: iotago.MessageIDToHexString(ms), MilestoneIndex: &msIndex}))
+	_, isTip = tipPool.Score(b)
+	assert.False(t, isTip)
+}
+
+func TestTipPool_Eviction(t *testing.T) {
+	tipPool := iotago.NewTipPool(iotago.WithTipPoolMaxTips(3), iotago.WithTipPoolRetention(time.Nanosecond), iotago.WithTipPoolMaxChildren(10))
+
+	msgIDs := make(iotago.MessageIDs, 5)
+	for i := range msgIDs {
+		var msg *iotago.Message
+		msg, msgIDs[i] = tipPoolMessage(t)
+		require.NoError(t, tipPool.AddMessage(msg))
+	}
+
+	// the oldest tips are evicted first
+	assert.Equal(t, 3, tipPool.Len())
+	for i, msgID := range msgIDs {
+		_, isTip := tipPool.Score(msgID)
+		assert.Equal(t, i >= 2, isTip)
+	}
+
+	// tips with children are removed after the retention
+	msg, child := tipPoolMessage(t, msgIDs[4])
+	require.NoError(t, tipPool.AddMessage(msg))
+	time.Sleep(time.Millisecond)
+	tips, err := tipPool.SelectTips(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, iotago.MessageIDs{msgIDs[2], msgIDs[3], child}, tips)
+}
+
+func TestTipPool_SeenMessages(t *testing.T) {
+	tipPool := iotago.NewTipPool(iotago.WithTipPoolMaxChildren(1), iotago.WithTipPoolSeenMessages(3))
+
+	// a removed tip which is added again stays removed and does not count as a child twice
+	msgA, a := tipPoolMessage(t)
+	msgB, b := tipPoolMessage(t, a)
+	require.NoError(t, tipPool.AddMessage(msgA))
+	require.NoError(t, tipPool.AddMessage(msgB))
+	_, isTip := tipPool.Score(a)
+	require.False(t, isTip)
+
+	require.NoError(t, tipPool.AddMessage(msgA))
+	_, isTip = tipPool.Score(a)
+	assert.False(t, isTip)
+	assert.Equal(t, 1, tipPool.Len())
+
+	// only the given amount of message IDs is remembered
+	for i := 0; i < 3; i++ {
+		msg, _ := tipPoolMessage(t)
+		require.NoError(t, tipPool.AddMessage(msg))
+	}
+	require.NoError(t, tipPool.AddMessage(msgA))
+	_, isTip = tipPool.Score(a)
+	assert.True(t, isTip)
+	_, isTip = tipPool.Score(b)
+	assert.True(t, isTip)
+}
+
+func TestTipPool_Run(t *testing.T) {
+	tipPool := iotago.NewTipPool()
+	msgs := make(chan *iotago.Message)
+	metadata := make(chan *iotago.MessageMetadataResponse)
+
+	done := make(chan error)
+	go func() {
+		done <- tipPool.Run(context.Background(), msgs, metadata)
+	}()
+
+	msg, msgID := tipPoolMessage(t)
+	msgs <- msg
+	shouldPromote := true
+	metadata <- &iotago.MessageMetadataResponse{MessageID: iotago.MessageIDToHexString(msgID), ShouldPromote: &shouldPromote}
+	close(msgs)
+	close(metadata)
+	require.NoError(t, <-done)
+
+	score, isTip := tipPool.Score(msgID)
+	assert.True(t, isTip)
+	assert.Equal(t, iotago.TipScoreSemiLazy, score)
+
+	// the message builder uses the selected tips as parents
+	tipPool = iotago.NewTipPool()
+	require.NoError(t, tipPool.AddMessage(msg))
+	built, err := iotago.NewMessageBuilder().TipsFromSelector(context.Background(), tipPool).Build()
+	require.NoError(t, err)
+	assert.Equal(t, iotago.MessageIDs{msgID}, built.Parents)
+
+	_, err = iotago.NewMessageBuilder().TipsFromSelector(context.Background(), iotago.NewTipPool()).Build()
+	assert.True(t, errors.Is(err, iotago.ErrNoTipsAvailable))
+}
@@ -0,0 +1,459 @@
+package tpkg
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/v2"
+)
+
+// FakeNode is an in-memory node serving the node HTTP API routes of its tangle and ledger.
+// Submitted messages are attached to the tangle and their transactions are booked into the ledger.
+// Submitted messages are reported as referenced by the next milestone from their second metadata query on.
+type FakeNode struct {
+	server *httptest.Server
+
+	mu             sync.Mutex
+	confirmedIndex uint32
+	maxResults     int
+	failSubmits    int
+	submits        int
+
+	outputs     map[iotago.UTXOInputID]iotago.Output
+	spent       map[iotago.UTXOInputID]bool
+	utxoChanges map[uint32]*iotago.MilestoneUTXOChangesResponse
+
+	msgs       map[iotago.MessageID][]byte
+	metadata   map[iotago.MessageID]*iotago.MessageMetadataResponse
+	children   map[iotago.MessageID]iotago.MessageIDs
+	milestones map[uint32]iotago.MessageID
+	indexed    map[string]iotago.MessageIDs
+	// the metadata queries of submitted messages which are not yet referenced
+	pending map[iotago.MessageID]int
+}
+
+// NewFakeNode creates and starts a new FakeNode. It must be closed via Close().
+func NewFakeNode() *FakeNode {
+	node := &FakeNode{
+		maxResults:  1000,
+		outputs:     map[iotago.UTXOInputID]iotago.Output{},
+		spent:       map[iotago.UTXOInputID]bool{},
+		utxoChanges: map[uint32]*iotago.MilestoneUTXOChangesResponse{},
+		msgs:        map[iotago.MessageID][]byte{},
+		metadata:    map[iotago.MessageID]*iotago.MessageMetadataResponse{},
+		children:    map[iotago.MessageID]iotago.MessageIDs{},
+		milestones:  map[uint32]iotago.MessageID{},
+		indexed:     map[string]iotago.MessageIDs{},
+		pending:     map[iotago.MessageID]int{},
+	}
+	node.server = httptest.NewServer(node)
+	return node
+}
+
+// Close shuts down the FakeNode.
+func (n *FakeNode) Close() {
+	n.server.Close()
+}
+
+// Client returns a NodeHTTPAPIClient talking to the FakeNode.
+func (n *FakeNode) Client() *iotago.NodeHTTPAPIClient {
+	return iotago.NewNodeHTTPAPIClient(n.server.URL)
+}
+
+// SetMaxResults sets the max. amount of results returned by queries for output and message IDs.
+func (n *FakeNode) SetMaxResults(maxResults int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.maxResults = maxResults
+}
+
+// FailSubmits lets the given amount of next message submissions fail with an internal server error.
+func (n *FakeNode) FailSubmits(count int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.failSubmits = count
+}
+
+// Submits returns the amount of message submissions, including failed ones.
+func (n *FakeNode) Submits() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.submits
+}
+
+// returns the UTXO changes of the given milestone, which becomes the confirmed milestone if it is newer.
+func (n *FakeNode) milestoneUTXOChanges(index uint32) *iotago.MilestoneUTXOChangesResponse {
+	if _, has := n.utxoChanges[index]; !has {
+		n.utxoChanges[index] = &iotago.MilestoneUTXOChangesResponse{Index: index, CreatedOutputs: []string{}, ConsumedOutputs: []string{}}
+	}
+	if index > n.confirmedIndex {
+		n.confirmedIndex = index
+	}
+	return n.utxoChanges[index]
+}
+
+// CreateOutput adds the given output to the ledger as created by the milestone with the given index.
+func (n *FakeNode) CreateOutput(index uint32, output iotago.Output) *iotago.UTXOInput {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	utxoInput := &iotago.UTXOInput{TransactionID: Rand32ByteArray()}
+	n.outputs[utxoInput.ID()] = output
+	changes := n.milestoneUTXOChanges(index)
+	changes.CreatedOutputs = append(changes.CreatedOutputs, utxoInput.ID().ToHex())
+	return utxoInput
+}
+
+// ConsumeOutput marks the given output as consumed by the milestone with the given index.
+func (n *FakeNode) ConsumeOutput(index uint32, utxoID iotago.UTXOInputID) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.spent[utxoID] = true
+	changes := n.milestoneUTXOChanges(index)
+	changes.ConsumedOutputs = append(changes.ConsumedOutputs, utxoID.ToHex())
+}
+
+// UnspentOutputs returns the unspent outputs on the given address.
+func (n *FakeNode) UnspentOutputs(addr iotago.Address) map[iotago.UTXOInputID]iotago.Output {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.unspentOutputs(addr)
+}
+
+func (n *FakeNode) unspentOutputs(addr iotago.Address) map[iotago.UTXOInputID]iotago.Output {
+	outputs := map[iotago.UTXOInputID]iotago.Output{}
+	for utxoID, output := range n.outputs {
+		target, err := output.Target()
+		Must(err)
+		if !n.spent[utxoID] && target.(iotago.Address).String() == addr.String() {
+			outputs[utxoID] = output
+		}
+	}
+	return outputs
+}
+
+// implements iotago.DustAllowanceFunc on the unspent outputs.
+func (n *FakeNode) dustAllowance(addr iotago.Address) (uint64, int64, error) {
+	var sum uint64
+	var numDustOutputs int64
+	for _, output := range n.unspentOutputs(addr) {
+		deposit, err := output.Deposit()
+		if err != nil {
+			return 0, 0, err
+		}
+		switch {
+		case output.Type() == iotago.OutputSigLockedDustAllowanceOutput:
+			sum += deposit
+		case deposit < iotago.OutputSigLockedDustAllowanceOutputMinDeposit:
+			numDustOutputs++
+		}
+	}
+	return sum, numDustOutputs, nil
+}
+
+// AttachMessage attaches the given message to the tangle and returns its ID.
+// A message containing a milestone becomes the confirmed milestone.
+func (n *FakeNode) AttachMessage(msg *iotago.Message) iotago.MessageID {
+	msgData, err := msg.Serialize(serializer.DeSeriModePerformValidation)
+	Must(err)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.attach(msg, msgData)
+}
+
+func (n *FakeNode) attach(msg *iotago.Message, msgData []byte) iotago.MessageID {
+	msgID, err := msg.ID()
+	Must(err)
+
+	n.msgs[*msgID] = msgData
+	metadata := &iotago.MessageMetadataResponse{MessageID: iotago.MessageIDToHexString(*msgID), Solid: true}
+	for _, parent := range msg.Parents {
+		n.children[parent] = append(n.children[parent], *msgID)
+		metadata.Parents = append(metadata.Parents, iotago.MessageIDToHexString(parent))
+	}
+	n.metadata[*msgID] = metadata
+
+	switch payload := msg.Payload.(type) {
+	case *iotago.Milestone:
+		index := payload.Index
+		metadata.MilestoneIndex = &index
+		n.milestones[index] = *msgID
+		if index > n.confirmedIndex {
+			n.confirmedIndex = index
+		}
+	case *iotago.Indexation:
+		n.indexed[string(payload.Index)] = append(n.indexed[string(payload.Index)], *msgID)
+	case *iotago.Transaction:
+		if essence, ok := payload.Essence.(*iotago.TransactionEssence); ok {
+			if indexation, ok := essence.Payload.(*iotago.Indexation); ok {
+				n.indexed[string(indexation.Index)] = append(n.indexed[string(indexation.Index)], *msgID)
+			}
+		}
+	}
+	return *msgID
+}
+
+// ReferenceMessage sets the index of the milestone referencing the given message.
+func (n *FakeNode) ReferenceMessage(msgID iotago.MessageID, index uint32) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.metadata[msgID].ReferencedByMilestoneIndex = &index
+}
+
+// Message returns the message with the given ID or nil if it is unknown.
+func (n *FakeNode) Message(msgID iotago.MessageID) *iotago.Message {
+	n.mu.Lock()
+	msgData, has := n.msgs[msgID]
+	n.mu.Unlock()
+	if !has {
+		return nil
+	}
+	msg := &iotago.Message{}
+	_, err := msg.Deserialize(msgData, serializer.DeSeriModePerformValidation)
+	Must(err)
+	return msg
+}
+
+// books the transaction of the given message into the ledger if it is semantically valid and
+// sets the ledger inclusion state of its metadata.
+func (n *FakeNode) book(msgID iotago.MessageID, tx *iotago.Transaction) {
+	txID, err := tx.ID()
+	Must(err)
+	essence := tx.Essence.(*iotago.TransactionEssence)
+
+	utxos := iotago.InputToOutputMapping{}
+	for _, input := range essence.Inputs {
+		utxoID := input.(*iotago.UTXOInput).ID()
+		if output, has := n.outputs[utxoID]; has && !n.spent[utxoID] {
+			utxos[utxoID] = output
+		}
+	}
+
+	metadata := n.metadata[msgID]
+	inclusionState := string(iotago.LedgerInclusionStateIncluded)
+	dustValidation := iotago.NewDustSemanticValidation(iotago.DustAllowanceDivisor, iotago.MaxDustOutputsOnAddress, n.dustAllowance)
+	if err := tx.SemanticallyValidate(utxos, dustValidation); err != nil {
+		inclusionState = string(iotago.LedgerInclusionStateConflicting)
+		metadata.ConflictReason = uint8(iotago.ConflictReasonFromError(err))
+	} else {
+		for utxoID := range utxos {
+			n.spent[utxoID] = true
+		}
+		for i, output := range essence.Outputs {
+			n.outputs[(&iotago.UTXOInput{TransactionID: *txID, TransactionOutputIndex: uint16(i)}).ID()] = output.(iotago.Output)
+		}
+	}
+	metadata.LedgerInclusionState = &inclusionState
+}
+
+// ServeHTTP implements http.Handler.
+func (n *FakeNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/")
+	parts := strings.Split(path, "/")
+	switch {
+	case r.Method == http.MethodPost && path == "messages":
+		n.serveSubmit(w, r)
+	case r.Method != http.MethodGet:
+		n.error(w, http.StatusNotFound)
+	case path == "info":
+		n.reply(w, &iotago.NodeInfoResponse{
+			NetworkID: "testnet", IsHealthy: true, MinPowScore: 1,
+			LatestMilestoneIndex: n.confirmedIndex, ConfirmedMilestoneIndex: n.confirmedIndex,
+		})
+	case path == "tips":
+		tip := Rand32ByteArray()
+		n.reply(w, &iotago.NodeTipsResponse{TipsHex: []string{hex.EncodeToString(tip[:])}})
+	case path == "messages":
+		n.serveMessageIDsByIndex(w, r)
+	case len(parts) == 3 && parts[0] == "messages":
+		n.serveMessage(w, parts[1], parts[2])
+	case len(parts) >= 2 && parts[0] == "milestones":
+		n.serveMilestone(w, parts[1:])
+	case len(parts) == 2 && parts[0] == "outputs":
+		n.serveOutput(w, parts[1])
+	case len(parts) == 4 && parts[0] == "addresses" && parts[1] == "ed25519" && parts[3] == "outputs":
+		n.serveAddressOutputs(w, parts[2])
+	default:
+		n.error(w, http.StatusNotFound)
+	}
+}
+
+func (n *FakeNode) reply(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	Must(json.NewEncoder(w).Encode(&iotago.HTTPOkResponseEnvelope{Data: data}))
+}
+
+func (n *FakeNode) error(w http.ResponseWriter, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	Must(json.NewEncoder(w).Encode(&iotago.HTTPErrorResponseEnvelope{}))
+}
+
+func (n *FakeNode) serveSubmit(w http.ResponseWriter, r *http.Request) {
+	n.submits++
+	if n.failSubmits > 0 {
+		n.failSubmits--
+		n.error(w, http.StatusInternalServerError)
+		return
+	}
+
+	msgData, err := io.ReadAll(r.Body)
+	Must(err)
+	msg := &iotago.Message{}
+	if _, err := msg.Deserialize(msgData, serializer.DeSeriModePerformValidation); err != nil {
+		n.error(w, http.StatusBadRequest)
+		return
+	}
+
+	msgID := n.attach(msg, msgData)
+	n.pending[msgID] = 0
+	if tx, ok := msg.Payload.(*iotago.Transaction); ok {
+		n.book(msgID, tx)
+	}
+	w.Header().Set("Location", iotago.MessageIDToHexString(msgID))
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (n *FakeNode) serveMessageIDsByIndex(w http.ResponseWriter, r *http.Request) {
+	index, err := hex.DecodeString(r.URL.Query().Get("index"))
+	if err != nil {
+		n.error(w, http.StatusBadRequest)
+		return
+	}
+	res := &iotago.MessageIDsByIndexResponse{Index: hex.EncodeToString(index), MaxResults: uint32(n.maxResults), MessageIDs: []string{}}
+	for _, msgID := range n.indexed[string(index)] {
+		if len(res.MessageIDs) == n.maxResults {
+			break
+		}
+		res.MessageIDs = append(res.MessageIDs, iotago.MessageIDToHexString(msgID))
+	}
+	res.Count = uint32(len(res.MessageIDs))
+	n.reply(w, res)
+}
+
+func (n *FakeNode) serveMessage(w http.ResponseWriter, msgIDHex string, resource string) {
+	msgID, err := iotago.MessageIDFromHexString(msgIDHex)
+	if err != nil {
+		n.error(w, http.StatusBadRequest)
+		return
+	}
+	msgData, has := n.msgs[msgID]
+	if !has {
+		n.error(w, http.StatusNotFound)
+		return
+	}
+
+	switch resource {
+	case "raw":
+		_, err := w.Write(msgData)
+		Must(err)
+	case "metadata":
+		metadata := *n.metadata[msgID]
+		if polls, isPending := n.pending[msgID]; isPending {
+			if polls == 0 {
+				n.pending[msgID]++
+				metadata.ReferencedByMilestoneIndex, metadata.LedgerInclusionState, metadata.ConflictReason = nil, nil, 0
+			} else {
+				delete(n.pending, msgID)
+				index := n.confirmedIndex + 1
+				n.metadata[msgID].ReferencedByMilestoneIndex = &index
+				metadata.ReferencedByMilestoneIndex = &index
+			}
+		}
+		n.reply(w, &metadata)
+	case "children":
+		res := &iotago.ChildrenResponse{MessageID: msgIDHex, MaxResults: uint32(n.maxResults), Children: []string{}}
+		for _, child := range n.children[msgID] {
+			if len(res.Children) == n.maxResults {
+				break
+			}
+			res.Children = append(res.Children, iotago.MessageIDToHexString(child))
+		}
+		res.Count = uint32(len(res.Children))
+		n.reply(w, res)
+	default:
+		n.error(w, http.StatusNotFound)
+	}
+}
+
+func (n *FakeNode) serveMilestone(w http.ResponseWriter, parts []string) {
+	index, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		n.error(w, http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case len(parts) == 1:
+		msgID, has := n.milestones[uint32(index)]
+		if !has {
+			n.error(w, http.StatusNotFound)
+			return
+		}
+		n.reply(w, &iotago.MilestoneResponse{Index: uint32(index), MessageID: iotago.MessageIDToHexString(msgID)})
+	case len(parts) == 2 && parts[1] == "utxo-changes":
+		changes, has := n.utxoChanges[uint32(index)]
+		if !has {
+			changes = &iotago.MilestoneUTXOChangesResponse{Index: uint32(index), CreatedOutputs: []string{}, ConsumedOutputs: []string{}}
+		}
+		n.reply(w, changes)
+	default:
+		n.error(w, http.StatusNotFound)
+	}
+}
+
+func (n *FakeNode) serveOutput(w http.ResponseWriter, outputIDHex string) {
+	utxoInput, err := iotago.OutputIDHex(outputIDHex).AsUTXOInput()
+	if err != nil {
+		n.error(w, http.StatusBadRequest)
+		return
+	}
+	output, has := n.outputs[utxoInput.ID()]
+	if !has {
+		n.error(w, http.StatusNotFound)
+		return
+	}
+
+	outputJSON, err := json.Marshal(output)
+	Must(err)
+	rawOutput := json.RawMessage(outputJSON)
+	n.reply(w, &iotago.NodeOutputResponse{
+		TransactionID: hex.EncodeToString(utxoInput.TransactionID[:]),
+		OutputIndex:   utxoInput.TransactionOutputIndex,
+		Spent:         n.spent[utxoInput.ID()],
+		LedgerIndex:   uint64(n.confirmedIndex),
+		RawOutput:     &rawOutput,
+	})
+}
+
+func (n *FakeNode) serveAddressOutputs(w http.ResponseWriter, addrHex string) {
+	addrBytes, err := hex.DecodeString(addrHex)
+	if err != nil || len(addrBytes) != iotago.Ed25519AddressBytesLength {
+		n.error(w, http.StatusBadRequest)
+		return
+	}
+	addr := &iotago.Ed25519Address{}
+	copy(addr[:], addrBytes)
+
+	res := &iotago.AddressOutputsResponse{
+		AddressType: iotago.AddressEd25519, Address: addrHex, MaxResults: uint32(n.maxResults),
+		OutputIDs: []iotago.OutputIDHex{}, LedgerIndex: uint64(n.confirmedIndex),
+	}
+	for utxoID := range n.unspentOutputs(addr) {
+		if len(res.OutputIDs) == n.maxResults {
+			break
+		}
+		res.OutputIDs = append(res.OutputIDs, iotago.OutputIDHex(utxoID.ToHex()))
+	}
+	res.Count = uint32(len(res.OutputIDs))
+	n.reply(w, res)
+}
@@ -57,6 +57,14 @@ func Rand49ByteArray() [49]byte {
 	return h
 }
 
+// RandLegacyTailTransactionHash returns a random T5B1 encoded legacy tail transaction hash and its trytes.
+func RandLegacyTailTransactionHash() (iotago.LegacyTailTransactionHash, trinary.Trytes) {
+	trytes := RandTrytes(legacy.HashTrytesSize)
+	hash, err := iotago.LegacyTailTransactionHashFromTrytes(trytes)
+	Must(err)
+	return hash, trytes
+}
+
 // Rand64ByteArray returns an array with 64 random bytes.
 func Rand64ByteArray() [64]byte {
 	var h [64]byte
@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/iotaledger/hive.go/serializer"
 
+	"github.com/iotaledger/iota.go/v2/ed25519"
+
 	"golang.org/x/crypto/blake2b"
 )
 
@@ -168,35 +171,44 @@ func (t *Transaction) UnmarshalJSON(bytes []byte) error {
 //	3. input and unlock blocks count must match
 //	4. signatures are unique and ref. unlock blocks reference a previous unlock block.
 func (t *Transaction) SyntacticallyValidate() error {
+	_, err := t.syntacticallyValidate(nil)
+	return err
+}
+
+// syntactically validates the Transaction and adds the violations to the given collector.
+// It returns the TransactionEssence unless the essence itself is invalid.
+func (t *Transaction) syntacticallyValidate(c *violationCollector) (*TransactionEssence, error) {
 
 	if t.Essence == nil {
-		return fmt.Errorf("%w: transaction is nil", ErrInvalidTransactionEssence)
+		return nil, c.add(newViolation(RuleTransactionEssenceType, -1, nil, nil, fmt.Errorf("%w: transaction is nil", ErrInvalidTransactionEssence)))
 	}
 
 	if t.UnlockBlocks == nil {
-		return fmt.Errorf("%w: unlock blocks are nil", ErrInvalidTransactionEssence)
+		return nil, c.add(newViolation(RuleTransactionEssenceType, -1, nil, nil, fmt.Errorf("%w: unlock blocks are nil", ErrInvalidTransactionEssence)))
 	}
 
 	txEssence, ok := t.Essence.(*TransactionEssence)
 	if !ok {
-		return fmt.Errorf("%w: transaction essence is not *TransactionEssence", ErrInvalidTransactionEssence)
+		return nil, c.add(newViolation(RuleTransactionEssenceType, -1, nil, fmt.Sprintf("%T", t.Essence), fmt.Errorf("%w: transaction essence is not *TransactionEssence", ErrInvalidTransactionEssence)))
 	}
 
-	if err := txEssence.SyntacticallyValidate(); err != nil {
-		return fmt.Errorf("%w: transaction essence part is invalid", err)
+	if err := txEssence.syntacticallyValidate(c); err != nil {
+		return nil, fmt.Errorf("%w: transaction essence part is invalid", err)
 	}
 
 	inputCount := len(txEssence.Inputs)
 	unlockBlockCount := len(t.UnlockBlocks)
 	if inputCount != unlockBlockCount {
-		return fmt.Errorf("%w: num of inputs %d, num of unlock blocks %d", ErrUnlockBlocksMustMatchInputCount, inputCount, unlockBlockCount)
+		if err := c.add(newViolation(RuleUnlockBlocksCount, -1, inputCount, unlockBlockCount, fmt.Errorf("%w: num of inputs %d, num of unlock blocks %d", ErrUnlockBlocksMustMatchInputCount, inputCount, unlockBlockCount))); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := ValidateUnlockBlocks(t.UnlockBlocks, UnlockBlocksSigUniqueAndRefValidator()); err != nil {
-		return fmt.Errorf("%w: invalid unlock blocks", err)
+	if err := validateUnlockBlocks(t.UnlockBlocks, c, UnlockBlocksSigUniqueAndRefValidator()); err != nil {
+		return nil, fmt.Errorf("%w: invalid unlock blocks", err)
 	}
 
-	return nil
+	return txEssence, nil
 }
 
 // SigValidationFunc is a function which when called tells whether
@@ -219,80 +231,100 @@ type DustAllowanceFunc func(addr Address) (dustAllowanceSum uint64, amountDustOu
 //	threshold of the sum of min(S / div, dustOutputsCountLimit). Where S is the sum of deposits of all dust allowance outputs on address A.
 func NewDustSemanticValidation(div int64, dustOutputsCountLimit int64, dustAllowanceFunc DustAllowanceFunc) SemanticValidationFunc {
 	return func(t *Transaction, utxos InputToOutputMapping) error {
-		essence := t.Essence.(*TransactionEssence)
-
-		addrToValidate := make(map[string]Address)
-		dustAllowanceAddrToBalance := make(map[string]int64)
-		dustAllowanceAddrToNumOfDustOutputs := make(map[string]int64)
+		return validateDustAllowance(t, utxos, div, dustOutputsCountLimit, dustAllowanceFunc, nil)
+	}
+}
 
-		for _, output := range essence.Outputs {
-			switch out := output.(type) {
-			case *SigLockedDustAllowanceOutput:
+// validates the dust allowance as described by NewDustSemanticValidation and adds the violations to the given collector.
+func validateDustAllowance(t *Transaction, utxos InputToOutputMapping, div int64, dustOutputsCountLimit int64, dustAllowanceFunc DustAllowanceFunc, c *violationCollector) error {
+	essence := t.Essence.(*TransactionEssence)
+
+	addrToValidate := make(map[string]Address)
+	dustAllowanceAddrToBalance := make(map[string]int64)
+	dustAllowanceAddrToNumOfDustOutputs := make(map[string]int64)
+
+	for _, output := range essence.Outputs {
+		switch out := output.(type) {
+		case *SigLockedDustAllowanceOutput:
+			addrToValidate[out.Address.(Address).String()] = out.Address.(Address)
+			dustAllowanceAddrToBalance[out.Address.(Address).String()] += int64(out.Amount)
+		case *SigLockedSingleOutput:
+			if out.Amount < OutputSigLockedDustAllowanceOutputMinDeposit {
 				addrToValidate[out.Address.(Address).String()] = out.Address.(Address)
-				dustAllowanceAddrToBalance[out.Address.(Address).String()] += int64(out.Amount)
-			case *SigLockedSingleOutput:
-				if out.Amount < OutputSigLockedDustAllowanceOutputMinDeposit {
-					addrToValidate[out.Address.(Address).String()] = out.Address.(Address)
-					dustAllowanceAddrToNumOfDustOutputs[out.Address.(Address).String()] += 1
-				}
+				dustAllowanceAddrToNumOfDustOutputs[out.Address.(Address).String()] += 1
 			}
 		}
+	}
 
-		for i, x := range t.Essence.(*TransactionEssence).Inputs {
-			utxoID := x.(*UTXOInput).ID()
-			utxo, ok := utxos[utxoID]
-			if !ok {
-				return fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, utxoID, i)
+	for i, x := range t.Essence.(*TransactionEssence).Inputs {
+		utxoID := x.(*UTXOInput).ID()
+		utxo, ok := utxos[utxoID]
+		if !ok {
+			if err := c.add(newViolation(RuleInputUTXOAvailable, i, nil, utxoID.ToHex(), fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, utxoID, i))); err != nil {
+				return err
 			}
+			continue
+		}
 
-			deposit, err := utxo.Deposit()
-			if err != nil {
-				return fmt.Errorf("unable to get deposit from UTXO %v (input at index %d): %w", utxoID, i, err)
-			}
+		deposit, err := utxo.Deposit()
+		if err != nil {
+			return fmt.Errorf("unable to get deposit from UTXO %v (input at index %d): %w", utxoID, i, err)
+		}
 
-			target, err := utxo.Target()
-			if err != nil {
-				return fmt.Errorf("unable to get target of UTXO %v (input at index %d): %w", utxoID, i, err)
-			}
+		target, err := utxo.Target()
+		if err != nil {
+			return fmt.Errorf("unable to get target of UTXO %v (input at index %d): %w", utxoID, i, err)
+		}
 
-			if deposit < OutputSigLockedDustAllowanceOutputMinDeposit {
-				addrToValidate[target.(Address).String()] = target.(Address)
-				dustAllowanceAddrToNumOfDustOutputs[target.(Address).String()] -= 1
-				continue
-			}
+		if deposit < OutputSigLockedDustAllowanceOutputMinDeposit {
+			addrToValidate[target.(Address).String()] = target.(Address)
+			dustAllowanceAddrToNumOfDustOutputs[target.(Address).String()] -= 1
+			continue
+		}
 
-			if utxo.Type() == OutputSigLockedDustAllowanceOutput {
-				addrToValidate[target.(Address).String()] = target.(Address)
-				dustAllowanceAddrToBalance[target.(Address).String()] -= int64(deposit)
-			}
+		if utxo.Type() == OutputSigLockedDustAllowanceOutput {
+			addrToValidate[target.(Address).String()] = target.(Address)
+			dustAllowanceAddrToBalance[target.(Address).String()] -= int64(deposit)
 		}
+	}
 
-		for addrKey, addr := range addrToValidate {
-			dustAllowanceDepositSumUint64, numDustOutputs, err := dustAllowanceFunc(addr)
-			if err != nil {
-				return fmt.Errorf("unable to fetch dust allowance information on address %v: %w", addr, err)
-			}
-			numDustOutputsPrev := numDustOutputs
-			numDustOutputs += dustAllowanceAddrToNumOfDustOutputs[addrKey]
+	// validated in a deterministic order to report the violations consistently
+	addrKeys := make([]string, 0, len(addrToValidate))
+	for addrKey := range addrToValidate {
+		addrKeys = append(addrKeys, addrKey)
+	}
+	sort.Strings(addrKeys)
+
+	for _, addrKey := range addrKeys {
+		addr := addrToValidate[addrKey]
+		dustAllowanceDepositSumUint64, numDustOutputs, err := dustAllowanceFunc(addr)
+		if err != nil {
+			return fmt.Errorf("unable to fetch dust allowance information on address %v: %w", addr, err)
+		}
+		numDustOutputsPrev := numDustOutputs
+		numDustOutputs += dustAllowanceAddrToNumOfDustOutputs[addrKey]
 
-			var dustAllowanceDepositSum = int64(dustAllowanceDepositSumUint64)
-			// Go integer division floors the value
-			prevAllowed := dustAllowanceDepositSum / div
-			allowed := (dustAllowanceDepositSum + dustAllowanceAddrToBalance[addrKey]) / div
+		var dustAllowanceDepositSum = int64(dustAllowanceDepositSumUint64)
+		// Go integer division floors the value
+		prevAllowed := dustAllowanceDepositSum / div
+		allowed := (dustAllowanceDepositSum + dustAllowanceAddrToBalance[addrKey]) / div
 
-			// limit
-			if allowed > dustOutputsCountLimit {
-				allowed = dustOutputsCountLimit
-			}
+		// limit
+		if allowed > dustOutputsCountLimit {
+			allowed = dustOutputsCountLimit
+		}
 
-			if numDustOutputs > allowed {
-				short := numDustOutputs - allowed
-				return fmt.Errorf("%w: addr %s, new num of dust outputs %d (previous %d), allowance deposit %d (previous %d), short %d", ErrInvalidDustAllowance, addrKey, numDustOutputs, numDustOutputsPrev, allowed, prevAllowed, short)
+		if numDustOutputs > allowed {
+			short := numDustOutputs - allowed
+			violation := newViolation(RuleDustAllowance, -1, allowed, numDustOutputs, fmt.Errorf("%w: addr %s, new num of dust outputs %d (previous %d), allowance deposit %d (previous %d), short %d", ErrInvalidDustAllowance, addrKey, numDustOutputs, numDustOutputsPrev, allowed, prevAllowed, short))
+			violation.Address = addr
+			if err := c.add(violation); err != nil {
+				return err
 			}
 		}
-
-		return nil
 	}
+
+	return nil
 }
 
 // InputToOutputMapping maps inputs to their origin UTXOs.
@@ -314,7 +346,7 @@ func (t *Transaction) SemanticallyValidate(utxos InputToOutputMapping, semValFun
 		return err
 	}
 
-	inputSum, sigValidFuncs, err := t.SemanticallyValidateInputs(utxos, txEssence, txEssenceBytes)
+	inputSum, sigValidations, _, err := t.semanticallyValidateInputs(utxos, txEssence, nil)
 	if err != nil {
 		return err
 	}
@@ -335,12 +367,40 @@ func (t *Transaction) SemanticallyValidate(utxos InputToOutputMapping, semValFun
 	}
 
 	// sig verifications runs at the end as they are the most computationally expensive operation
-	for _, f := range sigValidFuncs {
-		if err := f(); err != nil {
+	return verifyEd25519SigValidations(sigValidations, txEssenceBytes)
+}
+
+// semantically validates the Transaction like SemanticallyValidate without additional SemanticValidationFunc(s)
+// and adds the violations to the given collector. Every signature is verified on its own.
+func (t *Transaction) semanticallyValidate(utxos InputToOutputMapping, c *violationCollector) error {
+	txEssence := t.Essence.(*TransactionEssence)
+	txEssenceBytes, err := txEssence.SigningMessage()
+	if err != nil {
+		return err
+	}
+
+	inputSum, sigValidations, inputsComplete, err := t.semanticallyValidateInputs(utxos, txEssence, c)
+	if err != nil {
+		return err
+	}
+
+	outputSum, err := t.SemanticallyValidateOutputs(txEssence)
+	if err != nil {
+		return err
+	}
+
+	// the input sum is only meaningful if all UTXOs are available
+	if inputsComplete && inputSum != outputSum {
+		if err := c.add(newViolation(RuleInputOutputSum, -1, inputSum, outputSum, fmt.Errorf("%w: inputs sum %d, outputs sum %d", ErrInputOutputSumMismatch, inputSum, outputSum))); err != nil {
 			return err
 		}
 	}
 
+	for _, sigValidation := range sigValidations {
+		if err := c.add(sigValidation.validate(txEssenceBytes)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -348,66 +408,104 @@ func (t *Transaction) SemanticallyValidate(utxos InputToOutputMapping, semValFun
 // and returns functions which can be called to verify the signatures.
 // This function should only be called from SemanticallyValidate().
 func (t *Transaction) SemanticallyValidateInputs(utxos InputToOutputMapping, transaction *TransactionEssence, txEssenceBytes []byte) (uint64, []SigValidationFunc, error) {
-	var sigValidFuncs []SigValidationFunc
+	inputSum, sigValidations, _, err := t.semanticallyValidateInputs(utxos, transaction, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	sigValidFuncs := make([]SigValidationFunc, len(sigValidations))
+	for i, sigValidation := range sigValidations {
+		sigValidation := sigValidation
+		sigValidFuncs[i] = func() error {
+			return sigValidation.validate(txEssenceBytes)
+		}
+	}
+	return inputSum, sigValidFuncs, nil
+}
+
+// checks that every referenced UTXO is available, computes the input sum
+// and returns the signatures which need to be verified. The violations are added to the given collector.
+// The returned bool reports whether the input sum covers all inputs, which is not the case if a UTXO is missing.
+func (t *Transaction) semanticallyValidateInputs(utxos InputToOutputMapping, transaction *TransactionEssence, c *violationCollector) (uint64, []*ed25519SigValidation, bool, error) {
+	var sigValidations []*ed25519SigValidation
 	var inputSum uint64
+	inputsComplete := true
 	seenInputAddr := make(map[string]int)
 
 	for i, input := range transaction.Inputs {
 		in, alreadySeen := input.(*UTXOInput)
 		if !alreadySeen {
-			return 0, nil, fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i)
+			if err := c.add(newViolation(RuleInputType, i, nil, fmt.Sprintf("%T", input), fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i))); err != nil {
+				return 0, nil, false, err
+			}
+			inputsComplete = false
+			continue
 		}
 
 		// check that we got the needed UTXO
 		utxoID := in.ID()
 		utxo, has := utxos[utxoID]
 		if !has {
-			return 0, nil, fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, utxoID, i)
+			if err := c.add(newViolation(RuleInputUTXOAvailable, i, nil, utxoID.ToHex(), fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, utxoID, i))); err != nil {
+				return 0, nil, false, err
+			}
+			inputsComplete = false
+			continue
 		}
 
 		var err error
 		deposit, err := utxo.Deposit()
 		if err != nil {
-			return 0, nil, fmt.Errorf("unable to get deposit from UTXO %v (input at index %d): %w", utxoID, i, err)
+			return 0, nil, false, fmt.Errorf("unable to get deposit from UTXO %v (input at index %d): %w", utxoID, i, err)
 		}
 		inputSum += deposit
 
 		sigBlock, sigBlockIndex, err := t.signatureUnlockBlock(i)
 		if err != nil {
-			return 0, nil, err
+			if err := c.add(err); err != nil {
+				return 0, nil, false, err
+			}
+			continue
 		}
 
 		target, err := utxo.Target()
 		if err != nil {
-			return 0, nil, fmt.Errorf("unable to get target for UTXO %v: %w", utxoID, err)
+			return 0, nil, false, fmt.Errorf("unable to get target for UTXO %v: %w", utxoID, err)
 		}
 
 		// change this logic here once we got tx output types without addrs
 		addr, isAddr := target.(Address)
 		if !isAddr {
-			return 0, nil, fmt.Errorf("target for UTXO %v must be an address: %w", utxoID, err)
+			return 0, nil, false, fmt.Errorf("target for UTXO %v must be an address: %w", utxoID, err)
 		}
 
 		usedSigBlockIndex, alreadySeen := seenInputAddr[addr.String()]
 		if alreadySeen {
 			if usedSigBlockIndex != sigBlockIndex {
-				return 0, nil, fmt.Errorf("%w: target for UTXO %v uses a different signature unlock block (%d) than a previous UTXO (%d) for the same address", ErrInputSignatureUnlockBlockInvalid, utxoID, sigBlockIndex, usedSigBlockIndex)
+				violation := newViolation(RuleInputSigUnlockBlock, i, usedSigBlockIndex, sigBlockIndex, fmt.Errorf("%w: target for UTXO %v uses a different signature unlock block (%d) than a previous UTXO (%d) for the same address", ErrInputSignatureUnlockBlockInvalid, utxoID, sigBlockIndex, usedSigBlockIndex))
+				violation.Address = addr
+				if err := c.add(violation); err != nil {
+					return 0, nil, false, err
+				}
 			}
 			// we can skip here as we already created a sig validation func
 			continue
 		}
 
-		sigValidF, err := createSigValidationFunc(i, sigBlock.Signature, sigBlockIndex, txEssenceBytes, addr)
+		sigValidation, err := createSigValidation(i, sigBlock.Signature, sigBlockIndex, addr)
 		if err != nil {
-			return 0, nil, err
+			if err := c.add(err); err != nil {
+				return 0, nil, false, err
+			}
+			continue
 		}
 
 		seenInputAddr[addr.String()] = sigBlockIndex
 
-		sigValidFuncs = append(sigValidFuncs, sigValidF)
+		sigValidations = append(sigValidations, sigValidation)
 	}
 
-	return inputSum, sigValidFuncs, nil
+	return inputSum, sigValidations, inputsComplete, nil
 }
 
 // retrieves the SignatureUnlockBlock at the given index or follows
@@ -423,33 +521,86 @@ func (t *Transaction) signatureUnlockBlock(index int) (*SignatureUnlockBlock, in
 		sigUBIndex := int(ub.Reference)
 		return t.UnlockBlocks[sigUBIndex].(*SignatureUnlockBlock), sigUBIndex, nil
 	default:
-		return nil, 0, fmt.Errorf("%w: unsupported unlock block type at index %d", ErrUnknownUnlockBlockType, index)
+		return nil, 0, newViolation(RuleUnlockBlockType, index, nil, fmt.Sprintf("%T", ub), fmt.Errorf("%w: unsupported unlock block type at index %d", ErrUnknownUnlockBlockType, index))
+	}
+}
+
+// ed25519SigValidation is a pending verification of the Ed25519Signature unlocking the input at pos.
+type ed25519SigValidation struct {
+	pos           int
+	sigBlockIndex int
+	sig           *Ed25519Signature
+	addr          *Ed25519Address
+}
+
+// validate verifies the signature against the given essence bytes on its own.
+func (v *ed25519SigValidation) validate(essenceBytes []byte) error {
+	if err := v.sig.Valid(essenceBytes, v.addr); err != nil {
+		return v.wrapErr(err)
+	}
+	return nil
+}
+
+// wraps the error of the signature into a ValidationViolation of the input.
+func (v *ed25519SigValidation) wrapErr(err error) error {
+	rule := RuleSignatureValid
+	if errors.Is(err, ErrEd25519PubKeyAndAddrMismatch) {
+		rule = RuleSignaturePubKeyAddr
 	}
+	violation := newViolation(rule, v.pos, nil, nil, fmt.Errorf("%w: input at index %d, signature block at index %d", err, v.pos, v.sigBlockIndex))
+	violation.Address = v.addr
+	return violation
 }
 
-// creates a SigValidationFunc appropriate for the underlying signature type.
-func createSigValidationFunc(pos int, sig serializer.Serializable, sigBlockIndex int, txEssenceBytes []byte, addr Address) (SigValidationFunc, error) {
+// creates the signature validation appropriate for the underlying signature type.
+func createSigValidation(pos int, sig serializer.Serializable, sigBlockIndex int, addr Address) (*ed25519SigValidation, error) {
 	switch addr := addr.(type) {
 	case *Ed25519Address:
-		return createEd25519SigValidationFunc(pos, sig, sigBlockIndex, addr, txEssenceBytes)
+		return createEd25519SigValidation(pos, sig, sigBlockIndex, addr)
 	default:
-		return nil, fmt.Errorf("%w: unsupported address type at index %d", ErrUnknownAddrType, pos)
+		return nil, newViolation(RuleSignatureAddrType, pos, nil, fmt.Sprintf("%T", addr), fmt.Errorf("%w: unsupported address type at index %d", ErrUnknownAddrType, pos))
 	}
 }
 
-// creates a SigValidationFunc validating the given Ed25519Signature against the Ed25519Address.
-func createEd25519SigValidationFunc(pos int, sig serializer.Serializable, sigBlockIndex int, addr *Ed25519Address, essenceBytes []byte) (SigValidationFunc, error) {
+// creates a signature validation of the given Ed25519Signature against the Ed25519Address.
+func createEd25519SigValidation(pos int, sig serializer.Serializable, sigBlockIndex int, addr *Ed25519Address) (*ed25519SigValidation, error) {
 	ed25519Sig, isEd25519Sig := sig.(*Ed25519Signature)
 	if !isEd25519Sig {
-		return nil, fmt.Errorf("%w: UTXO at index %d has an Ed25519 address but its corresponding signature is of type %T (at index %d)", ErrSignatureAndAddrIncompatible, pos, sig, sigBlockIndex)
+		violation := newViolation(RuleSignatureAddrType, pos, nil, fmt.Sprintf("%T", sig), fmt.Errorf("%w: UTXO at index %d has an Ed25519 address but its corresponding signature is of type %T (at index %d)", ErrSignatureAndAddrIncompatible, pos, sig, sigBlockIndex))
+		violation.Address = addr
+		return nil, violation
 	}
+	return &ed25519SigValidation{pos: pos, sigBlockIndex: sigBlockIndex, sig: ed25519Sig, addr: addr}, nil
+}
 
-	return func() error {
-		if err := ed25519Sig.Valid(essenceBytes, addr); err != nil {
-			return fmt.Errorf("%w: input at index %d, signature block at index %d", err, pos, sigBlockIndex)
+// verifies the given signature validations as a batch and returns the error of the first invalid one.
+func verifyEd25519SigValidations(sigValidations []*ed25519SigValidation, essenceBytes []byte) error {
+	errs := make([]error, len(sigValidations))
+	batch := ed25519.NewBatchVerifier(len(sigValidations))
+	var batched []int
+	for i, v := range sigValidations {
+		if err := v.sig.matchesAddress(v.addr); err != nil {
+			errs[i] = err
+			continue
+		}
+		batch.Add(v.sig.PublicKey[:], essenceBytes, v.sig.Signature[:])
+		batched = append(batched, i)
+	}
+
+	if allValid, valid := batch.Verify(); !allValid {
+		for j, i := range batched {
+			if !valid[j] {
+				errs[i] = sigValidations[i].sig.invalidErr(sigValidations[i].addr)
+			}
 		}
-		return nil
-	}, nil
+	}
+
+	for i, err := range errs {
+		if err != nil {
+			return sigValidations[i].wrapErr(err)
+		}
+	}
+	return nil
 }
 
 // SemanticallyValidateOutputs accumulates the sum of all outputs.
@@ -85,6 +85,81 @@ func (b *TransactionBuilder) AddOutput(output Output) *TransactionBuilder {
 	return b
 }
 
+// ApplyDustPlan adds the outputs of the given DustPlan to the builder. If the plan consolidates dust outputs,
+// these are added as inputs, therefore the keys of the plan's address must be available to the signer.
+// It is the caller's job to provide inputs covering the payment and any dust allowance top-up.
+func (b *TransactionBuilder) ApplyDustPlan(plan *DustPlan) *TransactionBuilder {
+	for _, input := range plan.Consolidate {
+		b.AddInput(&ToBeSignedUTXOInput{Address: plan.Address, Input: input})
+	}
+	for _, output := range plan.Outputs() {
+		b.AddOutput(output)
+	}
+	return b
+}
+
+// AddDustSafeOutput plans a payment of the given amount to the given address with the given DustPlanner
+// using the DustAllowanceFunc and applies the resulting plan. planner can be nil.
+// As the DustAllowanceFunc does not provide the dust outputs of the address, they are never consolidated.
+func (b *TransactionBuilder) AddDustSafeOutput(addr Address, amount uint64, dustAllowanceFunc DustAllowanceFunc, planner *DustPlanner) *TransactionBuilder {
+	state, err := DustAllowanceStateFromFunc(addr, dustAllowanceFunc)
+	if err != nil {
+		b.occurredBuildErr = err
+		return b
+	}
+	return b.addDustSafeOutput(state, amount, planner)
+}
+
+// AddDustSafeOutputViaNodeQuery plans a payment of the given amount to the given address with the given DustPlanner
+// using the dust allowance state queried from the node and applies the resulting plan. planner can be nil.
+// The dust outputs of the address are only consolidated if the builder already holds inputs of the address,
+// as only then the keys of the address are known to be available to the signer.
+func (b *TransactionBuilder) AddDustSafeOutputViaNodeQuery(ctx context.Context, addr Address, amount uint64, nodeHTTPAPIClient *NodeHTTPAPIClient, planner *DustPlanner) *TransactionBuilder {
+	ed25519Addr, ok := addr.(*Ed25519Address)
+	if !ok {
+		b.occurredBuildErr = fmt.Errorf("%w: dust safe output via node query only supports Ed25519Address but got %T", ErrTransactionBuilderUnsupportedAddress, addr)
+		return b
+	}
+
+	state, err := DustAllowanceStateViaNodeQuery(ctx, ed25519Addr, nodeHTTPAPIClient)
+	if err != nil {
+		b.occurredBuildErr = err
+		return b
+	}
+	return b.addDustSafeOutput(state, amount, planner)
+}
+
+// plans the payment to the address of the given state and applies the plan.
+// Dust outputs are only consolidated if the address owns inputs of the builder and the inputs count limit allows it.
+func (b *TransactionBuilder) addDustSafeOutput(state *DustAllowanceState, amount uint64, planner *DustPlanner) *TransactionBuilder {
+	if planner == nil {
+		planner = NewDustPlanner()
+	}
+
+	if len(state.DustOutputs) > 0 && !b.ownsAddress(state.Address) {
+		ownState := *state
+		ownState.DustOutputs = nil
+		state = &ownState
+	}
+
+	plan, err := planner.plan(state, amount, MaxInputsCount-len(b.essence.Inputs))
+	if err != nil {
+		b.occurredBuildErr = err
+		return b
+	}
+	return b.ApplyDustPlan(plan)
+}
+
+// reports whether any input of the builder belongs to the given address.
+func (b *TransactionBuilder) ownsAddress(addr Address) bool {
+	for _, inputAddr := range b.inputToAddr {
+		if inputAddr.String() == addr.String() {
+			return true
+		}
+	}
+	return false
+}
+
 // AddIndexationPayload adds the given Indexation as the inner payload.
 func (b *TransactionBuilder) AddIndexationPayload(payload *Indexation) *TransactionBuilder {
 	b.essence.Payload = payload
@@ -293,23 +293,32 @@ func (u *TransactionEssence) UnmarshalJSON(bytes []byte) error {
 //	4. SigLockedDustAllowanceOutput deposits at least OutputSigLockedDustAllowanceOutputMinDeposit.
 // The function does not syntactically validate the input or outputs themselves.
 func (u *TransactionEssence) SyntacticallyValidate() error {
+	return u.syntacticallyValidate(nil)
+}
+
+// syntactically validates the TransactionEssence and adds the violations to the given collector.
+func (u *TransactionEssence) syntacticallyValidate(c *violationCollector) error {
 
 	if len(u.Inputs) == 0 {
-		return ErrMinInputsNotReached
+		if err := c.add(newViolation(RuleInputsMinCount, -1, MinInputsCount, 0, ErrMinInputsNotReached)); err != nil {
+			return err
+		}
 	}
 
 	if len(u.Outputs) == 0 {
-		return ErrMinOutputsNotReached
+		if err := c.add(newViolation(RuleOutputsMinCount, -1, MinOutputsCount, 0, ErrMinOutputsNotReached)); err != nil {
+			return err
+		}
 	}
 
-	if err := ValidateInputs(u.Inputs,
+	if err := validateInputs(u.Inputs, c,
 		InputsUTXORefIndexBoundsValidator(),
 		InputsUTXORefsUniqueValidator(),
 	); err != nil {
 		return err
 	}
 
-	if err := ValidateOutputs(u.Outputs,
+	if err := validateOutputs(u.Outputs, c,
 		OutputsAddrUniqueValidator(),
 		OutputsDepositAmountValidator(),
 	); err != nil {
@@ -2,6 +2,7 @@ package iotago_test
 
 import (
 	"errors"
+	"fmt"
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/tpkg"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/iotaledger/iota.go/v2"
 	"github.com/iotaledger/iota.go/v2/ed25519"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTransaction_Deserialize(t *testing.T) {
@@ -187,6 +189,48 @@ func TestTransaction_SemanticallyValidate(t *testing.T) {
 
 }
 
+func TestTransaction_SemanticallyValidate_Signatures(t *testing.T) {
+	const inputsCount = 4
+	builder := iotago.NewTransactionBuilder()
+	inputUTXOs := iotago.InputToOutputMapping{}
+	var addrKeys []iotago.AddressKeys
+	for i := 0; i < inputsCount; i++ {
+		prvKey := tpkg.RandEd25519PrivateKey()
+		inputAddr := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+		addrKeys = append(addrKeys, iotago.AddressKeys{Address: &inputAddr, Keys: prvKey})
+
+		inputUTXO := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+		builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO})
+		inputUTXOs[inputUTXO.ID()] = &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 1_000_000}
+	}
+	outputAddr, _ := tpkg.RandEd25519Address()
+	builder.AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: inputsCount * 1_000_000})
+
+	tx, err := builder.Build(iotago.NewInMemoryAddressSigner(addrKeys...))
+	require.NoError(t, err)
+	require.NoError(t, tx.SemanticallyValidate(inputUTXOs))
+
+	// the invalid signature is identified within the batch
+	txEssence := tx.Essence.(*iotago.TransactionEssence)
+	for i, input := range txEssence.Inputs {
+		inputAddr := inputUTXOs[input.(*iotago.UTXOInput).ID()].(*iotago.SigLockedSingleOutput).Address
+		if *inputAddr.(*iotago.Ed25519Address) == *addrKeys[2].Address.(*iotago.Ed25519Address) {
+			sig := tx.UnlockBlocks[i].(*iotago.SignatureUnlockBlock).Signature.(*iotago.Ed25519Signature)
+			sig.Signature[0] ^= 0xff
+
+			err := tx.SemanticallyValidate(inputUTXOs)
+			assert.True(t, errors.Is(err, iotago.ErrEd25519SignatureInvalid))
+			assert.Contains(t, err.Error(), fmt.Sprintf("input at index %d,", i))
+
+			txEssenceBytes, err := txEssence.SigningMessage()
+			require.NoError(t, err)
+			_, sigValidFuncs, err := tx.SemanticallyValidateInputs(inputUTXOs, txEssence, txEssenceBytes)
+			require.NoError(t, err)
+			assert.True(t, errors.Is(sigValidFuncs[i](), iotago.ErrEd25519SignatureInvalid))
+		}
+	}
+}
+
 func TestDustAllowance(t *testing.T) {
 	identityOne := tpkg.RandEd25519PrivateKey()
 	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
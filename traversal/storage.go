@@ -0,0 +1,192 @@
+package traversal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/iotaledger/iota.go/v2"
+)
+
+var (
+	// ErrMessageNotFound gets returned by a Storage when it does not hold a message.
+	ErrMessageNotFound = errors.New("message not found")
+)
+
+// Storage provides the messages, their metadata and their children to traverse.
+type Storage interface {
+	// Message returns the message with the given ID or an error wrapping ErrMessageNotFound.
+	Message(ctx context.Context, msgID iotago.MessageID) (*iotago.Message, error)
+	// Metadata returns the metadata of the message with the given ID or an error wrapping ErrMessageNotFound.
+	Metadata(ctx context.Context, msgID iotago.MessageID) (*iotago.MessageMetadataResponse, error)
+	// Children returns the IDs of the messages referencing the message with the given ID as a parent.
+	Children(ctx context.Context, msgID iotago.MessageID) (iotago.MessageIDs, error)
+}
+
+// NewNodeStorage creates a new Storage which queries the given node.
+func NewNodeStorage(nodeHTTPAPIClient *iotago.NodeHTTPAPIClient) Storage {
+	return &nodeStorage{nodeAPI: nodeHTTPAPIClient}
+}
+
+type nodeStorage struct {
+	nodeAPI *iotago.NodeHTTPAPIClient
+}
+
+// wraps ErrHTTPNotFound errors of the node with ErrMessageNotFound.
+func notFound(err error) error {
+	if errors.Is(err, iotago.ErrHTTPNotFound) {
+		return fmt.Errorf("%w: %s", ErrMessageNotFound, err)
+	}
+	return err
+}
+
+func (s *nodeStorage) Message(ctx context.Context, msgID iotago.MessageID) (*iotago.Message, error) {
+	msg, err := s.nodeAPI.MessageByMessageID(ctx, msgID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return msg, nil
+}
+
+func (s *nodeStorage) Metadata(ctx context.Context, msgID iotago.MessageID) (*iotago.MessageMetadataResponse, error) {
+	metadata, err := s.nodeAPI.MessageMetadataByMessageID(ctx, msgID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return metadata, nil
+}
+
+func (s *nodeStorage) Children(ctx context.Context, msgID iotago.MessageID) (iotago.MessageIDs, error) {
+	res, err := s.nodeAPI.ChildrenByMessageID(ctx, msgID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	children := make(iotago.MessageIDs, len(res.Children))
+	for i, childHex := range res.Children {
+		if children[i], err = iotago.MessageIDFromHexString(childHex); err != nil {
+			return nil, fmt.Errorf("invalid child message ID %s: %w", childHex, err)
+		}
+	}
+	return children, nil
+}
+
+// NewMemoryStorage creates a new empty in-memory Storage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		msgs:     make(map[iotago.MessageID]*iotago.Message),
+		metadata: make(map[iotago.MessageID]*iotago.MessageMetadataResponse),
+		children: make(map[iotago.MessageID]iotago.MessageIDs),
+	}
+}
+
+// MemoryStorage is a Storage holding messages in memory.
+type MemoryStorage struct {
+	mu       sync.RWMutex
+	msgs     map[iotago.MessageID]*iotago.Message
+	metadata map[iotago.MessageID]*iotago.MessageMetadataResponse
+	children map[iotago.MessageID]iotago.MessageIDs
+}
+
+// Add adds the given message and its metadata, which might be nil, and returns the ID of the message.
+func (s *MemoryStorage) Add(msg *iotago.Message, metadata *iotago.MessageMetadataResponse) (iotago.MessageID, error) {
+	msgID, err := msg.ID()
+	if err != nil {
+		return iotago.MessageID{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, has := s.msgs[*msgID]; !has {
+		for _, parent := range msg.Parents {
+			s.children[parent] = append(s.children[parent], *msgID)
+		}
+	}
+	s.msgs[*msgID] = msg
+	if metadata != nil {
+		s.metadata[*msgID] = metadata
+	}
+	return *msgID, nil
+}
+
+func (s *MemoryStorage) Message(_ context.Context, msgID iotago.MessageID) (*iotago.Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	msg, has := s.msgs[msgID]
+	if !has {
+		return nil, fmt.Errorf("%w: %s", ErrMessageNotFound, iotago.MessageIDToHexString(msgID))
+	}
+	return msg, nil
+}
+
+func (s *MemoryStorage) Metadata(_ context.Context, msgID iotago.MessageID) (*iotago.MessageMetadataResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	metadata, has := s.metadata[msgID]
+	if !has {
+		return nil, fmt.Errorf("%w: no metadata for %s", ErrMessageNotFound, iotago.MessageIDToHexString(msgID))
+	}
+	return metadata, nil
+}
+
+func (s *MemoryStorage) Children(_ context.Context, msgID iotago.MessageID) (iotago.MessageIDs, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append(iotago.MessageIDs{}, s.children[msgID]...), nil
+}
+
+// NewCachedStorage creates a new Storage which caches the messages and metadata returned by the given Storage.
+// Children are not cached as they grow over time.
+func NewCachedStorage(storage Storage) Storage {
+	return &cachedStorage{
+		Storage:  storage,
+		msgs:     make(map[iotago.MessageID]*iotago.Message),
+		metadata: make(map[iotago.MessageID]*iotago.MessageMetadataResponse),
+	}
+}
+
+type cachedStorage struct {
+	Storage
+	mu       sync.RWMutex
+	msgs     map[iotago.MessageID]*iotago.Message
+	metadata map[iotago.MessageID]*iotago.MessageMetadataResponse
+}
+
+func (s *cachedStorage) Message(ctx context.Context, msgID iotago.MessageID) (*iotago.Message, error) {
+	s.mu.RLock()
+	msg, has := s.msgs[msgID]
+	s.mu.RUnlock()
+	if has {
+		return msg, nil
+	}
+
+	msg, err := s.Storage.Message(ctx, msgID)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.msgs[msgID] = msg
+	s.mu.Unlock()
+	return msg, nil
+}
+
+func (s *cachedStorage) Metadata(ctx context.Context, msgID iotago.MessageID) (*iotago.MessageMetadataResponse, error) {
+	s.mu.RLock()
+	metadata, has := s.metadata[msgID]
+	s.mu.RUnlock()
+	if has {
+		return metadata, nil
+	}
+
+	metadata, err := s.Storage.Metadata(ctx, msgID)
+	if err != nil {
+		return nil, err
+	}
+	// the metadata of unreferenced messages still changes
+	if metadata.ReferencedByMilestoneIndex != nil {
+		s.mu.Lock()
+		s.metadata[msgID] = metadata
+		s.mu.Unlock()
+	}
+	return metadata, nil
+}
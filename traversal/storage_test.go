@@ -0,0 +1,90 @@
+package traversal_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/iotaledger/iota.go/v2/traversal"
+)
+
+const nodeAPIUrl = "http://127.0.0.1:14265"
+
+func TestNodeStorage(t *testing.T) {
+	defer gock.Off()
+
+	msg := &iotago.Message{Parents: tpkg.SortedRand32BytArray(2), Payload: &iotago.Indexation{Index: []byte("node"), Data: []byte{1}}}
+	msgData, err := msg.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+	msgID, err := msg.ID()
+	require.NoError(t, err)
+	msgIDHex := iotago.MessageIDToHexString(*msgID)
+	child := tpkg.Rand32ByteArray()
+	missing := tpkg.Rand32ByteArray()
+
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMessageBytes, msgIDHex)).
+		Times(2).
+		Reply(200).
+		Body(bytes.NewReader(msgData))
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMessageChildren, msgIDHex)).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.ChildrenResponse{MessageID: msgIDHex, Count: 1, Children: []string{iotago.MessageIDToHexString(child)}}})
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMessageMetadata, iotago.MessageIDToHexString(missing))).
+		Reply(404).
+		JSON(&iotago.HTTPErrorResponseEnvelope{})
+
+	storage := traversal.NewNodeStorage(iotago.NewNodeHTTPAPIClient(nodeAPIUrl))
+	fromNode, err := storage.Message(context.Background(), *msgID)
+	require.NoError(t, err)
+	assert.Equal(t, msg, fromNode)
+
+	children, err := storage.Children(context.Background(), *msgID)
+	require.NoError(t, err)
+	assert.Equal(t, iotago.MessageIDs{child}, children)
+
+	_, err = storage.Metadata(context.Background(), missing)
+	assert.True(t, errors.Is(err, traversal.ErrMessageNotFound))
+
+	// the second query of the message is served from the cache
+	cached := traversal.NewCachedStorage(storage)
+	for i := 0; i < 3; i++ {
+		fromCache, err := cached.Message(context.Background(), *msgID)
+		require.NoError(t, err)
+		assert.Equal(t, msg, fromCache)
+	}
+	assert.True(t, gock.IsDone())
+}
+
+func TestMemoryStorage(t *testing.T) {
+	storage := traversal.NewMemoryStorage()
+	parent := tpkg.Rand32ByteArray()
+	msg := &iotago.Message{Parents: iotago.MessageIDs{parent}, Payload: &iotago.Indexation{Index: []byte("memory")}}
+
+	msgID, err := storage.Add(msg, nil)
+	require.NoError(t, err)
+	_, err = storage.Add(msg, &iotago.MessageMetadataResponse{MessageID: iotago.MessageIDToHexString(msgID)})
+	require.NoError(t, err)
+
+	children, err := storage.Children(context.Background(), parent)
+	require.NoError(t, err)
+	assert.Equal(t, iotago.MessageIDs{msgID}, children)
+
+	metadata, err := storage.Metadata(context.Background(), msgID)
+	require.NoError(t, err)
+	assert.Equal(t, iotago.MessageIDToHexString(msgID), metadata.MessageID)
+
+	_, err = storage.Metadata(context.Background(), parent)
+	assert.True(t, errors.Is(err, traversal.ErrMessageNotFound))
+}
@@ -0,0 +1,325 @@
+// Package traversal provides walks over the past and future cones of messages in the Tangle.
+//
+// A Walker walks the parents (past cone) or children (future cone) of messages in breadth-first or depth-first order,
+// visiting every message once. Messages matching a StopCondition are neither consumed nor walked past:
+//
+//	storage := traversal.NewCachedStorage(traversal.NewNodeStorage(nodeHTTPAPIClient))
+//	walker := traversal.NewWalker(storage, traversal.WithStopCondition(traversal.StopAtReferencedByMilestone(1000)))
+//	err := walker.PastCone(ctx, iotago.MessageIDs{msgID}, func(msgID iotago.MessageID, msg *iotago.Message) error {
+//		// unreferenced past cone of msgID up to milestone 1000
+//		return nil
+//	})
+//
+// The messages are provided by a Storage, which can be a node, a local archive or an in-memory set of messages.
+package traversal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/iotaledger/iota.go/v2"
+)
+
+var (
+	// ErrStop can be returned by a Consumer to end a walk without an error.
+	ErrStop = errors.New("stop traversal")
+)
+
+// Order defines the order in which a Walker visits messages.
+type Order int
+
+const (
+	// BFS visits messages in breadth-first order.
+	BFS Order = iota
+	// DFS visits messages in depth-first order.
+	DFS
+)
+
+// Consumer is a function consuming the messages visited by a Walker.
+type Consumer func(msgID iotago.MessageID, msg *iotago.Message) error
+
+// StopCondition is a function which tells whether a Walker must stop at the given message.
+type StopCondition func(ctx context.Context, storage Storage, msgID iotago.MessageID, msg *iotago.Message) (bool, error)
+
+// StopAtMessages returns a StopCondition which stops at the messages with the given IDs.
+func StopAtMessages(msgIDs ...iotago.MessageID) StopCondition {
+	set := make(map[iotago.MessageID]struct{}, len(msgIDs))
+	for _, msgID := range msgIDs {
+		set[msgID] = struct{}{}
+	}
+	return func(_ context.Context, _ Storage, msgID iotago.MessageID, _ *iotago.Message) (bool, error) {
+		_, has := set[msgID]
+		return has, nil
+	}
+}
+
+// StopAtMilestones returns a StopCondition which stops at messages containing a Milestone.
+func StopAtMilestones() StopCondition {
+	return func(_ context.Context, _ Storage, _ iotago.MessageID, msg *iotago.Message) (bool, error) {
+		_, isMilestone := msg.Payload.(*iotago.Milestone)
+		return isMilestone, nil
+	}
+}
+
+// StopAtReferencedByMilestone returns a StopCondition which stops at messages referenced by the milestone
+// of the given index or a previous one. Messages without metadata are not stopped at.
+func StopAtReferencedByMilestone(index uint32) StopCondition {
+	return func(ctx context.Context, storage Storage, msgID iotago.MessageID, _ *iotago.Message) (bool, error) {
+		metadata, err := storage.Metadata(ctx, msgID)
+		switch {
+		case errors.Is(err, ErrMessageNotFound):
+			return false, nil
+		case err != nil:
+			return false, err
+		}
+		return metadata.ReferencedByMilestoneIndex != nil && *metadata.ReferencedByMilestoneIndex <= index, nil
+	}
+}
+
+// AnyOf returns a StopCondition which stops at a message if any of the given StopCondition does.
+func AnyOf(conditions ...StopCondition) StopCondition {
+	return func(ctx context.Context, storage Storage, msgID iotago.MessageID, msg *iotago.Message) (bool, error) {
+		for _, condition := range conditions {
+			stop, err := condition(ctx, storage, msgID, msg)
+			if err != nil || stop {
+				return stop, err
+			}
+		}
+		return false, nil
+	}
+}
+
+// the default options applied to the Walker.
+var defaultWalkerOptions = []WalkerOption{
+	WithOrder(BFS),
+	WithWorkers(1),
+	WithSkipMissing(false),
+}
+
+// WalkerOptions define options for the Walker.
+type WalkerOptions struct {
+	// The order in which messages are visited.
+	order Order
+	// The StopCondition, nil if the walk never stops.
+	stopCondition StopCondition
+	// The amount of workers fetching messages concurrently.
+	workers int
+	// Whether messages missing in the Storage are skipped.
+	skipMissing bool
+}
+
+// applies the given WalkerOption.
+func (wo *WalkerOptions) apply(opts ...WalkerOption) {
+	for _, opt := range opts {
+		opt(wo)
+	}
+}
+
+// WithOrder sets the order in which messages are visited.
+func WithOrder(order Order) WalkerOption {
+	return func(opts *WalkerOptions) {
+		opts.order = order
+	}
+}
+
+// WithStopCondition sets the StopCondition of the walk. Use AnyOf to combine conditions.
+func WithStopCondition(condition StopCondition) WalkerOption {
+	return func(opts *WalkerOptions) {
+		opts.stopCondition = condition
+	}
+}
+
+// WithWorkers sets the amount of workers fetching the messages of one level concurrently in a BFS walk.
+// DFS walks fetch messages sequentially.
+func WithWorkers(workers int) WalkerOption {
+	return func(opts *WalkerOptions) {
+		if workers < 1 {
+			workers = 1
+		}
+		opts.workers = workers
+	}
+}
+
+// WithSkipMissing defines whether messages missing in the Storage, for example solid entry points or pruned messages,
+// are skipped instead of ending the walk with an error.
+func WithSkipMissing(skip bool) WalkerOption {
+	return func(opts *WalkerOptions) {
+		opts.skipMissing = skip
+	}
+}
+
+// WalkerOption is a function setting a Walker option.
+type WalkerOption func(opts *WalkerOptions)
+
+// NewWalker creates a new Walker walking the messages of the given Storage.
+func NewWalker(storage Storage, opts ...WalkerOption) *Walker {
+	options := &WalkerOptions{}
+	options.apply(defaultWalkerOptions...)
+	options.apply(opts...)
+	return &Walker{storage: storage, opts: options}
+}
+
+// Walker walks the past or future cone of messages.
+type Walker struct {
+	storage Storage
+	opts    *WalkerOptions
+}
+
+// PastCone walks the past cone of the given messages, including themselves, and passes every visited message to the consumer.
+func (w *Walker) PastCone(ctx context.Context, start iotago.MessageIDs, consumer Consumer) error {
+	return w.walk(ctx, start, consumer, func(_ context.Context, _ iotago.MessageID, msg *iotago.Message) (iotago.MessageIDs, error) {
+		return msg.Parents, nil
+	})
+}
+
+// FutureCone walks the future cone of the given messages, including themselves, and passes every visited message to the consumer.
+func (w *Walker) FutureCone(ctx context.Context, start iotago.MessageIDs, consumer Consumer) error {
+	return w.walk(ctx, start, consumer, func(ctx context.Context, msgID iotago.MessageID, _ *iotago.Message) (iotago.MessageIDs, error) {
+		return w.storage.Children(ctx, msgID)
+	})
+}
+
+// neighborsFunc returns the messages to walk to from the given message.
+type neighborsFunc func(ctx context.Context, msgID iotago.MessageID, msg *iotago.Message) (iotago.MessageIDs, error)
+
+// the result of visiting a message.
+type visit struct {
+	msgID     iotago.MessageID
+	msg       *iotago.Message
+	skip      bool
+	neighbors iotago.MessageIDs
+	err       error
+}
+
+// fetches the message, evaluates the StopCondition and determines the neighbors to walk to.
+func (w *Walker) visit(ctx context.Context, msgID iotago.MessageID, neighbors neighborsFunc) *visit {
+	v := &visit{msgID: msgID}
+	if v.msg, v.err = w.storage.Message(ctx, msgID); v.err != nil {
+		if errors.Is(v.err, ErrMessageNotFound) && w.opts.skipMissing {
+			v.skip, v.err = true, nil
+		}
+		return v
+	}
+
+	if w.opts.stopCondition != nil {
+		if v.skip, v.err = w.opts.stopCondition(ctx, w.storage, msgID, v.msg); v.err != nil || v.skip {
+			return v
+		}
+	}
+
+	v.neighbors, v.err = neighbors(ctx, msgID, v.msg)
+	return v
+}
+
+// visits the given messages concurrently.
+func (w *Walker) visitAll(ctx context.Context, msgIDs iotago.MessageIDs, neighbors neighborsFunc) []*visit {
+	visits := make([]*visit, len(msgIDs))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < w.opts.workers && i < len(msgIDs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				visits[index] = w.visit(ctx, msgIDs[index], neighbors)
+			}
+		}()
+	}
+	for i := range msgIDs {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return visits
+}
+
+func (w *Walker) walk(ctx context.Context, start iotago.MessageIDs, consumer Consumer, neighbors neighborsFunc) error {
+	consume := func(v *visit) error {
+		if v.err != nil {
+			return fmt.Errorf("unable to visit message %s: %w", iotago.MessageIDToHexString(v.msgID), v.err)
+		}
+		if v.skip {
+			return nil
+		}
+		return consumer(v.msgID, v.msg)
+	}
+
+	var err error
+	switch w.opts.order {
+	case DFS:
+		err = w.walkDFS(ctx, start, consume, neighbors)
+	default:
+		err = w.walkBFS(ctx, start, consume, neighbors)
+	}
+	if errors.Is(err, ErrStop) {
+		return nil
+	}
+	return err
+}
+
+// walks level by level, visiting the messages of a level concurrently.
+func (w *Walker) walkBFS(ctx context.Context, start iotago.MessageIDs, consume func(v *visit) error, neighbors neighborsFunc) error {
+	visited := make(map[iotago.MessageID]struct{})
+	var level iotago.MessageIDs
+	enqueue := func(msgIDs iotago.MessageIDs) {
+		for _, msgID := range msgIDs {
+			if _, has := visited[msgID]; has {
+				continue
+			}
+			visited[msgID] = struct{}{}
+			level = append(level, msgID)
+		}
+	}
+
+	enqueue(start)
+	for len(level) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		visits := w.visitAll(ctx, level, neighbors)
+		level = nil
+		for _, v := range visits {
+			if err := consume(v); err != nil {
+				return err
+			}
+			enqueue(v.neighbors)
+		}
+	}
+	return nil
+}
+
+// walks as deep as possible along the first neighbors before backtracking.
+func (w *Walker) walkDFS(ctx context.Context, start iotago.MessageIDs, consume func(v *visit) error, neighbors neighborsFunc) error {
+	visited := make(map[iotago.MessageID]struct{})
+	stack := make(iotago.MessageIDs, 0, len(start))
+	push := func(msgIDs iotago.MessageIDs) {
+		// push in reverse to visit the first message first
+		for i := len(msgIDs) - 1; i >= 0; i-- {
+			if _, has := visited[msgIDs[i]]; !has {
+				stack = append(stack, msgIDs[i])
+			}
+		}
+	}
+
+	push(start)
+	for len(stack) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, has := visited[current]; has {
+			continue
+		}
+		visited[current] = struct{}{}
+
+		v := w.visit(ctx, current, neighbors)
+		if err := consume(v); err != nil {
+			return err
+		}
+		push(v.neighbors)
+	}
+	return nil
+}
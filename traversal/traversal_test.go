@@ -0,0 +1,132 @@
+package traversal_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/iotaledger/iota.go/v2/traversal"
+)
+
+// testTangle is the following tangle, where g references a missing parent:
+//
+//	g <- a <- c <- d <- e
+//	 ^        |         |
+//	 +-- b <--+---------+
+type testTangle struct {
+	storage                *traversal.MemoryStorage
+	missing, g, a, b, c, d iotago.MessageID
+	e                      iotago.MessageID
+	names                  map[iotago.MessageID]string
+}
+
+func newTestTangle(t *testing.T) *testTangle {
+	tt := &testTangle{storage: traversal.NewMemoryStorage(), missing: tpkg.Rand32ByteArray(), names: map[iotago.MessageID]string{}}
+	attach := func(name string, parents ...iotago.MessageID) iotago.MessageID {
+		msg := &iotago.Message{
+			Parents: serializer.RemoveDupsAndSortByLexicalOrderArrayOf32Bytes(parents),
+			Payload: &iotago.Indexation{Index: []byte(name)},
+		}
+		msgID, err := tt.storage.Add(msg, &iotago.MessageMetadataResponse{})
+		require.NoError(t, err)
+		tt.names[msgID] = name
+		return msgID
+	}
+	tt.g = attach("g", tt.missing)
+	tt.a = attach("a", tt.g)
+	tt.b = attach("b", tt.g)
+	tt.c = attach("c", tt.a, tt.b)
+	tt.d = attach("d", tt.c)
+	tt.e = attach("e", tt.d, tt.b)
+	return tt
+}
+
+// walks with the given function and returns the names of the visited messages in order.
+func (tt *testTangle) collect(t *testing.T, walk func(ctx context.Context, start iotago.MessageIDs, consumer traversal.Consumer) error, start ...iotago.MessageID) []string {
+	var names []string
+	require.NoError(t, walk(context.Background(), start, func(msgID iotago.MessageID, msg *iotago.Message) error {
+		names = append(names, tt.names[msgID])
+		return nil
+	}))
+	return names
+}
+
+func TestWalker_PastCone(t *testing.T) {
+	tt := newTestTangle(t)
+
+	_, err := tt.storage.Message(context.Background(), tt.missing)
+	require.True(t, errors.Is(err, traversal.ErrMessageNotFound))
+
+	// the missing parent of g ends the walk
+	err = traversal.NewWalker(tt.storage).PastCone(context.Background(), iotago.MessageIDs{tt.e}, func(iotago.MessageID, *iotago.Message) error { return nil })
+	assert.True(t, errors.Is(err, traversal.ErrMessageNotFound))
+
+	bfs := tt.collect(t, traversal.NewWalker(tt.storage, traversal.WithSkipMissing(true), traversal.WithWorkers(4)).PastCone, tt.e)
+	require.Len(t, bfs, 6)
+	assert.Equal(t, "e", bfs[0])
+	assert.ElementsMatch(t, []string{"d", "b"}, bfs[1:3])
+	assert.ElementsMatch(t, []string{"c", "g"}, bfs[3:5])
+	assert.Equal(t, "a", bfs[5])
+
+	dfs := tt.collect(t, traversal.NewWalker(tt.storage, traversal.WithSkipMissing(true), traversal.WithOrder(traversal.DFS)).PastCone, tt.d)
+	require.Len(t, dfs, 5)
+	assert.Equal(t, []string{"d", "c"}, dfs[:2])
+	// the first parent of c is walked to g before the second parent is visited
+	if bytes.Compare(tt.a[:], tt.b[:]) < 0 {
+		assert.Equal(t, []string{"a", "g", "b"}, dfs[2:])
+	} else {
+		assert.Equal(t, []string{"b", "g", "a"}, dfs[2:])
+	}
+
+	stopped := tt.collect(t, traversal.NewWalker(tt.storage, traversal.WithStopCondition(traversal.StopAtMessages(tt.b, tt.c))).PastCone, tt.e)
+	assert.Equal(t, []string{"e", "d"}, stopped)
+
+	var visits int
+	err = traversal.NewWalker(tt.storage).PastCone(context.Background(), iotago.MessageIDs{tt.e}, func(iotago.MessageID, *iotago.Message) error {
+		visits++
+		return traversal.ErrStop
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, visits)
+}
+
+func TestWalker_FutureCone(t *testing.T) {
+	tt := newTestTangle(t)
+
+	assert.ElementsMatch(t, []string{"b", "c", "d", "e"}, tt.collect(t, traversal.NewWalker(tt.storage).FutureCone, tt.b))
+	assert.ElementsMatch(t, []string{"g", "a", "b", "c", "d", "e"}, tt.collect(t, traversal.NewWalker(tt.storage, traversal.WithOrder(traversal.DFS)).FutureCone, tt.g))
+
+	stopped := tt.collect(t, traversal.NewWalker(tt.storage, traversal.WithStopCondition(traversal.StopAtMessages(tt.c))).FutureCone, tt.a, tt.b)
+	assert.ElementsMatch(t, []string{"a", "b", "e"}, stopped)
+}
+
+func TestStopConditions(t *testing.T) {
+	tt := newTestTangle(t)
+
+	// g and a are referenced by milestone 5, b by milestone 6 and the milestone ms references e
+	for msgID, index := range map[iotago.MessageID]uint32{tt.g: 5, tt.a: 5, tt.b: 6} {
+		msg, err := tt.storage.Message(context.Background(), msgID)
+		require.NoError(t, err)
+		referencedBy := index
+		_, err = tt.storage.Add(msg, &iotago.MessageMetadataResponse{ReferencedByMilestoneIndex: &referencedBy})
+		require.NoError(t, err)
+	}
+	ms, _ := tpkg.RandMilestone(iotago.MessageIDs{tt.e})
+	msID, err := tt.storage.Add(&iotago.Message{Parents: iotago.MessageIDs{tt.e}, Payload: ms}, nil)
+	require.NoError(t, err)
+	tt.names[msID] = "ms"
+
+	referencedBy5 := traversal.WithStopCondition(traversal.StopAtReferencedByMilestone(5))
+	assert.ElementsMatch(t, []string{"ms", "e", "d", "c", "b"}, tt.collect(t, traversal.NewWalker(tt.storage, referencedBy5).PastCone, msID))
+
+	referencedBy6 := traversal.WithStopCondition(traversal.AnyOf(traversal.StopAtMilestones(), traversal.StopAtReferencedByMilestone(6)))
+	assert.Empty(t, tt.collect(t, traversal.NewWalker(tt.storage, referencedBy6).PastCone, msID))
+	assert.ElementsMatch(t, []string{"e", "d", "c"}, tt.collect(t, traversal.NewWalker(tt.storage, referencedBy6).PastCone, tt.e))
+}
@@ -0,0 +1,251 @@
+package iotago
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrTreasuryAuditFailed gets returned when an audit of the treasury found discrepancies.
+	ErrTreasuryAuditFailed = errors.New("treasury audit failed")
+)
+
+// TreasuryDiscrepancyKind defines the kind of a TreasuryDiscrepancy.
+type TreasuryDiscrepancyKind string
+
+const (
+	// TreasuryDiscrepancyInvalidReceipt denotes a receipt which is invalid as described by ValidateReceipt.
+	TreasuryDiscrepancyInvalidReceipt TreasuryDiscrepancyKind = "invalid-receipt"
+	// TreasuryDiscrepancyDuplicateTailTransactionHash denotes a legacy tail transaction hash migrated by multiple receipts.
+	TreasuryDiscrepancyDuplicateTailTransactionHash TreasuryDiscrepancyKind = "duplicate-tail-transaction-hash"
+	// TreasuryDiscrepancyReceiptOrder denotes a receipt violating the order of migrated at indices and final receipts.
+	TreasuryDiscrepancyReceiptOrder TreasuryDiscrepancyKind = "receipt-order"
+	// TreasuryDiscrepancyTreasuryLink denotes a TreasuryTransaction not consuming the TreasuryOutput of the previous milestone.
+	TreasuryDiscrepancyTreasuryLink TreasuryDiscrepancyKind = "treasury-link"
+	// TreasuryDiscrepancyTreasuryMismatch denotes that the treasury reported by the node does not match the audited one.
+	TreasuryDiscrepancyTreasuryMismatch TreasuryDiscrepancyKind = "treasury-mismatch"
+)
+
+// TreasuryDiscrepancy is a finding of a treasury audit.
+type TreasuryDiscrepancy struct {
+	// The kind of the discrepancy.
+	Kind TreasuryDiscrepancyKind `json:"kind"`
+	// The index of the milestone containing the receipt, 0 if not related to a receipt.
+	MilestoneIndex uint32 `json:"milestoneIndex,omitempty"`
+	// The human readable description of the discrepancy.
+	Message string `json:"message"`
+	// The underlying error.
+	Err error `json:"-"`
+}
+
+// TreasuryAuditStep is the state of the treasury after applying one receipt.
+type TreasuryAuditStep struct {
+	// The index of the milestone containing the receipt.
+	MilestoneIndex uint32 `json:"milestoneIndex"`
+	// The hex encoded ID of the milestone containing the receipt, empty if links are not verified.
+	MilestoneID string `json:"milestoneId,omitempty"`
+	// The milestone index at which the funds were migrated in the legacy network.
+	MigratedAt uint32 `json:"migratedAt"`
+	// Whether the receipt is the final one for its migrated at index.
+	Final bool `json:"final"`
+	// The amount of migrated funds entries.
+	Entries int `json:"entries"`
+	// The sum of the migrated funds.
+	Migrated uint64 `json:"migrated"`
+	// The treasury before applying the receipt.
+	TreasuryBefore uint64 `json:"treasuryBefore"`
+	// The treasury after applying the receipt.
+	TreasuryAfter uint64 `json:"treasuryAfter"`
+}
+
+// TreasuryAuditReport is the result of a treasury audit. The treasury of every step can be reconciled
+// as TreasuryBefore - Migrated = TreasuryAfter, the audited treasury as GenesisTreasury - Migrated.
+type TreasuryAuditReport struct {
+	// The treasury at genesis.
+	GenesisTreasury uint64 `json:"genesisTreasury"`
+	// The treasury after applying all receipts.
+	AuditedTreasury uint64 `json:"auditedTreasury"`
+	// The treasury reported by the node.
+	NodeTreasury uint64 `json:"nodeTreasury"`
+	// The hex encoded ID of the milestone which generated the treasury reported by the node.
+	NodeTreasuryMilestoneID string `json:"nodeTreasuryMilestoneId"`
+	// The sum of all migrated funds.
+	Migrated uint64 `json:"migrated"`
+	// The amount of migrated funds entries.
+	Entries int `json:"entries"`
+	// The applied receipts in milestone order.
+	Steps []*TreasuryAuditStep `json:"steps"`
+	// The totals per legacy milestone index.
+	MigratedAt []*MigratedAtTotal `json:"migratedAt"`
+	// The found discrepancies.
+	Discrepancies []*TreasuryDiscrepancy `json:"discrepancies"`
+}
+
+// Valid tells whether the audit found no discrepancies.
+func (r *TreasuryAuditReport) Valid() bool {
+	return len(r.Discrepancies) == 0
+}
+
+// Err returns an error wrapping ErrTreasuryAuditFailed and describing the first discrepancy, or nil if there are none.
+func (r *TreasuryAuditReport) Err() error {
+	if r.Valid() {
+		return nil
+	}
+	return fmt.Errorf("%w: %d discrepancies, first (%s): %s", ErrTreasuryAuditFailed, len(r.Discrepancies), r.Discrepancies[0].Kind, r.Discrepancies[0].Message)
+}
+
+func (r *TreasuryAuditReport) addDiscrepancy(kind TreasuryDiscrepancyKind, msIndex uint32, err error) {
+	r.Discrepancies = append(r.Discrepancies, &TreasuryDiscrepancy{Kind: kind, MilestoneIndex: msIndex, Message: err.Error(), Err: err})
+}
+
+var defaultTreasuryAuditorOptions = []TreasuryAuditorOption{
+	WithTreasuryAuditorVerifyLinks(true),
+}
+
+// TreasuryAuditorOptions define options for the TreasuryAuditor.
+type TreasuryAuditorOptions struct {
+	// Whether to verify the inputs of the TreasuryTransactions against the IDs of the milestones.
+	verifyLinks bool
+}
+
+// applies the given TreasuryAuditorOption.
+func (ao *TreasuryAuditorOptions) apply(opts ...TreasuryAuditorOption) {
+	for _, opt := range opts {
+		opt(ao)
+	}
+}
+
+// WithTreasuryAuditorVerifyLinks defines whether the input of every TreasuryTransaction is verified to reference
+// the milestone containing the previous receipt. This requires to query the milestone of every receipt.
+func WithTreasuryAuditorVerifyLinks(verify bool) TreasuryAuditorOption {
+	return func(opts *TreasuryAuditorOptions) {
+		opts.verifyLinks = verify
+	}
+}
+
+// TreasuryAuditorOption is a function setting a TreasuryAuditor option.
+type TreasuryAuditorOption func(opts *TreasuryAuditorOptions)
+
+// NewTreasuryAuditor creates a new TreasuryAuditor auditing the receipts of the given node.
+func NewTreasuryAuditor(nodeHTTPAPIClient *NodeHTTPAPIClient, opts ...TreasuryAuditorOption) *TreasuryAuditor {
+	options := &TreasuryAuditorOptions{}
+	options.apply(defaultTreasuryAuditorOptions...)
+	options.apply(opts...)
+	return &TreasuryAuditor{nodeAPI: nodeHTTPAPIClient, opts: options}
+}
+
+// TreasuryAuditor walks all receipts of a node in milestone order and validates them step by step
+// starting from the genesis treasury.
+type TreasuryAuditor struct {
+	nodeAPI *NodeHTTPAPIClient
+	opts    *TreasuryAuditorOptions
+}
+
+// Audit audits the receipts starting with the given genesis treasury generated by the given milestone ID,
+// which is the zero ID for the treasury of the genesis snapshot. Discrepancies are collected in the report instead
+// of aborting the audit, an error is only returned if the node can't be queried.
+func (a *TreasuryAuditor) Audit(ctx context.Context, genesisMilestoneID MilestoneID, genesisTreasury *TreasuryOutput) (*TreasuryAuditReport, error) {
+	receipts, err := a.nodeAPI.Receipts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query receipts: %w", err)
+	}
+	sort.SliceStable(receipts, func(i, j int) bool {
+		return receipts[i].MilestoneIndex < receipts[j].MilestoneIndex
+	})
+
+	report := &TreasuryAuditReport{GenesisTreasury: genesisTreasury.Amount, AuditedTreasury: genesisTreasury.Amount}
+	prevMilestoneID := genesisMilestoneID
+	chain := newReceiptChain("the receipt of milestone %d")
+
+	for _, tuple := range receipts {
+		receipt, msIndex := tuple.Receipt, tuple.MilestoneIndex
+		treasuryTx := receipt.Treasury()
+		if treasuryTx == nil {
+			report.addDiscrepancy(TreasuryDiscrepancyInvalidReceipt, msIndex, ErrReceiptMustContainATreasuryTransaction)
+			continue
+		}
+
+		step := &TreasuryAuditStep{
+			MilestoneIndex: msIndex,
+			MigratedAt:     receipt.MigratedAt,
+			Final:          receipt.Final,
+			Entries:        len(receipt.Funds),
+			Migrated:       receipt.Sum(),
+			TreasuryBefore: report.AuditedTreasury,
+			TreasuryAfter:  treasuryTx.Output.(*TreasuryOutput).Amount,
+		}
+		report.Steps = append(report.Steps, step)
+
+		if len(receipt.Funds) == 0 {
+			report.addDiscrepancy(TreasuryDiscrepancyInvalidReceipt, msIndex, fmt.Errorf("%w: receipt has no migrated funds", ErrInvalidReceipt))
+		} else if err := ValidateReceipt(receipt, &TreasuryOutput{Amount: report.AuditedTreasury}); err != nil {
+			report.addDiscrepancy(TreasuryDiscrepancyInvalidReceipt, msIndex, err)
+		}
+
+		duplicateErrs, orderErr := chain.add(receipt, msIndex)
+		for _, err := range duplicateErrs {
+			report.addDiscrepancy(TreasuryDiscrepancyDuplicateTailTransactionHash, msIndex, err)
+		}
+		if orderErr != nil {
+			report.addDiscrepancy(TreasuryDiscrepancyReceiptOrder, msIndex, orderErr)
+		}
+
+		if a.opts.verifyLinks {
+			milestoneID, err := a.milestoneID(ctx, msIndex)
+			if err != nil {
+				return nil, err
+			}
+			step.MilestoneID = hex.EncodeToString(milestoneID[:])
+
+			if treasuryInput, ok := treasuryTx.Input.(*TreasuryInput); !ok || *treasuryInput != TreasuryInput(prevMilestoneID) {
+				report.addDiscrepancy(TreasuryDiscrepancyTreasuryLink, msIndex, fmt.Errorf("%w: treasury input does not reference the previous treasury milestone %s", ErrInvalidReceiptChain, hex.EncodeToString(prevMilestoneID[:])))
+			}
+			prevMilestoneID = *milestoneID
+		}
+
+		report.Entries += step.Entries
+		report.Migrated += step.Migrated
+		report.AuditedTreasury = step.TreasuryAfter
+	}
+	report.MigratedAt = chain.migratedAt
+
+	treasury, err := a.nodeAPI.Treasury(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query treasury: %w", err)
+	}
+	report.NodeTreasury = treasury.Amount
+	report.NodeTreasuryMilestoneID = treasury.MilestoneID
+
+	if report.NodeTreasury != report.AuditedTreasury {
+		report.addDiscrepancy(TreasuryDiscrepancyTreasuryMismatch, 0, fmt.Errorf("%w: node treasury %d, audited %d", ErrTreasuryAuditFailed, report.NodeTreasury, report.AuditedTreasury))
+	}
+	if a.opts.verifyLinks && treasury.MilestoneID != hex.EncodeToString(prevMilestoneID[:]) {
+		report.addDiscrepancy(TreasuryDiscrepancyTreasuryMismatch, 0, fmt.Errorf("%w: node treasury generated by milestone %s, audited %s", ErrTreasuryAuditFailed, treasury.MilestoneID, hex.EncodeToString(prevMilestoneID[:])))
+	}
+
+	return report, nil
+}
+
+// queries the ID of the milestone with the given index.
+func (a *TreasuryAuditor) milestoneID(ctx context.Context, msIndex uint32) (*MilestoneID, error) {
+	msRes, err := a.nodeAPI.MilestoneByIndex(ctx, msIndex)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query milestone %d: %w", msIndex, err)
+	}
+	msgID, err := MessageIDFromHexString(msRes.MessageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message ID of milestone %d: %w", msIndex, err)
+	}
+	msg, err := a.nodeAPI.MessageByMessageID(ctx, msgID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query message of milestone %d: %w", msIndex, err)
+	}
+	ms, ok := msg.Payload.(*Milestone)
+	if !ok {
+		return nil, fmt.Errorf("%w: message of milestone %d contains %T", ErrUnknownPayloadType, msIndex, msg.Payload)
+	}
+	return ms.ID()
+}
@@ -0,0 +1,170 @@
+package iotago_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+// mocks the milestone with the given index containing the given receipt and returns its ID.
+func mockReceiptMilestone(t *testing.T, index uint32, receipt *iotago.Receipt) iotago.MilestoneID {
+	ms, _ := tpkg.RandMilestone(nil)
+	ms.Index = index
+	ms.Receipt = receipt
+	msID, err := ms.ID()
+	require.NoError(t, err)
+
+	msg := &iotago.Message{Parents: tpkg.SortedRand32BytArray(1), Payload: ms}
+	msgData, err := msg.Serialize(serializer.DeSeriModeNoValidation)
+	require.NoError(t, err)
+	msgID, err := msg.ID()
+	require.NoError(t, err)
+
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMilestone, strconv.FormatUint(uint64(index), 10))).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.MilestoneResponse{Index: index, MessageID: hex.EncodeToString(msgID[:])}})
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMessageBytes, hex.EncodeToString(msgID[:]))).
+		Reply(200).
+		Body(bytes.NewReader(msgData))
+
+	return *msID
+}
+
+func mockReceiptsAndTreasury(receipts []*iotago.ReceiptTuple, treasury *iotago.TreasuryResponse) {
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteReceipts).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.ReceiptsResponse{Receipts: receipts}})
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteTreasury).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: treasury})
+}
+
+func TestTreasuryAuditor_Audit(t *testing.T) {
+	defer gock.Off()
+
+	addr, _ := tpkg.RandEd25519Address()
+	genesisMilestoneID := iotago.MilestoneID{}
+	genesisTreasury := &iotago.TreasuryOutput{Amount: 1_000_000_000}
+
+	receipts1000, err := iotago.BuildMigrationReceipts(1000, genesisMilestoneID, genesisTreasury, randMigratedFundsEntries(200, addr, 1_000_000))
+	require.NoError(t, err)
+	receipts1001, err := iotago.BuildMigrationReceipts(1001, genesisMilestoneID, receipts1000[1].Treasury().Output.(*iotago.TreasuryOutput), randMigratedFundsEntries(10, addr, 2_000_000))
+	require.NoError(t, err)
+
+	// returned out of order to ensure the auditor sorts them by milestone index
+	tuples := []*iotago.ReceiptTuple{
+		{MilestoneIndex: 30, Receipt: receipts1001[0]},
+		{MilestoneIndex: 10, Receipt: receipts1000[0]},
+		{MilestoneIndex: 20, Receipt: receipts1000[1]},
+	}
+
+	msID := mockReceiptMilestone(t, 10, receipts1000[0])
+	receipts1000[1].LinkTreasury(msID)
+	msID = mockReceiptMilestone(t, 20, receipts1000[1])
+	receipts1001[0].LinkTreasury(msID)
+	msID = mockReceiptMilestone(t, 30, receipts1001[0])
+	mockReceiptsAndTreasury(tuples, &iotago.TreasuryResponse{MilestoneID: hex.EncodeToString(msID[:]), Amount: 780_000_000})
+
+	report, err := iotago.NewTreasuryAuditor(iotago.NewNodeHTTPAPIClient(nodeAPIUrl)).Audit(context.Background(), genesisMilestoneID, genesisTreasury)
+	require.NoError(t, err)
+	assert.True(t, report.Valid(), report.Err())
+	assert.NoError(t, report.Err())
+	assert.EqualValues(t, 1_000_000_000, report.GenesisTreasury)
+	assert.EqualValues(t, 780_000_000, report.AuditedTreasury)
+	assert.EqualValues(t, 780_000_000, report.NodeTreasury)
+	assert.EqualValues(t, 220_000_000, report.Migrated)
+	assert.Equal(t, 210, report.Entries)
+	assert.Equal(t, []*iotago.MigratedAtTotal{
+		{MigratedAt: 1000, Receipts: 2, Entries: 200, Amount: 200_000_000, Final: true},
+		{MigratedAt: 1001, Receipts: 1, Entries: 10, Amount: 20_000_000, Final: true},
+	}, report.MigratedAt)
+
+	require.Len(t, report.Steps, 3)
+	for i, step := range report.Steps {
+		assert.EqualValues(t, (i+1)*10, step.MilestoneIndex)
+		assert.Equal(t, step.TreasuryBefore-step.Migrated, step.TreasuryAfter)
+	}
+	assert.Equal(t, hex.EncodeToString(msID[:]), report.Steps[2].MilestoneID)
+	assert.True(t, gock.IsDone())
+}
+
+func TestTreasuryAuditor_AuditDiscrepancies(t *testing.T) {
+	defer gock.Off()
+
+	addr, _ := tpkg.RandEd25519Address()
+	genesisMilestoneID := iotago.MilestoneID{}
+	genesisTreasury := &iotago.TreasuryOutput{Amount: 100_000_000}
+
+	entries := randMigratedFundsEntries(2, addr, 1_000_000)
+	first, err := iotago.BuildMigrationReceipts(1000, genesisMilestoneID, genesisTreasury, entries[:1])
+	require.NoError(t, err)
+	first[0].Final = false
+	// migrates the same tail transaction hash again and does not reference the milestone of the first receipt
+	second, err := iotago.BuildMigrationReceipts(1000, genesisMilestoneID, first[0].Treasury().Output.(*iotago.TreasuryOutput), entries)
+	require.NoError(t, err)
+	// does not consume the output of the second receipt
+	third, err := iotago.BuildMigrationReceipts(1001, genesisMilestoneID, genesisTreasury, randMigratedFundsEntries(1, addr, 1_000_000))
+	require.NoError(t, err)
+
+	mockReceiptMilestone(t, 10, first[0])
+	mockReceiptMilestone(t, 20, second[0])
+	mockReceiptMilestone(t, 30, third[0])
+	mockReceiptsAndTreasury([]*iotago.ReceiptTuple{
+		{MilestoneIndex: 10, Receipt: first[0]},
+		{MilestoneIndex: 20, Receipt: second[0]},
+		{MilestoneIndex: 30, Receipt: third[0]},
+	}, &iotago.TreasuryResponse{MilestoneID: hex.EncodeToString(genesisMilestoneID[:]), Amount: 100_000_000})
+
+	report, err := iotago.NewTreasuryAuditor(iotago.NewNodeHTTPAPIClient(nodeAPIUrl)).Audit(context.Background(), genesisMilestoneID, genesisTreasury)
+	require.NoError(t, err)
+	assert.False(t, report.Valid())
+	assert.True(t, errors.Is(report.Err(), iotago.ErrTreasuryAuditFailed))
+
+	kinds := make(map[iotago.TreasuryDiscrepancyKind][]uint32)
+	for _, discrepancy := range report.Discrepancies {
+		kinds[discrepancy.Kind] = append(kinds[discrepancy.Kind], discrepancy.MilestoneIndex)
+	}
+	assert.Equal(t, map[iotago.TreasuryDiscrepancyKind][]uint32{
+		iotago.TreasuryDiscrepancyDuplicateTailTransactionHash: {20},
+		iotago.TreasuryDiscrepancyTreasuryLink:                 {20, 30},
+		iotago.TreasuryDiscrepancyInvalidReceipt:               {30},
+		iotago.TreasuryDiscrepancyTreasuryMismatch:             {0, 0},
+	}, kinds)
+	assert.EqualValues(t, 99_000_000, report.AuditedTreasury)
+}
+
+func TestTreasuryAuditor_WithoutLinks(t *testing.T) {
+	defer gock.Off()
+
+	addr, _ := tpkg.RandEd25519Address()
+	genesisTreasury := &iotago.TreasuryOutput{Amount: 100_000_000}
+	receipts, err := iotago.BuildMigrationReceipts(1000, iotago.MilestoneID{}, genesisTreasury, randMigratedFundsEntries(1, addr, 1_000_000))
+	require.NoError(t, err)
+
+	// milestones are not queried if links are not verified
+	mockReceiptsAndTreasury([]*iotago.ReceiptTuple{{MilestoneIndex: 10, Receipt: receipts[0]}}, &iotago.TreasuryResponse{Amount: 98_000_000})
+
+	report, err := iotago.NewTreasuryAuditor(iotago.NewNodeHTTPAPIClient(nodeAPIUrl), iotago.WithTreasuryAuditorVerifyLinks(false)).
+		Audit(context.Background(), iotago.MilestoneID{}, genesisTreasury)
+	require.NoError(t, err)
+	require.Len(t, report.Discrepancies, 1)
+	assert.Equal(t, iotago.TreasuryDiscrepancyTreasuryMismatch, report.Discrepancies[0].Kind)
+	assert.Empty(t, report.Steps[0].MilestoneID)
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,232 @@
+package units
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// TokenSupply is the total supply of IOTA tokens in iotas, equal to iotago.TokenSupply.
+const TokenSupply Amount = 2_779_530_283_277_761
+
+var (
+	// ErrInvalidAmount gets returned when a string is not a valid amount.
+	ErrInvalidAmount = errors.New("invalid amount")
+	// ErrUnknownUnit gets returned when an amount uses an unknown unit.
+	ErrUnknownUnit = errors.New("unknown unit")
+	// ErrSubIotaPrecision gets returned when an amount is more precise than a single iota.
+	ErrSubIotaPrecision = errors.New("amount is more precise than a single iota")
+	// ErrAmountOverflow gets returned when an amount does not fit into an uint64.
+	ErrAmountOverflow = errors.New("amount overflows uint64")
+	// ErrAmountExceedsSupply gets returned when an amount exceeds the TokenSupply.
+	ErrAmountExceedsSupply = errors.New("amount exceeds the token supply")
+)
+
+// the known units with their suffixes, from the biggest to the smallest.
+var unitSuffixes = []struct {
+	unit     Unit
+	suffix   string
+	decimals int
+}{
+	{Pi, "Pi", 15}, {Ti, "Ti", 12}, {Gi, "Gi", 9}, {Mi, "Mi", 6}, {Ki, "Ki", 3}, {I, "i", 0},
+}
+
+// Amount is an exact amount of iotas.
+// Unlike ConvertUnits, it never involves floating point arithmetic.
+type Amount uint64
+
+// ParseAmount parses an amount consisting of a decimal number and an optional unit suffix,
+// e.g. "1.5 Mi", "0.000001 Gi", "2,779,530 Ti" or "42". Amounts without a unit are in I.
+// Commas may be used as thousands separators in the integer part. The unit suffix is case-insensitive.
+// Amounts which are more precise than a single iota are rejected with ErrSubIotaPrecision.
+// ParseAmount does not check the amount against the TokenSupply, use Amount.CheckSupply for that.
+func ParseAmount(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	numEnd := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != ',' && r != '.'
+	})
+	if numEnd == -1 {
+		numEnd = len(s)
+	}
+	number, suffix := s[:numEnd], strings.TrimSpace(s[numEnd:])
+	if number == "" {
+		return 0, fmt.Errorf("%w: %q does not start with a number", ErrInvalidAmount, s)
+	}
+
+	unit := unitSuffixes[len(unitSuffixes)-1]
+	if suffix != "" {
+		found := false
+		for _, u := range unitSuffixes {
+			if strings.EqualFold(u.suffix, suffix) {
+				unit, found = u, true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("%w: %q in amount %q", ErrUnknownUnit, suffix, s)
+		}
+	}
+
+	intPart, fracPart := number, ""
+	if dot := strings.IndexByte(number, '.'); dot != -1 {
+		intPart, fracPart = number[:dot], number[dot+1:]
+		if fracPart == "" || strings.ContainsAny(fracPart, ".,") {
+			return 0, fmt.Errorf("%w: malformed fraction in %q", ErrInvalidAmount, s)
+		}
+	}
+	intDigits, err := stripThousandsSeparators(intPart)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", err, s)
+	}
+
+	// the fractional digits beyond the unit's decimals must all be zero
+	if len(fracPart) > unit.decimals {
+		if strings.TrimRight(fracPart[unit.decimals:], "0") != "" {
+			return 0, fmt.Errorf("%w: %q", ErrSubIotaPrecision, s)
+		}
+		fracPart = fracPart[:unit.decimals]
+	}
+	fracPart += strings.Repeat("0", unit.decimals-len(fracPart))
+
+	intValue, err := strconv.ParseUint(intDigits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrAmountOverflow, s)
+	}
+	var fracValue uint64
+	if fracPart != "" {
+		// at most 15 digits, therefore always fits
+		fracValue, _ = strconv.ParseUint(fracPart, 10, 64)
+	}
+
+	hi, value := bits.Mul64(intValue, uint64(unit.unit))
+	value, carry := bits.Add64(value, fracValue, 0)
+	if hi != 0 || carry != 0 {
+		return 0, fmt.Errorf("%w: %q", ErrAmountOverflow, s)
+	}
+	return Amount(value), nil
+}
+
+// MustParseAmount parses the given amount and panics if it is invalid.
+func MustParseAmount(s string) Amount {
+	amount, err := ParseAmount(s)
+	if err != nil {
+		panic(err)
+	}
+	return amount
+}
+
+// removes the thousands separators from the given integer part and checks that it only consists of digits.
+func stripThousandsSeparators(intPart string) (string, error) {
+	groups := strings.Split(intPart, ",")
+	for i, group := range groups {
+		switch {
+		case group == "":
+			return "", fmt.Errorf("%w: missing digits", ErrInvalidAmount)
+		case i > 0 && len(group) != 3, len(groups) > 1 && len(groups[0]) > 3:
+			return "", fmt.Errorf("%w: misplaced thousands separator", ErrInvalidAmount)
+		}
+	}
+	return strings.Join(groups, ""), nil
+}
+
+// Uint64 returns the amount in iotas.
+func (a Amount) Uint64() uint64 {
+	return uint64(a)
+}
+
+// CheckSupply returns ErrAmountExceedsSupply if the amount exceeds the TokenSupply.
+func (a Amount) CheckSupply() error {
+	if a > TokenSupply {
+		return fmt.Errorf("%w: %s", ErrAmountExceedsSupply, a)
+	}
+	return nil
+}
+
+// Add returns the sum of both amounts or ErrAmountExceedsSupply if it exceeds the TokenSupply.
+func (a Amount) Add(b Amount) (Amount, error) {
+	sum, carry := bits.Add64(uint64(a), uint64(b), 0)
+	if carry != 0 {
+		return 0, fmt.Errorf("%w: %s + %s", ErrAmountExceedsSupply, a, b)
+	}
+	if err := Amount(sum).CheckSupply(); err != nil {
+		return 0, err
+	}
+	return Amount(sum), nil
+}
+
+// BestFitUnit returns the biggest unit in which the amount is at least one, I for zero.
+func (a Amount) BestFitUnit() Unit {
+	for _, u := range unitSuffixes {
+		if uint64(a) >= uint64(u.unit) {
+			return u.unit
+		}
+	}
+	return I
+}
+
+// Format renders the amount exactly in the given unit without trailing zeros, e.g. "1.5 Mi".
+// Units other than the predefined ones are rendered in I.
+func (a Amount) Format(unit Unit) string {
+	u := unitSuffixes[len(unitSuffixes)-1]
+	for _, known := range unitSuffixes {
+		if known.unit == unit {
+			u = known
+			break
+		}
+	}
+	div := uint64(u.unit)
+	value := strconv.FormatUint(uint64(a)/div, 10)
+	if rest := uint64(a) % div; rest != 0 {
+		frac := strconv.FormatUint(rest, 10)
+		frac = strings.Repeat("0", u.decimals-len(frac)) + frac
+		value += "." + strings.TrimRight(frac, "0")
+	}
+	return value + " " + u.suffix
+}
+
+// String renders the amount in its best fitting unit, e.g. "1.5 Mi".
+func (a Amount) String() string {
+	return a.Format(a.BestFitUnit())
+}
+
+// MarshalText renders the amount in its best fitting unit.
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText parses the amount as described by ParseAmount.
+func (a *Amount) UnmarshalText(text []byte) error {
+	amount, err := ParseAmount(string(text))
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+// MarshalJSON renders the amount as a number of iotas, as used by the node API.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(a), 10)), nil
+}
+
+// UnmarshalJSON parses the amount either from a number of iotas or from a string as described by ParseAmount.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	} else {
+		s = string(data)
+		if strings.ContainsAny(s, ", \t\n\r") {
+			return fmt.Errorf("%w: %s", ErrInvalidAmount, s)
+		}
+	}
+	return a.UnmarshalText([]byte(s))
+}
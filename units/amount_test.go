@@ -0,0 +1,123 @@
+package units_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/units"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		expected units.Amount
+		err      error
+	}{
+		{name: "ok - Mi with fraction", in: "1.5 Mi", expected: 1_500_000},
+		{name: "ok - single iota in Gi", in: "0.000001 Gi", expected: 1000},
+		{name: "ok - thousands separators", in: "2,779,530 Ti", expected: 2_779_530_000_000_000_000},
+		{name: "ok - token supply", in: "2779.530283277761 Ti", expected: units.TokenSupply},
+		{name: "ok - without unit", in: "42", expected: 42},
+		{name: "ok - without space", in: "7Ki", expected: 7000},
+		{name: "ok - case-insensitive unit", in: "1 mi", expected: 1_000_000},
+		{name: "ok - trailing zeros beyond iota", in: "1.0000 i", expected: 1},
+		{name: "ok - fraction of Pi", in: "0.000000000000001 Pi", expected: 1},
+		{name: "err - sub iota", in: "1.5 i", err: units.ErrSubIotaPrecision},
+		{name: "err - sub iota in Ki", in: "0.0001 Ki", err: units.ErrSubIotaPrecision},
+		{name: "err - unknown unit", in: "1 Xi", err: units.ErrUnknownUnit},
+		{name: "err - empty", in: "", err: units.ErrInvalidAmount},
+		{name: "err - only unit", in: "Mi", err: units.ErrInvalidAmount},
+		{name: "err - negative", in: "-1 Mi", err: units.ErrInvalidAmount},
+		{name: "err - missing fraction", in: "1. Mi", err: units.ErrInvalidAmount},
+		{name: "err - two dots", in: "1.2.3 Mi", err: units.ErrInvalidAmount},
+		{name: "err - misplaced separator", in: "1,00 Mi", err: units.ErrInvalidAmount},
+		{name: "err - separator in fraction", in: "1.000,000 Mi", err: units.ErrInvalidAmount},
+		{name: "err - overflow", in: "18446744073709551616", err: units.ErrAmountOverflow},
+		{name: "err - overflow by unit", in: "18447 Pi", err: units.ErrAmountOverflow},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			amount, err := units.ParseAmount(test.in)
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err), "expected %v, got %v", test.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, amount)
+		})
+	}
+}
+
+func TestAmount_Format(t *testing.T) {
+	amount := units.MustParseAmount("1,234,567,890 i")
+	assert.Equal(t, "1234567890 i", amount.Format(units.I))
+	assert.Equal(t, "1234567.89 Ki", amount.Format(units.Ki))
+	assert.Equal(t, "1234.56789 Mi", amount.Format(units.Mi))
+	assert.Equal(t, "1.23456789 Gi", amount.Format(units.Gi))
+	assert.Equal(t, "0.00123456789 Ti", amount.Format(units.Ti))
+	assert.Equal(t, "1234567890 i", amount.Format(units.Unit(42)))
+
+	assert.Equal(t, "0 i", units.Amount(0).String())
+	assert.Equal(t, "999 i", units.Amount(999).String())
+	assert.Equal(t, "1.5 Mi", units.Amount(1_500_000).String())
+	assert.Equal(t, "2.779530283277761 Pi", units.TokenSupply.String())
+	assert.Equal(t, units.Pi, units.TokenSupply.BestFitUnit())
+
+	// formatting is lossless
+	for _, a := range []units.Amount{0, 1, 1000, 1_000_001, units.TokenSupply, units.TokenSupply - 1} {
+		parsed, err := units.ParseAmount(a.String())
+		require.NoError(t, err)
+		assert.Equal(t, a, parsed)
+	}
+}
+
+func TestAmount_CheckSupply(t *testing.T) {
+	assert.EqualValues(t, iotago.TokenSupply, units.TokenSupply)
+	assert.NoError(t, units.TokenSupply.CheckSupply())
+	assert.True(t, errors.Is((units.TokenSupply+1).CheckSupply(), units.ErrAmountExceedsSupply))
+
+	sum, err := units.Amount(1).Add(units.TokenSupply - 1)
+	require.NoError(t, err)
+	assert.Equal(t, units.TokenSupply, sum)
+
+	_, err = units.Amount(2).Add(units.TokenSupply - 1)
+	assert.True(t, errors.Is(err, units.ErrAmountExceedsSupply))
+	_, err = units.Amount(^uint64(0)).Add(1)
+	assert.True(t, errors.Is(err, units.ErrAmountExceedsSupply))
+}
+
+func TestAmount_Marshalling(t *testing.T) {
+	type account struct {
+		Balance units.Amount `json:"balance"`
+	}
+
+	jsonBytes, err := json.Marshal(&account{Balance: units.TokenSupply})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"balance":2779530283277761}`, string(jsonBytes))
+
+	acc := &account{}
+	require.NoError(t, json.Unmarshal(jsonBytes, acc))
+	assert.Equal(t, units.TokenSupply, acc.Balance)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"balance":"1.5 Mi"}`), acc))
+	assert.EqualValues(t, 1_500_000, acc.Balance)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"balance":1.5}`), acc))
+	assert.Error(t, json.Unmarshal([]byte(`{"balance":1e6}`), acc))
+	assert.Error(t, json.Unmarshal([]byte(`{"balance":-1}`), acc))
+
+	text, err := units.Amount(2_500_000_000).MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "2.5 Gi", string(text))
+
+	var amount units.Amount
+	require.NoError(t, amount.UnmarshalText(text))
+	assert.EqualValues(t, 2_500_000_000, amount)
+	assert.True(t, errors.Is(amount.UnmarshalText([]byte("0.5 i")), units.ErrSubIotaPrecision))
+}
@@ -25,6 +25,8 @@ const (
 )
 
 // ConvertUnits converts the given value in the base Unit to the given new Unit.
+//
+// Deprecated: float64 can not exactly represent all amounts up to the token supply, use Amount instead.
 func ConvertUnits(val float64, from Unit, to Unit) float64 {
 	value := Unit(val)
 	// convert to I unit by multiplying with the current unit
@@ -38,6 +40,8 @@ func ConvertUnits(val float64, from Unit, to Unit) float64 {
 }
 
 // ConvertUnitsString converts the given string value in the base Unit to the given new Unit.
+//
+// Deprecated: float64 can not exactly represent all amounts up to the token supply, use ParseAmount instead.
 func ConvertUnitsString(val string, from Unit, to Unit) (float64, error) {
 	floatValue, err := strconv.ParseFloat(val, 64)
 	if err != nil {
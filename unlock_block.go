@@ -170,7 +170,7 @@ func UnlockBlocksSigUniqueAndRefValidator() UnlockBlockValidatorFunc {
 		switch x := unlockBlock.(type) {
 		case *SignatureUnlockBlock:
 			if x.Signature == nil {
-				return fmt.Errorf("%w: at index %d is nil", ErrSigUnlockBlockHasNilSig, index)
+				return newViolation(RuleSigUnlockBlockSignature, index, nil, nil, fmt.Errorf("%w: at index %d is nil", ErrSigUnlockBlockHasNilSig, index))
 			}
 
 			sigBlockBytes, err := x.Serialize(serializer.DeSeriModeNoValidation)
@@ -179,7 +179,7 @@ func UnlockBlocksSigUniqueAndRefValidator() UnlockBlockValidatorFunc {
 			}
 
 			if existingIndex, exists := seenSigBlocksBytes[string(sigBlockBytes)]; exists {
-				return fmt.Errorf("%w: signature unlock block at index %d is the same as %d", ErrSigUnlockBlocksNotUnique, index, existingIndex)
+				return newViolation(RuleSigUnlockBlocksUnique, index, nil, existingIndex, fmt.Errorf("%w: signature unlock block at index %d is the same as %d", ErrSigUnlockBlocksNotUnique, index, existingIndex))
 			}
 			seenSigBlocksBytes[string(sigBlockBytes)] = index
 
@@ -187,15 +187,15 @@ func UnlockBlocksSigUniqueAndRefValidator() UnlockBlockValidatorFunc {
 			case *Ed25519Signature:
 				seenSigBlocks[index] = struct{}{}
 			default:
-				return fmt.Errorf("%w: signature unblock block at index %d holds unknown signature type %T", ErrUnknownSignatureType, index, x)
+				return newViolation(RuleSigUnlockBlockSignature, index, nil, fmt.Sprintf("%T", x.Signature), fmt.Errorf("%w: signature unblock block at index %d holds unknown signature type %T", ErrUnknownSignatureType, index, x))
 			}
 		case *ReferenceUnlockBlock:
 			reference := int(x.Reference)
 			if _, has := seenSigBlocks[reference]; !has {
-				return fmt.Errorf("%w: %d references non existent unlock block %d", ErrRefUnlockBlockInvalidRef, index, reference)
+				return newViolation(RuleRefUnlockBlockRef, index, nil, reference, fmt.Errorf("%w: %d references non existent unlock block %d", ErrRefUnlockBlockInvalidRef, index, reference))
 			}
 		default:
-			return fmt.Errorf("%w: unlock block at index %d is of unknown type %T", ErrUnknownUnlockBlockType, index, x)
+			return newViolation(RuleUnlockBlockType, index, nil, fmt.Sprintf("%T", x), fmt.Errorf("%w: unlock block at index %d is of unknown type %T", ErrUnknownUnlockBlockType, index, x))
 		}
 
 		return nil
@@ -204,15 +204,23 @@ func UnlockBlocksSigUniqueAndRefValidator() UnlockBlockValidatorFunc {
 
 // ValidateUnlockBlocks validates the unlock blocks by running them against the given UnlockBlockValidatorFunc.
 func ValidateUnlockBlocks(unlockBlocks serializer.Serializables, funcs ...UnlockBlockValidatorFunc) error {
+	return validateUnlockBlocks(unlockBlocks, nil, funcs...)
+}
+
+// validates the unlock blocks and adds the violations to the given collector.
+func validateUnlockBlocks(unlockBlocks serializer.Serializables, c *violationCollector, funcs ...UnlockBlockValidatorFunc) error {
 	for i, unlockBlock := range unlockBlocks {
 		switch unlockBlock.(type) {
 		case *SignatureUnlockBlock:
 		case *ReferenceUnlockBlock:
 		default:
-			return fmt.Errorf("%w: can only validate signature or reference unlock blocks", ErrUnknownInputType)
+			if err := c.add(newViolation(RuleUnlockBlockType, i, nil, fmt.Sprintf("%T", unlockBlock), fmt.Errorf("%w: can only validate signature or reference unlock blocks", ErrUnknownInputType))); err != nil {
+				return err
+			}
+			continue
 		}
 		for _, f := range funcs {
-			if err := f(i, unlockBlock); err != nil {
+			if err := c.add(f(i, unlockBlock)); err != nil {
 				return err
 			}
 		}
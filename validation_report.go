@@ -0,0 +1,294 @@
+package iotago
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidationRule identifies a syntactic or semantic validation rule of a Transaction.
+// Its numeric value is the rule's machine-readable code which never changes.
+type ValidationRule uint16
+
+const (
+	// RuleTransactionEssenceType requires the essence to be a TransactionEssence and the unlock blocks to be set.
+	RuleTransactionEssenceType ValidationRule = 100
+	// RuleInputsMinCount requires at least MinInputsCount inputs.
+	RuleInputsMinCount ValidationRule = 101
+	// RuleOutputsMinCount requires at least MinOutputsCount outputs.
+	RuleOutputsMinCount ValidationRule = 102
+	// RuleInputType requires every input to be an UTXOInput.
+	RuleInputType ValidationRule = 110
+	// RuleInputUTXORefIndexBounds requires the referenced output index to be within RefUTXOIndexMin and RefUTXOIndexMax.
+	RuleInputUTXORefIndexBounds ValidationRule = 111
+	// RuleInputUTXORefsUnique requires every input to reference a distinct UTXO.
+	RuleInputUTXORefsUnique ValidationRule = 112
+	// RuleOutputType requires every output to be a known Output.
+	RuleOutputType ValidationRule = 120
+	// RuleOutputAddrUnique requires the outputs of the same type to deposit to distinct addresses.
+	RuleOutputAddrUnique ValidationRule = 121
+	// RuleOutputDepositGreaterThanZero requires every output to deposit more than zero.
+	RuleOutputDepositGreaterThanZero ValidationRule = 122
+	// RuleOutputDustAllowanceMinDeposit requires a SigLockedDustAllowanceOutput to deposit at least OutputSigLockedDustAllowanceOutputMinDeposit.
+	RuleOutputDustAllowanceMinDeposit ValidationRule = 123
+	// RuleOutputDepositWithinSupply requires every output to deposit at most the TokenSupply.
+	RuleOutputDepositWithinSupply ValidationRule = 124
+	// RuleOutputsSumWithinSupply requires the sum of the output deposits to be at most the TokenSupply.
+	RuleOutputsSumWithinSupply ValidationRule = 125
+	// RuleUnlockBlocksCount requires an unlock block for every input.
+	RuleUnlockBlocksCount ValidationRule = 130
+	// RuleUnlockBlockType requires every unlock block to be a SignatureUnlockBlock or ReferenceUnlockBlock.
+	RuleUnlockBlockType ValidationRule = 131
+	// RuleSigUnlockBlockSignature requires every SignatureUnlockBlock to hold a signature of a known type.
+	RuleSigUnlockBlockSignature ValidationRule = 132
+	// RuleSigUnlockBlocksUnique requires every SignatureUnlockBlock to be unique.
+	RuleSigUnlockBlocksUnique ValidationRule = 133
+	// RuleRefUnlockBlockRef requires every ReferenceUnlockBlock to reference a previous SignatureUnlockBlock.
+	RuleRefUnlockBlockRef ValidationRule = 134
+	// RuleInputUTXOAvailable requires the UTXO referenced by every input to be available.
+	RuleInputUTXOAvailable ValidationRule = 200
+	// RuleInputOutputSum requires the outputs to deposit exactly the sum of the inputs.
+	RuleInputOutputSum ValidationRule = 201
+	// RuleInputSigUnlockBlock requires the inputs on the same address to be unlocked by the same SignatureUnlockBlock.
+	RuleInputSigUnlockBlock ValidationRule = 202
+	// RuleSignatureAddrType requires the signature type to be compatible with the address of the input.
+	RuleSignatureAddrType ValidationRule = 203
+	// RuleSignaturePubKeyAddr requires the public key of the signature to correspond to the address of the input.
+	RuleSignaturePubKeyAddr ValidationRule = 204
+	// RuleSignatureValid requires the signature to be valid.
+	RuleSignatureValid ValidationRule = 205
+	// RuleDustAllowance requires the dust outputs on an address to not exceed its dust allowance.
+	RuleDustAllowance ValidationRule = 206
+)
+
+var validationRuleIDs = map[ValidationRule]string{
+	RuleTransactionEssenceType:        "transaction-essence-type",
+	RuleInputsMinCount:                "inputs-min-count",
+	RuleOutputsMinCount:               "outputs-min-count",
+	RuleInputType:                     "input-type",
+	RuleInputUTXORefIndexBounds:       "input-utxo-ref-index-bounds",
+	RuleInputUTXORefsUnique:           "input-utxo-refs-unique",
+	RuleOutputType:                    "output-type",
+	RuleOutputAddrUnique:              "output-addr-unique",
+	RuleOutputDepositGreaterThanZero:  "output-deposit-greater-than-zero",
+	RuleOutputDustAllowanceMinDeposit: "output-dust-allowance-min-deposit",
+	RuleOutputDepositWithinSupply:     "output-deposit-within-supply",
+	RuleOutputsSumWithinSupply:        "outputs-sum-within-supply",
+	RuleUnlockBlocksCount:             "unlock-blocks-count",
+	RuleUnlockBlockType:               "unlock-block-type",
+	RuleSigUnlockBlockSignature:       "sig-unlock-block-signature",
+	RuleSigUnlockBlocksUnique:         "sig-unlock-blocks-unique",
+	RuleRefUnlockBlockRef:             "ref-unlock-block-ref",
+	RuleInputUTXOAvailable:            "input-utxo-available",
+	RuleInputOutputSum:                "input-output-sum",
+	RuleInputSigUnlockBlock:           "input-sig-unlock-block",
+	RuleSignatureAddrType:             "signature-addr-type",
+	RuleSignaturePubKeyAddr:           "signature-pub-key-addr",
+	RuleSignatureValid:                "signature-valid",
+	RuleDustAllowance:                 "dust-allowance",
+}
+
+// String returns the ID of the rule, e.g. "input-output-sum".
+func (r ValidationRule) String() string {
+	if id, has := validationRuleIDs[r]; has {
+		return id
+	}
+	return fmt.Sprintf("unknown-rule-%d", uint16(r))
+}
+
+// Code returns the machine-readable code of the rule.
+func (r ValidationRule) Code() uint16 {
+	return uint16(r)
+}
+
+// ValidationViolation is the error describing a violated ValidationRule.
+// It wraps the error the validation returns, i.e. errors.Is still works against the sentinel errors.
+type ValidationViolation struct {
+	// The violated rule.
+	Rule ValidationRule
+	// The index of the offending input, output or unlock block, -1 if the rule does not apply to a single one.
+	Index int
+	// The address the rule applies to, nil if it does not apply to an address.
+	Address Address
+	// The expected value or bound, nil if not applicable.
+	Expected interface{}
+	// The actual value, nil if not applicable.
+	Actual interface{}
+	// The underlying error.
+	Err error
+}
+
+// creates a ValidationViolation of the given rule wrapping err.
+func newViolation(rule ValidationRule, index int, expected interface{}, actual interface{}, err error) *ValidationViolation {
+	return &ValidationViolation{Rule: rule, Index: index, Expected: expected, Actual: actual, Err: err}
+}
+
+func (v *ValidationViolation) Error() string {
+	return v.Err.Error()
+}
+
+func (v *ValidationViolation) Unwrap() error {
+	return v.Err
+}
+
+// MarshalJSON returns the JSON representation of the violation for support tooling.
+func (v *ValidationViolation) MarshalJSON() ([]byte, error) {
+	jViolation := &jsonValidationViolation{
+		Rule:     v.Rule.String(),
+		Code:     v.Rule.Code(),
+		Expected: v.Expected,
+		Actual:   v.Actual,
+		Message:  v.Error(),
+	}
+	if v.Index != -1 {
+		jViolation.Index = &v.Index
+	}
+	if v.Address != nil {
+		jViolation.Address = v.Address.String()
+	}
+	return json.Marshal(jViolation)
+}
+
+// jsonValidationViolation defines the JSON representation of a ValidationViolation.
+type jsonValidationViolation struct {
+	Rule     string      `json:"rule"`
+	Code     uint16      `json:"code"`
+	Index    *int        `json:"index,omitempty"`
+	Address  string      `json:"address,omitempty"`
+	Expected interface{} `json:"expected,omitempty"`
+	Actual   interface{} `json:"actual,omitempty"`
+	Message  string      `json:"message"`
+}
+
+// ValidationReport lists every violated rule of a Transaction instead of only the first one.
+type ValidationReport struct {
+	// The violations in the order of the validation steps and indices.
+	Violations []*ValidationViolation `json:"violations"`
+}
+
+// Valid tells whether the report contains no violations.
+func (r *ValidationReport) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+// Has tells whether the report contains a violation of the given rule.
+func (r *ValidationReport) Has(rule ValidationRule) bool {
+	for _, v := range r.Violations {
+		if v.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns an error listing all violations or nil if there are none.
+// The returned error wraps the first violation.
+func (r *ValidationReport) Err() error {
+	if r.Valid() {
+		return nil
+	}
+	msgs := make([]string, len(r.Violations))
+	for i, v := range r.Violations {
+		msgs[i] = fmt.Sprintf("[%s] %s", v.Rule, v.Error())
+	}
+	return fmt.Errorf("%w (%d violations: %s)", r.Violations[0], len(r.Violations), strings.Join(msgs, "; "))
+}
+
+// collects the violations of validation rules, either stopping at the first one or gathering all of them.
+// A nil collector stops at the first error.
+type violationCollector struct {
+	violations []*ValidationViolation
+}
+
+// add collects err if it is a ValidationViolation and returns it otherwise or if the collector is nil,
+// meaning the validation must stop.
+func (c *violationCollector) add(err error) error {
+	var v *ValidationViolation
+	if c == nil || !errors.As(err, &v) {
+		return err
+	}
+	c.violations = append(c.violations, v)
+	return nil
+}
+
+// ValidationReportOptions define options for Transaction.ValidationReport.
+type ValidationReportOptions struct {
+	// The UTXOs referenced by the inputs, the semantic rules are only checked if set.
+	utxos InputToOutputMapping
+	// The dust allowance parameters, the dust allowance is only checked if set.
+	dustAllowanceFunc     DustAllowanceFunc
+	dustAllowanceDiv      int64
+	dustOutputsCountLimit int64
+}
+
+// applies the given ValidationReportOption.
+func (vo *ValidationReportOptions) apply(opts ...ValidationReportOption) {
+	for _, opt := range opts {
+		opt(vo)
+	}
+}
+
+// WithValidationReportUTXOs sets the UTXOs the inputs reference to additionally check the semantic rules.
+func WithValidationReportUTXOs(utxos InputToOutputMapping) ValidationReportOption {
+	return func(opts *ValidationReportOptions) {
+		opts.utxos = utxos
+	}
+}
+
+// WithValidationReportDustAllowance sets the parameters to additionally check the dust allowance,
+// see NewDustSemanticValidation.
+func WithValidationReportDustAllowance(div int64, dustOutputsCountLimit int64, dustAllowanceFunc DustAllowanceFunc) ValidationReportOption {
+	return func(opts *ValidationReportOptions) {
+		opts.dustAllowanceDiv = div
+		opts.dustOutputsCountLimit = dustOutputsCountLimit
+		opts.dustAllowanceFunc = dustAllowanceFunc
+	}
+}
+
+// ValidationReportOption is a function setting a ValidationReport option.
+type ValidationReportOption func(opts *ValidationReportOptions)
+
+// ValidationReport validates the Transaction like SyntacticallyValidate and, if the UTXOs are given,
+// like SemanticallyValidate, but reports every violated rule instead of stopping at the first one.
+// The semantic rules are skipped if the inputs, outputs or unlock blocks are malformed
+// and the dust allowance is skipped if UTXOs are missing.
+// The returned error is only non-nil if the validation itself fails, e.g. if the DustAllowanceFunc fails.
+func (t *Transaction) ValidationReport(opts ...ValidationReportOption) (*ValidationReport, error) {
+	options := &ValidationReportOptions{}
+	options.apply(opts...)
+
+	c := &violationCollector{}
+	report := &ValidationReport{}
+
+	txEssence, err := t.syntacticallyValidate(c)
+	if err != nil {
+		return nil, err
+	}
+	report.Violations = c.violations
+	if txEssence == nil || options.utxos == nil {
+		return report, nil
+	}
+
+	// the semantic rules rely on well-formed inputs, outputs and unlock blocks
+	for _, rule := range []ValidationRule{RuleInputType, RuleOutputType, RuleUnlockBlocksCount, RuleUnlockBlockType, RuleSigUnlockBlockSignature, RuleRefUnlockBlockRef} {
+		if report.Has(rule) {
+			return report, nil
+		}
+	}
+
+	if err := t.semanticallyValidate(options.utxos, c); err != nil {
+		return nil, err
+	}
+	report.Violations = c.violations
+
+	// the dust allowance can only be computed if all UTXOs are available
+	if options.dustAllowanceFunc != nil && !report.Has(RuleInputUTXOAvailable) {
+		if err := validateDustAllowance(t, options.utxos, options.dustAllowanceDiv, options.dustOutputsCountLimit, options.dustAllowanceFunc, c); err != nil {
+			return nil, err
+		}
+		report.Violations = c.violations
+	}
+
+	return report, nil
+}
@@ -0,0 +1,208 @@
+package iotago_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+)
+
+// returns the rules of the violations of the given report.
+func reportRules(report *iotago.ValidationReport) []iotago.ValidationRule {
+	rules := make([]iotago.ValidationRule, len(report.Violations))
+	for i, v := range report.Violations {
+		rules[i] = v.Rule
+	}
+	return rules
+}
+
+func TestTransaction_ValidationReport_Syntactic(t *testing.T) {
+	prvKey := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+	outputAddr, _ := tpkg.RandEd25519Address()
+
+	tx, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 1_000_000}).
+		Build(iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: prvKey}))
+	require.NoError(t, err)
+
+	// every output violates a rule and an unlock block is missing
+	tx.Essence.(*iotago.TransactionEssence).Outputs = append(tx.Essence.(*iotago.TransactionEssence).Outputs,
+		&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 10},
+		&iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 0},
+		&iotago.SigLockedDustAllowanceOutput{Address: outputAddr, Amount: 1000},
+	)
+	tx.Essence.(*iotago.TransactionEssence).Inputs = append(tx.Essence.(*iotago.TransactionEssence).Inputs,
+		&iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: iotago.RefUTXOIndexMax + 1},
+	)
+
+	report, err := tx.ValidationReport()
+	require.NoError(t, err)
+	assert.False(t, report.Valid())
+	assert.Equal(t, []iotago.ValidationRule{
+		iotago.RuleInputUTXORefIndexBounds,
+		iotago.RuleOutputAddrUnique,
+		iotago.RuleOutputDepositGreaterThanZero,
+		iotago.RuleOutputDustAllowanceMinDeposit,
+		iotago.RuleUnlockBlocksCount,
+	}, reportRules(report))
+
+	assert.Equal(t, 1, report.Violations[0].Index)
+	assert.EqualValues(t, iotago.RefUTXOIndexMax+1, report.Violations[0].Actual)
+	assert.Equal(t, 1, report.Violations[1].Index)
+	assert.Equal(t, outputAddr, report.Violations[1].Address)
+	assert.Equal(t, 2, report.Violations[2].Index)
+	assert.Equal(t, 3, report.Violations[3].Index)
+	assert.EqualValues(t, iotago.OutputSigLockedDustAllowanceOutputMinDeposit, report.Violations[3].Expected)
+	assert.EqualValues(t, 1000, report.Violations[3].Actual)
+	assert.Equal(t, -1, report.Violations[4].Index)
+	assert.Equal(t, 2, report.Violations[4].Expected)
+	assert.Equal(t, 1, report.Violations[4].Actual)
+
+	// the combined error wraps the first violation
+	reportErr := report.Err()
+	assert.True(t, errors.Is(reportErr, iotago.ErrRefUTXOIndexInvalid))
+	assert.Contains(t, reportErr.Error(), "5 violations")
+	assert.Contains(t, reportErr.Error(), "[output-dust-allowance-min-deposit]")
+
+	// the first error wins when validating without a report
+	syntacticErr := tx.SyntacticallyValidate()
+	var violation *iotago.ValidationViolation
+	require.True(t, errors.As(syntacticErr, &violation))
+	assert.Equal(t, iotago.RuleInputUTXORefIndexBounds, violation.Rule)
+}
+
+func TestTransaction_ValidationReport_Semantic(t *testing.T) {
+	prvKey1, prvKey2 := tpkg.RandEd25519PrivateKey(), tpkg.RandEd25519PrivateKey()
+	inputAddr1 := iotago.AddressFromEd25519PubKey(prvKey1.Public().(ed25519.PublicKey))
+	inputAddr2 := iotago.AddressFromEd25519PubKey(prvKey2.Public().(ed25519.PublicKey))
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}
+	inputUTXO2 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}
+
+	tx, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr1, Input: inputUTXO1}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr2, Input: inputUTXO2}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 3_000_000}).
+		Build(iotago.NewInMemoryAddressSigner(
+			iotago.AddressKeys{Address: &inputAddr1, Keys: prvKey1},
+			iotago.AddressKeys{Address: &inputAddr2, Keys: prvKey2},
+		))
+	require.NoError(t, err)
+	require.NoError(t, tx.SyntacticallyValidate())
+
+	// invalidate the signature of the second input
+	txEssence := tx.Essence.(*iotago.TransactionEssence)
+	invalidSigIndex := 0
+	if *txEssence.Inputs[1].(*iotago.UTXOInput) == *inputUTXO2 {
+		invalidSigIndex = 1
+	}
+	tx.UnlockBlocks[invalidSigIndex].(*iotago.SignatureUnlockBlock).Signature.(*iotago.Ed25519Signature).Signature[0] ^= 0xff
+
+	utxos := iotago.InputToOutputMapping{
+		inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr1, Amount: 1_000_000},
+		inputUTXO2.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr2, Amount: 1_000_000},
+	}
+
+	report, err := tx.ValidationReport(iotago.WithValidationReportUTXOs(utxos))
+	require.NoError(t, err)
+	assert.Equal(t, []iotago.ValidationRule{iotago.RuleInputOutputSum, iotago.RuleSignatureValid}, reportRules(report))
+	assert.EqualValues(t, 2_000_000, report.Violations[0].Expected)
+	assert.EqualValues(t, 3_000_000, report.Violations[0].Actual)
+	assert.Equal(t, invalidSigIndex, report.Violations[1].Index)
+	assert.Equal(t, &inputAddr2, report.Violations[1].Address)
+	assert.True(t, errors.Is(report.Violations[1], iotago.ErrEd25519SignatureInvalid))
+
+	// without the UTXOs, only the syntactic rules are checked
+	report, err = tx.ValidationReport()
+	require.NoError(t, err)
+	assert.True(t, report.Valid())
+	assert.NoError(t, report.Err())
+
+	// input violations besides missing UTXOs do not skip the input sum
+	sameAddrUTXOs := iotago.InputToOutputMapping{
+		inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr1, Amount: 1_000_000},
+		inputUTXO2.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr1, Amount: 1_000_000},
+	}
+	report, err = tx.ValidationReport(iotago.WithValidationReportUTXOs(sameAddrUTXOs))
+	require.NoError(t, err)
+	assert.Contains(t, reportRules(report), iotago.RuleInputSigUnlockBlock)
+	assert.Contains(t, reportRules(report), iotago.RuleInputOutputSum)
+
+	// a missing UTXO skips the input sum
+	delete(utxos, inputUTXO1.ID())
+	report, err = tx.ValidationReport(iotago.WithValidationReportUTXOs(utxos))
+	require.NoError(t, err)
+	assert.Equal(t, []iotago.ValidationRule{iotago.RuleInputUTXOAvailable, iotago.RuleSignatureValid}, reportRules(report))
+}
+
+func TestTransaction_ValidationReport_DustAllowance(t *testing.T) {
+	prvKey := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+	dustAddr1, _ := tpkg.RandEd25519Address()
+	dustAddr2, _ := tpkg.RandEd25519Address()
+	inputUTXO := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}
+
+	tx, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: dustAddr1, Amount: 1}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: dustAddr2, Amount: 1}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 1_999_998}).
+		Build(iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: prvKey}))
+	require.NoError(t, err)
+
+	utxos := iotago.InputToOutputMapping{
+		inputUTXO.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 2_000_000},
+	}
+	noAllowance := func(addr iotago.Address) (uint64, int64, error) {
+		return 0, 0, nil
+	}
+
+	report, err := tx.ValidationReport(
+		iotago.WithValidationReportUTXOs(utxos),
+		iotago.WithValidationReportDustAllowance(iotago.DustAllowanceDivisor, iotago.MaxDustOutputsOnAddress, noAllowance),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []iotago.ValidationRule{iotago.RuleDustAllowance, iotago.RuleDustAllowance}, reportRules(report))
+	reportedAddrs := []iotago.Address{report.Violations[0].Address, report.Violations[1].Address}
+	assert.ElementsMatch(t, []iotago.Address{dustAddr1, dustAddr2}, reportedAddrs)
+	for _, v := range report.Violations {
+		assert.EqualValues(t, 0, v.Expected)
+		assert.EqualValues(t, 1, v.Actual)
+	}
+
+	// the same rule fails the semantic validation
+	semanticErr := tx.SemanticallyValidate(utxos, iotago.NewDustSemanticValidation(iotago.DustAllowanceDivisor, iotago.MaxDustOutputsOnAddress, noAllowance))
+	assert.True(t, errors.Is(semanticErr, iotago.ErrInvalidDustAllowance))
+	assert.Equal(t, iotago.ConflictInvalidDustAllowance, iotago.ConflictReasonFromError(semanticErr))
+}
+
+func TestValidationViolation_MarshalJSON(t *testing.T) {
+	addr, _ := tpkg.RandEd25519Address()
+	violation := &iotago.ValidationViolation{
+		Rule:     iotago.RuleInputOutputSum,
+		Index:    -1,
+		Address:  addr,
+		Expected: uint64(10),
+		Actual:   uint64(20),
+		Err:      iotago.ErrInputOutputSumMismatch,
+	}
+
+	violationJSON, err := json.Marshal(violation)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"rule": "input-output-sum",
+		"code": 201,
+		"address": "`+addr.String()+`",
+		"expected": 10,
+		"actual": 20,
+		"message": "`+iotago.ErrInputOutputSumMismatch.Error()+`"
+	}`, string(violationJSON))
+}
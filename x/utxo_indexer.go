@@ -0,0 +1,450 @@
+package iotagox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/serializer"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+var (
+	// ErrUTXOIndexerNotBootstrapped gets returned when a UTXOIndexer applies milestones before it was bootstrapped.
+	ErrUTXOIndexerNotBootstrapped = errors.New("utxo indexer is not bootstrapped")
+	// ErrUTXOIndexerInconsistentLedgerIndex gets returned when the outputs of the watched addresses
+	// could not be queried at the same ledger index.
+	ErrUTXOIndexerInconsistentLedgerIndex = errors.New("inconsistent ledger index")
+	// ErrUTXOIndexerTooManyOutputs gets returned when the node does not return all outputs of a watched address
+	// because they exceed its max. results.
+	ErrUTXOIndexerTooManyOutputs = errors.New("too many outputs on watched address")
+)
+
+// BalanceChange describes the change of the balance of a watched address by the UTXO diff of a milestone.
+type BalanceChange struct {
+	// The watched address.
+	Address iotago.Address
+	// The index of the milestone which changed the balance.
+	MilestoneIndex uint32
+	// The balance before the milestone.
+	PreviousBalance uint64
+	// The balance after the milestone.
+	Balance uint64
+	// The IDs of the outputs the milestone created on the address.
+	Created iotago.UTXOInputIDs
+	// The IDs of the outputs of the address the milestone consumed.
+	Consumed iotago.UTXOInputIDs
+}
+
+// the default options applied to the UTXOIndexer.
+var defaultUTXOIndexerOptions = []UTXOIndexerOption{
+	WithUTXOIndexerBootstrapRetries(3),
+	WithUTXOIndexerBalanceChangesBuffer(100),
+}
+
+// UTXOIndexerOptions define options for the UTXOIndexer.
+type UTXOIndexerOptions struct {
+	// The ledger index at which the watched addresses are known to hold no outputs, nil to bootstrap from the node.
+	ledgerIndex *uint32
+	// The amount of retries to query the outputs of the watched addresses at a consistent ledger index.
+	bootstrapRetries int
+	// The size of the buffer of the balance changes channel.
+	balanceChangesBuffer int
+}
+
+// applies the given UTXOIndexerOption.
+func (uio *UTXOIndexerOptions) apply(opts ...UTXOIndexerOption) {
+	for _, opt := range opts {
+		opt(uio)
+	}
+}
+
+// WithUTXOIndexerLedgerIndex defines that the watched addresses hold no outputs at the given ledger index,
+// for example because they were just generated. The UTXOIndexer then needs no bootstrapping and applies
+// the milestones following the given index.
+func WithUTXOIndexerLedgerIndex(index uint32) UTXOIndexerOption {
+	return func(opts *UTXOIndexerOptions) {
+		opts.ledgerIndex = &index
+	}
+}
+
+// WithUTXOIndexerBootstrapRetries sets the amount of retries to query the outputs of the watched addresses
+// at a consistent ledger index while bootstrapping.
+func WithUTXOIndexerBootstrapRetries(retries int) UTXOIndexerOption {
+	return func(opts *UTXOIndexerOptions) {
+		opts.bootstrapRetries = retries
+	}
+}
+
+// WithUTXOIndexerBalanceChangesBuffer sets the size of the buffer of the channel returned by BalanceChanges.
+func WithUTXOIndexerBalanceChangesBuffer(size int) UTXOIndexerOption {
+	return func(opts *UTXOIndexerOptions) {
+		opts.balanceChangesBuffer = size
+	}
+}
+
+// UTXOIndexerOption is a function setting a UTXOIndexer option.
+type UTXOIndexerOption func(opts *UTXOIndexerOptions)
+
+// NewUTXOIndexer creates a new UTXOIndexer maintaining the balances of the given addresses.
+func NewUTXOIndexer(nodeHTTPAPIClient *iotago.NodeHTTPAPIClient, addrs []iotago.Address, opts ...UTXOIndexerOption) (*UTXOIndexer, error) {
+	options := &UTXOIndexerOptions{}
+	options.apply(defaultUTXOIndexerOptions...)
+	options.apply(opts...)
+
+	indexer := &UTXOIndexer{
+		nodeAPI:        nodeHTTPAPIClient,
+		opts:           options,
+		addrs:          make(map[string]*watchedAddress, len(addrs)),
+		outputs:        make(map[iotago.UTXOInputID]indexedOutput),
+		balanceChanges: make(chan *BalanceChange, options.balanceChangesBuffer),
+	}
+	for _, addr := range addrs {
+		key, err := addrKey(addr)
+		if err != nil {
+			return nil, err
+		}
+		indexer.addrs[key] = &watchedAddress{addr: addr}
+	}
+	if options.ledgerIndex != nil {
+		indexer.ledgerIndex = *options.ledgerIndex
+		indexer.bootstrapped = true
+	}
+	return indexer, nil
+}
+
+// returns the serialized form of the given address as a map key.
+func addrKey(addr serializer.Serializable) (string, error) {
+	addrData, err := addr.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return "", fmt.Errorf("unable to serialize address: %w", err)
+	}
+	return string(addrData), nil
+}
+
+type watchedAddress struct {
+	addr    iotago.Address
+	balance uint64
+}
+
+type indexedOutput struct {
+	addrKey string
+	deposit uint64
+}
+
+// UTXOIndexer maintains the balances of a set of watched addresses by applying the UTXO diffs of confirmed milestones.
+// Milestones are applied in order without gaps: when it is told about a confirmed milestone,
+// all milestones since the last applied one are fetched and applied first.
+type UTXOIndexer struct {
+	nodeAPI *iotago.NodeHTTPAPIClient
+	opts    *UTXOIndexerOptions
+
+	// syncMu serializes bootstrapping and the application of milestones.
+	syncMu sync.Mutex
+	// the keys of the addresses whose balance changes of the milestone following the ledger index were already sent,
+	// guarded by syncMu
+	sentChanges    map[string]struct{}
+	mu             sync.RWMutex
+	addrs          map[string]*watchedAddress
+	outputs        map[iotago.UTXOInputID]indexedOutput
+	ledgerIndex    uint32
+	bootstrapped   bool
+	balanceChanges chan *BalanceChange
+}
+
+// BalanceChanges returns the channel of balance changes of the watched addresses.
+// The UTXOIndexer blocks once the buffer of the channel is full, so the channel must be drained.
+// The changes of a milestone are sent before the milestone is applied: if the context is done while sending,
+// the milestone stays unapplied and only its changes which were not sent yet are sent when it is applied later.
+// Every change is therefore sent exactly once.
+func (idx *UTXOIndexer) BalanceChanges() <-chan *BalanceChange {
+	return idx.balanceChanges
+}
+
+// LedgerIndex returns the index of the last applied milestone.
+func (idx *UTXOIndexer) LedgerIndex() uint32 {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.ledgerIndex
+}
+
+// Balance returns the balance of the given watched address and the ledger index it was computed at.
+func (idx *UTXOIndexer) Balance(addr iotago.Address) (uint64, uint32, error) {
+	key, err := addrKey(addr)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	watched, has := idx.addrs[key]
+	if !has {
+		return 0, 0, fmt.Errorf("address %s is not watched", addr)
+	}
+	return watched.balance, idx.ledgerIndex, nil
+}
+
+// Bootstrap queries the unspent outputs of the watched addresses from the node.
+// The outputs of all addresses must be available at the same ledger index, otherwise the query is retried.
+// Bootstrapping fails if the node does not return all outputs of an address because they exceed its max. results.
+func (idx *UTXOIndexer) Bootstrap(ctx context.Context) error {
+	idx.syncMu.Lock()
+	defer idx.syncMu.Unlock()
+
+	for i := 0; i <= idx.opts.bootstrapRetries; i++ {
+		outputs, ledgerIndex, consistent, err := idx.queryUnspentOutputs(ctx)
+		if err != nil {
+			return err
+		}
+		if !consistent {
+			continue
+		}
+
+		idx.mu.Lock()
+		idx.outputs = outputs
+		for _, watched := range idx.addrs {
+			watched.balance = 0
+		}
+		for _, output := range outputs {
+			idx.addrs[output.addrKey].balance += output.deposit
+		}
+		idx.ledgerIndex = ledgerIndex
+		idx.bootstrapped = true
+		idx.sentChanges = nil
+		idx.mu.Unlock()
+		return nil
+	}
+	return fmt.Errorf("%w: outputs changed during %d queries", ErrUTXOIndexerInconsistentLedgerIndex, idx.opts.bootstrapRetries+1)
+}
+
+// queries the unspent outputs of all watched addresses and returns whether they are from the same ledger index.
+func (idx *UTXOIndexer) queryUnspentOutputs(ctx context.Context) (map[iotago.UTXOInputID]indexedOutput, uint32, bool, error) {
+	outputs := make(map[iotago.UTXOInputID]indexedOutput)
+	var ledgerIndex *uint64
+	for key, watched := range idx.addrs {
+		edAddr, ok := watched.addr.(*iotago.Ed25519Address)
+		if !ok {
+			return nil, 0, false, fmt.Errorf("%w: unable to query outputs of %T", iotago.ErrUnknownAddrType, watched.addr)
+		}
+		res, addrOutputs, err := idx.nodeAPI.OutputsByEd25519Address(ctx, edAddr, false)
+		if err != nil {
+			return nil, 0, false, fmt.Errorf("unable to query outputs of address %s: %w", edAddr, err)
+		}
+		if res.Count >= res.MaxResults {
+			return nil, 0, false, fmt.Errorf("%w: address %s holds at least %d outputs", ErrUTXOIndexerTooManyOutputs, edAddr, res.MaxResults)
+		}
+		if ledgerIndex != nil && *ledgerIndex != res.LedgerIndex {
+			return nil, 0, false, nil
+		}
+		ledgerIndex = &res.LedgerIndex
+
+		for utxoInput, output := range addrOutputs {
+			deposit, err := output.Deposit()
+			if err != nil {
+				return nil, 0, false, err
+			}
+			outputs[utxoInput.ID()] = indexedOutput{addrKey: key, deposit: deposit}
+		}
+	}
+	if ledgerIndex == nil {
+		// without watched addresses the confirmed milestone is the ledger index
+		info, err := idx.nodeAPI.Info(ctx)
+		if err != nil {
+			return nil, 0, false, fmt.Errorf("unable to query node info: %w", err)
+		}
+		return outputs, info.ConfirmedMilestoneIndex, true, nil
+	}
+	return outputs, uint32(*ledgerIndex), true, nil
+}
+
+// SyncTo applies the UTXO diffs of all milestones following the last applied milestone up to the given index.
+func (idx *UTXOIndexer) SyncTo(ctx context.Context, index uint32) error {
+	idx.syncMu.Lock()
+	defer idx.syncMu.Unlock()
+
+	idx.mu.RLock()
+	bootstrapped, ledgerIndex := idx.bootstrapped, idx.ledgerIndex
+	idx.mu.RUnlock()
+	if !bootstrapped {
+		return ErrUTXOIndexerNotBootstrapped
+	}
+
+	for msIndex := ledgerIndex + 1; msIndex <= index; msIndex++ {
+		if err := idx.applyMilestone(ctx, msIndex); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetches and applies the UTXO diff of the given milestone and emits the resulting balance changes.
+func (idx *UTXOIndexer) applyMilestone(ctx context.Context, index uint32) error {
+	diff, err := idx.nodeAPI.MilestoneUTXOChangesByIndex(ctx, index)
+	if err != nil {
+		return fmt.Errorf("unable to query UTXO changes of milestone %d: %w", index, err)
+	}
+
+	changes := make(map[string]*BalanceChange)
+	change := func(key string) *BalanceChange {
+		if c, has := changes[key]; has {
+			return c
+		}
+		watched := idx.addrs[key]
+		c := &BalanceChange{Address: watched.addr, MilestoneIndex: index, PreviousBalance: watched.balance, Balance: watched.balance}
+		changes[key] = c
+		return c
+	}
+
+	// outputs created on watched addresses are fetched before the state is modified
+	created := make(map[iotago.UTXOInputID]indexedOutput)
+	for _, outputIDHex := range diff.CreatedOutputs {
+		utxoInput, err := iotago.OutputIDHex(outputIDHex).AsUTXOInput()
+		if err != nil {
+			return fmt.Errorf("invalid created output ID %s in milestone %d: %w", outputIDHex, index, err)
+		}
+		res, err := idx.nodeAPI.OutputByID(ctx, utxoInput.ID())
+		if err != nil {
+			return fmt.Errorf("unable to query output %s: %w", outputIDHex, err)
+		}
+		output, err := res.Output()
+		if err != nil {
+			return fmt.Errorf("unable to parse output %s: %w", outputIDHex, err)
+		}
+		target, err := output.Target()
+		if err != nil || target == nil {
+			continue
+		}
+		key, err := addrKey(target)
+		if err != nil {
+			return err
+		}
+		if _, watched := idx.addrs[key]; !watched {
+			continue
+		}
+		deposit, err := output.Deposit()
+		if err != nil {
+			return err
+		}
+		created[utxoInput.ID()] = indexedOutput{addrKey: key, deposit: deposit}
+	}
+
+	// the balance changes are computed and sent before the state is modified
+	idx.mu.RLock()
+	for utxoID, output := range created {
+		c := change(output.addrKey)
+		c.Created = append(c.Created, utxoID)
+	}
+	consumed := make(map[iotago.UTXOInputID]indexedOutput)
+	for _, outputIDHex := range diff.ConsumedOutputs {
+		utxoInput, err := iotago.OutputIDHex(outputIDHex).AsUTXOInput()
+		if err != nil {
+			idx.mu.RUnlock()
+			return fmt.Errorf("invalid consumed output ID %s in milestone %d: %w", outputIDHex, index, err)
+		}
+		output, has := created[utxoInput.ID()]
+		if !has {
+			output, has = idx.outputs[utxoInput.ID()]
+		}
+		if !has {
+			continue
+		}
+		c := change(output.addrKey)
+		c.Consumed = append(c.Consumed, utxoInput.ID())
+		consumed[utxoInput.ID()] = output
+	}
+	idx.mu.RUnlock()
+
+	for _, output := range created {
+		changes[output.addrKey].Balance += output.deposit
+	}
+	for _, output := range consumed {
+		changes[output.addrKey].Balance -= output.deposit
+	}
+	if idx.sentChanges == nil {
+		idx.sentChanges = make(map[string]struct{})
+	}
+	for key, c := range changes {
+		if _, sent := idx.sentChanges[key]; sent {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case idx.balanceChanges <- c:
+			idx.sentChanges[key] = struct{}{}
+		}
+	}
+	idx.sentChanges = nil
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for utxoID, output := range created {
+		idx.outputs[utxoID] = output
+	}
+	for utxoID := range consumed {
+		delete(idx.outputs, utxoID)
+	}
+	for key, c := range changes {
+		idx.addrs[key].balance = c.Balance
+	}
+	idx.ledgerIndex = index
+	return nil
+}
+
+// Run applies the milestones received from the given channel, such as NodeEventAPIClient.ConfirmedMilestones(),
+// until the context is done or the channel is closed. Milestones missed in between, for example while the
+// event API was reconnecting, are backfilled. The UTXOIndexer is bootstrapped first if needed.
+func (idx *UTXOIndexer) Run(ctx context.Context, confirmedMilestones <-chan *MilestonePointer) error {
+	if err := idx.bootstrapIfNeeded(ctx); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ms, ok := <-confirmedMilestones:
+			if !ok {
+				return nil
+			}
+			if err := idx.SyncTo(ctx, ms.Index); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Poll applies the confirmed milestones of the node, which it queries in the given interval,
+// until the context is done. The UTXOIndexer is bootstrapped first if needed.
+func (idx *UTXOIndexer) Poll(ctx context.Context, interval time.Duration) error {
+	if err := idx.bootstrapIfNeeded(ctx); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		info, err := idx.nodeAPI.Info(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to query node info: %w", err)
+		}
+		if err := idx.SyncTo(ctx, info.ConfirmedMilestoneIndex); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (idx *UTXOIndexer) bootstrapIfNeeded(ctx context.Context) error {
+	idx.mu.RLock()
+	bootstrapped := idx.bootstrapped
+	idx.mu.RUnlock()
+	if bootstrapped {
+		return nil
+	}
+	return idx.Bootstrap(ctx)
+}
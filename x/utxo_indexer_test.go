@@ -0,0 +1,168 @@
+package iotagox_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/iotaledger/iota.go/v2/x"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// creates a fake node and returns a function creating outputs on it.
+func newFakeLedger(t *testing.T) (*tpkg.FakeNode, func(index uint32, addr *iotago.Ed25519Address, amount uint64) iotago.UTXOInputID) {
+	node := tpkg.NewFakeNode()
+	t.Cleanup(node.Close)
+	return node, func(index uint32, addr *iotago.Ed25519Address, amount uint64) iotago.UTXOInputID {
+		return node.CreateOutput(index, &iotago.SigLockedSingleOutput{Address: addr, Amount: amount}).ID()
+	}
+}
+
+func TestUTXOIndexer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ledger, create := newFakeLedger(t)
+	nodeAPI := ledger.Client()
+	addrA, _ := tpkg.RandEd25519Address()
+	addrB, _ := tpkg.RandEd25519Address()
+	other, _ := tpkg.RandEd25519Address()
+	o1 := create(1, addrA, 100)
+	create(1, other, 5)
+
+	indexer, err := iotagox.NewUTXOIndexer(nodeAPI, []iotago.Address{addrA, addrB})
+	require.NoError(t, err)
+	assert.True(t, errors.Is(indexer.SyncTo(ctx, 1), iotagox.ErrUTXOIndexerNotBootstrapped))
+
+	require.NoError(t, indexer.Bootstrap(ctx))
+	balance, ledgerIndex, err := indexer.Balance(addrA)
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, balance)
+	assert.EqualValues(t, 1, ledgerIndex)
+	_, _, err = indexer.Balance(other)
+	assert.Error(t, err)
+
+	o3 := create(2, addrB, 50)
+	ledger.ConsumeOutput(2, o1)
+	o4 := create(2, addrA, 30)
+	o5 := create(3, addrA, 1)
+
+	// milestone 2 is missed and backfilled
+	confirmedMilestones := make(chan *iotagox.MilestonePointer, 1)
+	confirmedMilestones <- &iotagox.MilestonePointer{Index: 3}
+	close(confirmedMilestones)
+	require.NoError(t, indexer.Run(ctx, confirmedMilestones))
+	assert.EqualValues(t, 3, indexer.LedgerIndex())
+
+	changes := map[string]*iotagox.BalanceChange{}
+	for i := 0; i < 3; i++ {
+		c := <-indexer.BalanceChanges()
+		changes[c.Address.String()+strconv.Itoa(int(c.MilestoneIndex))] = c
+	}
+	assert.Equal(t, &iotagox.BalanceChange{Address: addrA, MilestoneIndex: 2, PreviousBalance: 100, Balance: 30, Created: iotago.UTXOInputIDs{o4}, Consumed: iotago.UTXOInputIDs{o1}}, changes[addrA.String()+"2"])
+	assert.Equal(t, &iotagox.BalanceChange{Address: addrB, MilestoneIndex: 2, PreviousBalance: 0, Balance: 50, Created: iotago.UTXOInputIDs{o3}}, changes[addrB.String()+"2"])
+	assert.Equal(t, &iotagox.BalanceChange{Address: addrA, MilestoneIndex: 3, PreviousBalance: 30, Balance: 31, Created: iotago.UTXOInputIDs{o5}}, changes[addrA.String()+"3"])
+
+	// already applied milestones are ignored
+	require.NoError(t, indexer.SyncTo(ctx, 2))
+	assert.Empty(t, indexer.BalanceChanges())
+
+	// the state of a new indexer matches the applied diffs
+	bootstrapped, err := iotagox.NewUTXOIndexer(nodeAPI, []iotago.Address{addrA, addrB})
+	require.NoError(t, err)
+	require.NoError(t, bootstrapped.Bootstrap(ctx))
+	for _, addr := range []iotago.Address{addrA, addrB} {
+		expected, _, err := indexer.Balance(addr)
+		require.NoError(t, err)
+		balance, _, err := bootstrapped.Balance(addr)
+		require.NoError(t, err)
+		assert.Equal(t, expected, balance)
+	}
+
+	// polling applies new milestones
+	ledger.ConsumeOutput(4, o3)
+	go func() {
+		_ = indexer.Poll(ctx, 10*time.Millisecond)
+	}()
+	select {
+	case c := <-indexer.BalanceChanges():
+		assert.Equal(t, &iotagox.BalanceChange{Address: addrB, MilestoneIndex: 4, PreviousBalance: 50, Balance: 0, Consumed: iotago.UTXOInputIDs{o3}}, c)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no balance change polled")
+	}
+}
+
+func TestUTXOIndexer_LedgerIndex(t *testing.T) {
+	ledger, create := newFakeLedger(t)
+	nodeAPI := ledger.Client()
+	addr, _ := tpkg.RandEd25519Address()
+	create(5, addr, 10)
+	utxoID := create(6, addr, 20)
+
+	indexer, err := iotagox.NewUTXOIndexer(nodeAPI, []iotago.Address{addr}, iotagox.WithUTXOIndexerLedgerIndex(5))
+	require.NoError(t, err)
+	require.NoError(t, indexer.SyncTo(context.Background(), 6))
+	assert.Equal(t, &iotagox.BalanceChange{Address: addr, MilestoneIndex: 6, Balance: 20, Created: iotago.UTXOInputIDs{utxoID}}, <-indexer.BalanceChanges())
+}
+
+func TestUTXOIndexer_TooManyOutputs(t *testing.T) {
+	ledger, create := newFakeLedger(t)
+	addr, _ := tpkg.RandEd25519Address()
+	create(1, addr, 10)
+	create(1, addr, 20)
+	ledger.SetMaxResults(2)
+
+	indexer, err := iotagox.NewUTXOIndexer(ledger.Client(), []iotago.Address{addr})
+	require.NoError(t, err)
+	assert.True(t, errors.Is(indexer.Bootstrap(context.Background()), iotagox.ErrUTXOIndexerTooManyOutputs))
+}
+
+func TestUTXOIndexer_CancelledBalanceChanges(t *testing.T) {
+	ledger, create := newFakeLedger(t)
+	addrA, _ := tpkg.RandEd25519Address()
+	addrB, _ := tpkg.RandEd25519Address()
+	utxoIDs := map[string]iotago.UTXOInputID{
+		addrA.String(): create(2, addrA, 20),
+		addrB.String(): create(2, addrB, 30),
+	}
+
+	indexer, err := iotagox.NewUTXOIndexer(ledger.Client(), []iotago.Address{addrA, addrB},
+		iotagox.WithUTXOIndexerLedgerIndex(1), iotagox.WithUTXOIndexerBalanceChangesBuffer(0))
+	require.NoError(t, err)
+
+	// the milestone is not applied if one of its balance changes can not be sent
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		errs <- indexer.SyncTo(ctx, 2)
+	}()
+	first := <-indexer.BalanceChanges()
+	cancel()
+	assert.True(t, errors.Is(<-errs, context.Canceled))
+	assert.EqualValues(t, 1, indexer.LedgerIndex())
+	balance, _, err := indexer.Balance(first.Address)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, balance)
+
+	// only the balance change which was not sent yet is sent on the next sync
+	go func() {
+		errs <- indexer.SyncTo(context.Background(), 2)
+	}()
+	second := <-indexer.BalanceChanges()
+	require.NoError(t, <-errs)
+	assert.NotEqual(t, first.Address.String(), second.Address.String())
+	assert.Empty(t, indexer.BalanceChanges())
+	assert.EqualValues(t, 2, indexer.LedgerIndex())
+
+	for _, c := range []*iotagox.BalanceChange{first, second} {
+		assert.Equal(t, iotago.UTXOInputIDs{utxoIDs[c.Address.String()]}, c.Created)
+		balance, _, err := indexer.Balance(c.Address)
+		require.NoError(t, err)
+		assert.Equal(t, c.Balance, balance)
+	}
+}